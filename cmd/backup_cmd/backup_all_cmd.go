@@ -2,12 +2,14 @@
 // Deskripsi perintah 'all' untuk membuat backup semua database
 // Author: Hadiyatna Muflihun
 // Tanggal: 2024-10-03
-// Last Modified: 2024-10-03
+// Last Modified: 16 Oktober 2026
 
 package backup_cmd
 
 import (
+	"fmt"
 	"sfDBTools/internal/backup"
+	"sfDBTools/pkg/exitcode"
 	flags "sfDBTools/pkg/flag"
 	"sfDBTools/pkg/parsing"
 	"sfDBTools/pkg/ui"
@@ -27,7 +29,7 @@ Gunakan 'backup all-databases --help' untuk informasi lebih lanjut tentang opsi
   # Membuat backup semua database dengan input non-interaktif
   backup all-databases --config-name local --host localhost --port 3306 --username user --password pass --encryption-key mydb --interactive=false
 `,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Akses logger dan config yang sudah di-inject
 		logger := GetLogger()
 		cfg := GetConfig()
@@ -37,32 +39,29 @@ Gunakan 'backup all-databases --help' untuk informasi lebih lanjut tentang opsi
 		// Resolve configuration from flags
 		BackupAllFlags, err := parsing.ParseBackupAllFlags(cmd)
 		if err != nil {
-			logger.Errorf("Gagal mem-parse flags: %v", err)
-			return
+			return exitcode.AsConfigError(fmt.Errorf("gagal mem-parse flags: %w", err))
 		}
 
 		// Buat service backup dengan state dari flags
 		service := backup.NewService(logger, cfg, BackupAllFlags)
+		defer service.CloseStorage()
 
 		// validasi mode backup
 		if BackupAllFlags.Mode != "single" && BackupAllFlags.Mode != "multi" {
-			logger.Errorf("Mode backup tidak valid: %s. Gunakan 'single' atau 'multi'.", BackupAllFlags.Mode)
-			return
+			return exitcode.AsConfigError(fmt.Errorf("mode backup tidak valid: %s. Gunakan 'single' atau 'multi'", BackupAllFlags.Mode))
 		}
 
 		// Jalankan proses backup berdasarkan mode yang dipilih
 		if BackupAllFlags.Mode == "single" {
 			if err := service.BackupAllDatabases(); err != nil {
-				logger.Errorf("Backup semua database gagal: %v", err)
-				return
+				return fmt.Errorf("backup semua database gagal: %w", err)
 			}
 		} else {
 			if err := service.BackupDatabase(); err != nil {
-				logger.Errorf("Backup database gagal: %v", err)
-				return
+				return fmt.Errorf("backup database gagal: %w", err)
 			}
 		}
-
+		return nil
 	},
 }
 
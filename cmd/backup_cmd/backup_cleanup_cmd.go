@@ -2,7 +2,7 @@
 // Deskripsi : Command untuk cleanup backup manual
 // Author : Hadiyatna Muflihun
 // Tanggal : 2024-10-14
-// Last Modified : 2024-10-14
+// Last Modified : 16 Oktober 2026
 
 package backup_cmd
 
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"sfDBTools/internal/backup"
 	"sfDBTools/internal/structs"
+	"sfDBTools/pkg/exitcode"
 	flags "sfDBTools/pkg/flag"
 	"sfDBTools/pkg/globals"
 	"sfDBTools/pkg/parsing"
@@ -22,11 +23,16 @@ var BackupCleanupCmd = &cobra.Command{
 	Use:   "cleanup",
 	Short: "Bersihkan backup lama berdasarkan retention policy",
 	Long: `Command 'cleanup' membersihkan file backup lama berdasarkan retention policy yang dikonfigurasi.
-Backup yang lebih lama dari retention days akan dihapus secara permanen.
+Dengan policy age (default), file backup yang lebih lama dari retention days dihapus.
+Dengan policy gfs (backup.retention.policy atau --policy), backup set dari summary JSON disimpan per hari,
+minggu, dan bulan (keep_daily/keep_weekly/keep_monthly) ditambah set terakhir yang berhasil untuk setiap
+database; set lain dihapus utuh, begitu juga arsip binlog sebelum posisi GTID set tertua yang disimpan.
+Arsip binlog di <output>/binlogs/ tidak pernah dihapus oleh policy age maupun --pattern.
 
 Contoh penggunaan:
   sfdbtools backup cleanup --cleanup-days 7 --output /mnt/nfs/backup
-  sfdbtools backup cleanup --cleanup --cleanup-days 30`,
+  sfdbtools backup cleanup --cleanup --cleanup-days 30
+  sfdbtools backup cleanup --policy gfs --keep-daily 7 --keep-weekly 4 --keep-monthly 12 --dry-run`,
 
 	Example: `  # Cleanup backup dengan retention 7 hari
   backup cleanup --cleanup-days 7 --output /path/to/backup
@@ -51,8 +57,7 @@ Contoh penggunaan:
 		// Parse flags khusus cleanup
 		cleanupFlags, err := parsing.ParseCleanupFlags(cmd)
 		if err != nil {
-			logger.Errorf("Gagal mem-parse flags: %v", err)
-			return err
+			return exitcode.AsConfigError(fmt.Errorf("gagal mem-parse flags: %w", err))
 		}
 
 		// Debug: Log flags yang diterima
@@ -64,8 +69,7 @@ Contoh penggunaan:
 
 		// Pastikan cleanup diaktifkan
 		if !cleanupFlags.Enabled {
-			logger.Warn("Cleanup tidak diaktifkan, mohon gunakan flag --cleanup untuk mengaktifkan")
-			return fmt.Errorf("cleanup harus diaktifkan dengan flag --cleanup")
+			return exitcode.AsConfigError(fmt.Errorf("cleanup harus diaktifkan dengan flag --cleanup"))
 		}
 
 		// Konversi CleanupFlags ke BackupAllFlags untuk kompatibilitas dengan service
@@ -75,33 +79,43 @@ Contoh penggunaan:
 				Cleanup: structs.CleanupOptions{
 					Enabled:       cleanupFlags.Enabled,
 					RetentionDays: cleanupFlags.RetentionDays,
+					Policy:        firstNonEmpty(cleanupFlags.Policy, cfg.Backup.Retention.Policy),
+					KeepDaily:     firstNonZero(cleanupFlags.KeepDaily, cfg.Backup.Retention.KeepDaily),
+					KeepWeekly:    firstNonZero(cleanupFlags.KeepWeekly, cfg.Backup.Retention.KeepWeekly),
+					KeepMonthly:   firstNonZero(cleanupFlags.KeepMonthly, cfg.Backup.Retention.KeepMonthly),
 				},
+				Lock: cleanupFlags.Lock,
 			},
 		}
 
 		// Buat service backup
 		svc := backup.NewService(logger, cfg, backupAllFlags)
+		defer svc.CloseStorage()
+
+		// Cleanup tidak boleh menghapus file yang sedang ditulis backup yang berjalan
+		release, err := svc.AcquireBackupLock(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("gagal memperoleh lock backup: %w", err)
+		}
+		defer release()
 
 		// Jalankan cleanup berdasarkan mode
 		if cleanupFlags.Pattern != "" {
 			// Cleanup dengan pattern khusus
 			if err := svc.CleanupByPattern(cleanupFlags.Pattern); err != nil {
-				logger.Errorf("Cleanup dengan pattern gagal: %v", err)
-				return err
+				return fmt.Errorf("cleanup dengan pattern gagal: %w", err)
 			}
 		} else {
 			// Cleanup normal
 			if cleanupFlags.DryRun {
 				// Mode dry-run: tampilkan file yang akan dihapus tanpa menghapus
 				if err := svc.CleanupDryRun(); err != nil {
-					logger.Errorf("Cleanup dry-run gagal: %v", err)
-					return err
+					return fmt.Errorf("cleanup dry-run gagal: %w", err)
 				}
 			} else {
 				// Cleanup sebenarnya
 				if err := svc.CleanupOldBackups(); err != nil {
-					logger.Errorf("Cleanup gagal: %v", err)
-					return err
+					return fmt.Errorf("cleanup gagal: %w", err)
 				}
 			}
 		}
@@ -111,6 +125,22 @@ Contoh penggunaan:
 	},
 }
 
+// firstNonEmpty mengembalikan nilai flag jika diisi, selain itu nilai dari konfigurasi
+func firstNonEmpty(flagValue, configValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	return configValue
+}
+
+// firstNonZero mengembalikan nilai flag jika diisi, selain itu nilai dari konfigurasi
+func firstNonZero(flagValue, configValue int) int {
+	if flagValue != 0 {
+		return flagValue
+	}
+	return configValue
+}
+
 func init() {
 	// Daftarkan flags cleanup yang lebih sederhana
 	flags.AddCleanupFlags(BackupCleanupCmd)
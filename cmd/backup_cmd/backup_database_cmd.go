@@ -0,0 +1,74 @@
+// File : cmd/backup_cmd/backup_database_cmd.go
+// Deskripsi : Command untuk membuat backup database tertentu berdasarkan flag --db
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package backup_cmd
+
+import (
+	"fmt"
+	"sfDBTools/internal/backup"
+	"sfDBTools/pkg/exitcode"
+	flags "sfDBTools/pkg/flag"
+	"sfDBTools/pkg/parsing"
+	"sfDBTools/pkg/ui"
+
+	"github.com/spf13/cobra"
+)
+
+// BackupDatabaseCmd adalah command untuk membackup database yang disebutkan pada --db
+var BackupDatabaseCmd = &cobra.Command{
+	Use:   "database",
+	Short: "Membuat backup database tertentu",
+	Long: `Perintah 'database' membuat backup hanya untuk database yang disebutkan pada flag --db.
+Mode 'multi' menghasilkan satu file per database, mode 'single' menggabungkan semua database ke satu file.
+Database yang tidak ditemukan di server akan menggagalkan proses sebelum backup dimulai.`,
+	Example: `  # Backup dua database ke file terpisah
+  sfdbtools backup database --db app,billing --config prod
+
+  # Backup beberapa database ke satu file gabungan
+  sfdbtools backup database --db app,billing,report --mode single --config prod`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Akses logger dan config yang sudah di-inject
+		logger := GetLogger()
+		cfg := GetConfig()
+		ui.ClearScreen()
+		logger.Info("Memulai proses backup database...")
+
+		// Resolve configuration from flags
+		backupDBFlags, err := parsing.ParseBackupDBFlags(cmd)
+		if err != nil {
+			return exitcode.AsConfigError(fmt.Errorf("gagal mem-parse flags: %w", err))
+		}
+
+		if len(backupDBFlags.DBName) == 0 {
+			return exitcode.AsConfigError(fmt.Errorf("flag --db wajib diisi minimal satu nama database"))
+		}
+
+		// validasi mode backup
+		if backupDBFlags.Mode != "single" && backupDBFlags.Mode != "multi" {
+			return exitcode.AsConfigError(fmt.Errorf("mode backup tidak valid: %s. Gunakan 'single' atau 'multi'", backupDBFlags.Mode))
+		}
+
+		// Buat service backup dengan state dari flags
+		service := backup.NewService(logger, cfg, backupDBFlags)
+		defer service.CloseStorage()
+
+		if backupDBFlags.Mode == "single" {
+			err = service.BackupSelectedDatabasesCombined()
+		} else {
+			err = service.BackupDatabase()
+		}
+		if err != nil {
+			return fmt.Errorf("backup database gagal: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	// Flags khusus untuk perintah 'database'
+	flags.AddBackupDBFlags(BackupDatabaseCmd)
+}
@@ -30,7 +30,13 @@ Gunakan 'backup <sub-command> --help' untuk informasi lebih lanjut tentang masin
 func init() {
 	// Tambahkan sub-command ke parent command
 	BackupCMD.AddCommand(BackupCMDAll)
+	BackupCMD.AddCommand(BackupDatabaseCmd)
 	BackupCMD.AddCommand(BackupCleanupCmd)
+	BackupCMD.AddCommand(BackupRestoreCmd)
+	BackupCMD.AddCommand(BackupVerifyCmd)
+	BackupCMD.AddCommand(BackupRetryCmd)
+	BackupCMD.AddCommand(BackupPhysicalCmd)
+	BackupCMD.AddCommand(BackupNotifyCmd)
 }
 
 // GetLogger, GetConfig adalah fungsi helper sederhana untuk modul ini
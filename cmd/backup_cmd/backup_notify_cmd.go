@@ -0,0 +1,50 @@
+// File : cmd/backup_cmd/backup_notify_cmd.go
+// Deskripsi : Command untuk mengirim ulang notifikasi summary backup ke target email/webhook
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package backup_cmd
+
+import (
+	"fmt"
+	"sfDBTools/internal/backup"
+	"sfDBTools/pkg/exitcode"
+	flags "sfDBTools/pkg/flag"
+	"sfDBTools/pkg/globals"
+	"sfDBTools/pkg/parsing"
+
+	"github.com/spf13/cobra"
+)
+
+// BackupNotifyCmd adalah command untuk mengirim notifikasi dari summary backup yang sudah ada
+var BackupNotifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Kirim ulang notifikasi summary backup ke email/webhook",
+	Long: `Command 'notify' membaca summary backup berdasarkan backup ID lalu mengirimnya ke target
+notifikasi yang aktif pada backup.notifications (email SMTP dan webhook).
+Notifikasi yang sama dikirim otomatis setiap kali summary backup disimpan; command ini berguna
+untuk menguji konfigurasi terhadap SMTP sink atau HTTP server lokal, atau mengirim ulang notifikasi yang gagal.`,
+	Example: `  # Kirim notifikasi sesuai aturan 'on' masing-masing target
+  sfdbtools backup notify --backup-id backup_20251015_034246
+
+  # Kirim ke semua target aktif tanpa memeriksa status
+  sfdbtools backup notify --backup-id backup_20251015_034246 --force`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := globals.GetLogger()
+		cfg := globals.GetConfig()
+
+		notifyFlags, err := parsing.ParseBackupNotifyFlags(cmd)
+		if err != nil {
+			return exitcode.AsConfigError(fmt.Errorf("gagal mem-parse flags: %w", err))
+		}
+
+		svc := backup.NewService(logger, cfg, notifyFlags)
+		defer svc.CloseStorage()
+		return svc.ExecuteNotify(cmd.Context(), notifyFlags)
+	},
+}
+
+func init() {
+	flags.AddBackupNotifyFlags(BackupNotifyCmd)
+}
@@ -0,0 +1,64 @@
+// File : cmd/backup_cmd/backup_physical_cmd.go
+// Deskripsi : Command untuk backup fisik instance MariaDB menggunakan mariabackup
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package backup_cmd
+
+import (
+	"context"
+	"fmt"
+	"sfDBTools/internal/backup"
+	"sfDBTools/pkg/exitcode"
+	flags "sfDBTools/pkg/flag"
+	"sfDBTools/pkg/parsing"
+
+	"github.com/spf13/cobra"
+)
+
+// BackupPhysicalCmd adalah command untuk backup fisik (mariabackup --stream=xbstream)
+var BackupPhysicalCmd = &cobra.Command{
+	Use:   "physical",
+	Short: "Membuat backup fisik seluruh instance menggunakan mariabackup",
+	Long: `Perintah 'physical' menjalankan 'mariabackup --backup --stream=xbstream' pada server database
+(mariadb.data_dir) dan menulis stream-nya melalui kompresi dan enkripsi yang sama dengan backup logis.
+Jauh lebih cepat daripada mysqldump untuk instance berukuran ratusan GB.
+
+Backup incremental dibuat dari LSN backup sebelumnya (--incremental-from <backup_id> atau --incremental-lsn).
+Dengan --prepare, stream juga diekstrak (mbstream) ke --prepare-dir lalu 'mariabackup --prepare' dijalankan
+sehingga salinan siap di-copy-back. Untuk incremental, --prepare-dir harus berisi backup full yang sudah
+di-prepare dan perubahan incremental diterapkan ke dalamnya.
+
+Hasil dicatat pada summary dengan mode 'physical' sehingga 'backup summary' dan 'backup cleanup'
+memperlakukannya sama seperti backup logis. Command ini harus dijalankan di host server database.`,
+	Example: `  # Backup fisik full
+  sfdbtools backup physical --config prod
+
+  # Backup full dan siapkan salinan yang siap di-restore
+  sfdbtools backup physical --config prod --prepare --prepare-dir /data/restore/full
+
+  # Backup incremental dari backup fisik sebelumnya
+  sfdbtools backup physical --config prod --incremental-from backup_20251015_010000`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := GetLogger()
+		cfg := GetConfig()
+
+		physicalFlags, err := parsing.ParseBackupPhysicalFlags(cmd)
+		if err != nil {
+			return exitcode.AsConfigError(fmt.Errorf("gagal mem-parse flags: %w", err))
+		}
+
+		svc := backup.NewService(logger, cfg, physicalFlags)
+		defer svc.CloseStorage()
+		if err := svc.ExecutePhysicalBackup(context.Background()); err != nil {
+			return fmt.Errorf("backup fisik gagal: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	flags.AddBackupPhysicalFlags(BackupPhysicalCmd)
+}
@@ -0,0 +1,82 @@
+// File : cmd/backup_cmd/backup_restore_cmd.go
+// Deskripsi : Command untuk me-restore file backup ke server database
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package backup_cmd
+
+import (
+	"context"
+	"fmt"
+	"sfDBTools/internal/backup"
+	"sfDBTools/pkg/exitcode"
+	flags "sfDBTools/pkg/flag"
+	"sfDBTools/pkg/globals"
+	"sfDBTools/pkg/parsing"
+
+	"github.com/spf13/cobra"
+)
+
+// BackupRestoreCmd adalah command untuk me-restore file backup sfDBTools
+var BackupRestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore file backup ke server database",
+	Long: `Command 'restore' mengalirkan file backup sfDBTools ke client mysql pada server tujuan.
+Lapisan enkripsi (AES-256-GCM) dan kompresi dibuka secara otomatis berdasarkan header dan ekstensi file.
+Server tujuan dipilih dari profil dbconfig yang tersimpan.
+
+Sumber restore dapat berupa:
+  - File backup tunggal (--file)
+  - Backup ID dari summary JSON (--backup-id), semua file yang berhasil pada backup tersebut akan di-restore
+
+Dengan --users, file export user dan grant (users_<timestamp>.sql) pada backup set ikut di-restore
+setelah semua file database.
+
+Point-in-time restore (--until atau --until-gtid) me-restore backup dasar yang memiliki posisi GTID
+(--backup-id, atau backup berhasil terbaru sebelum target), lalu me-replay arsip binlog dari
+'binlog archive' sampai waktu/GTID yang diminta. Membutuhkan mysqlbinlog MariaDB 10.8 atau lebih baru.`,
+	Example: `  # Restore satu file backup
+  sfdbtools backup restore --file /mnt/nfs/backup/db_app_20251015.sql.gz.enc --config prod
+
+  # Restore semua file dari backup ID tertentu
+  sfdbtools backup restore --backup-id backup_20251015_034246 --config prod
+
+  # Restore database tertentu dari backup ID tanpa konfirmasi
+  sfdbtools backup restore --backup-id backup_20251015_034246 --db app,billing --config prod --force
+
+  # Restore semua database beserta akun user dan grant
+  sfdbtools backup restore --backup-id backup_20251015_034246 --users --config prod
+
+  # Point-in-time restore sampai waktu tertentu (backup dasar dipilih otomatis)
+  sfdbtools backup restore --until "2025-10-15 14:30:00" --config prod
+
+  # Point-in-time restore sampai posisi GTID tertentu dari backup ID tertentu
+  sfdbtools backup restore --backup-id backup_20251015_034246 --until-gtid 0-1-12345 --config prod`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := globals.GetLogger()
+		cfg := globals.GetConfig()
+
+		// Parse flags dari command
+		restoreFlags, err := parsing.ParseBackupRestoreFlags(cmd)
+		if err != nil {
+			return exitcode.AsConfigError(fmt.Errorf("gagal mem-parse flags: %w", err))
+		}
+
+		logger.Debugf("Restore file: %s, backup ID: %s, database: %v, until: %s, until GTID: %s", restoreFlags.File, restoreFlags.BackupID, restoreFlags.Databases, restoreFlags.Until, restoreFlags.UntilGTID)
+
+		// Buat service backup dengan state dari flags restore
+		svc := backup.NewService(logger, cfg, restoreFlags)
+		defer svc.CloseStorage()
+
+		if err := svc.ExecuteRestore(context.Background()); err != nil {
+			return fmt.Errorf("restore gagal: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	flags.AddBackupRestoreFlags(BackupRestoreCmd)
+}
@@ -0,0 +1,63 @@
+// File : cmd/backup_cmd/backup_retry_cmd.go
+// Deskripsi : Command untuk mem-backup ulang database yang gagal pada backup sebelumnya
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package backup_cmd
+
+import (
+	"context"
+	"fmt"
+	"sfDBTools/internal/backup"
+	"sfDBTools/pkg/exitcode"
+	flags "sfDBTools/pkg/flag"
+	"sfDBTools/pkg/globals"
+	"sfDBTools/pkg/parsing"
+
+	"github.com/spf13/cobra"
+)
+
+// BackupRetryCmd adalah command untuk mem-backup ulang database yang gagal dari summary backup
+var BackupRetryCmd = &cobra.Command{
+	Use:   "retry",
+	Short: "Backup ulang database yang gagal dari backup sebelumnya",
+	Long: `Command 'retry' membaca summary backup (base_directory/summaries/<backup_id>.json) dan
+mem-backup ulang hanya database yang tercatat gagal ke direktori output yang sama, menggunakan
+konfigurasi kompresi, enkripsi, filter tabel, dan verifikasi dari backup asli.
+Summary dan MANIFEST diperbarui di tempat sehingga status gabungan backup menjadi benar,
+dan setiap eksekusi dicatat pada riwayat retry summary.
+
+Kegagalan sementara (koneksi terputus, server has gone away, deadlock, lock wait timeout)
+dicoba ulang otomatis dengan jeda yang dilipatgandakan (--retry-attempts, --retry-backoff).
+Hanya backup mode separate (file per database) yang dapat di-retry.`,
+	Example: `  # Backup ulang database yang gagal pada backup tertentu
+  sfdbtools backup retry --backup-id backup_20251015_034246 --config prod
+
+  # Dengan 5 percobaan per database dan jeda awal 30 detik
+  sfdbtools backup retry --backup-id backup_20251015_034246 --config prod --retry-attempts 5 --retry-backoff 30`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := globals.GetLogger()
+		cfg := globals.GetConfig()
+
+		// Parse flags dari command
+		retryFlags, err := parsing.ParseBackupRetryFlags(cmd)
+		if err != nil {
+			return exitcode.AsConfigError(fmt.Errorf("gagal mem-parse flags: %w", err))
+		}
+
+		logger.Debugf("Retry backup ID: %s, percobaan: %d, backoff: %d detik", retryFlags.BackupID, retryFlags.Retry.MaxAttempts, retryFlags.Retry.BackoffSeconds)
+
+		svc := backup.NewService(logger, cfg, retryFlags)
+		defer svc.CloseStorage()
+		if err := svc.ExecuteRetry(context.Background(), retryFlags); err != nil {
+			return fmt.Errorf("retry backup gagal: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	flags.AddBackupRetryFlags(BackupRetryCmd)
+}
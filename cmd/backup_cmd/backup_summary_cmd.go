@@ -2,13 +2,15 @@
 // Deskripsi : Command untuk menampilkan summary backup
 // Author : Hadiyatna Muflihun
 // Tanggal : 15 Oktober 2025
-// Last Modified : 15 Oktober 2025
+// Last Modified : 16 Oktober 2026
 
 package backup_cmd
 
 import (
+	"fmt"
 	"sfDBTools/internal/backup"
 	"sfDBTools/internal/structs"
+	"sfDBTools/pkg/exitcode"
 	flags "sfDBTools/pkg/flag"
 	"sfDBTools/pkg/globals"
 	"sfDBTools/pkg/parsing"
@@ -33,7 +35,11 @@ Contoh penggunaan:
   sfdbtools backup summary --latest
 
   # Menampilkan summary berdasarkan backup ID
-  sfdbtools backup summary --backup-id=backup_20251015_034246`,
+  sfdbtools backup summary --backup-id=backup_20251015_034246
+
+  # Mencari riwayat backup di katalog target database (tabel backup_runs/backup_files)
+  sfdbtools backup summary --catalog --host=10.0.0.5 --status=failed
+  sfdbtools backup summary --catalog --database=dbsf_nbc_client --from=2026-10-01 --to=2026-10-15`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Ambil dependency yang sudah di-inject
 		logger := globals.GetLogger()
@@ -42,8 +48,7 @@ Contoh penggunaan:
 		// Parse flags dari command
 		backupSummaryFlags, err := parsing.ParseBackupSummaryFlags(cmd)
 		if err != nil {
-			logger.Errorf("Gagal mem-parse flags: %v", err)
-			return err
+			return exitcode.AsConfigError(fmt.Errorf("gagal mem-parse flags: %w", err))
 		}
 
 		// Debug flags
@@ -51,9 +56,13 @@ Contoh penggunaan:
 
 		// Inisialisasi service backup
 		svc := backup.NewService(logger, cfg, backupSummaryFlags)
+		defer svc.CloseStorage()
 
 		// Tentukan aksi berdasarkan flag
-		if backupSummaryFlags.Latest {
+		if backupSummaryFlags.Catalog {
+			// Query katalog backup di target database
+			return svc.ShowCatalog(cmd.Context(), backupSummaryFlags)
+		} else if backupSummaryFlags.Latest {
 			// Tampilkan summary terbaru
 			return svc.ShowLatestSummary()
 		} else if backupSummaryFlags.BackupID != "" {
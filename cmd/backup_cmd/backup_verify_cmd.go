@@ -0,0 +1,53 @@
+// File : cmd/backup_cmd/backup_verify_cmd.go
+// Deskripsi : Command untuk memverifikasi file backup terhadap MANIFEST checksum
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package backup_cmd
+
+import (
+	"fmt"
+	"sfDBTools/internal/backup"
+	"sfDBTools/pkg/exitcode"
+	flags "sfDBTools/pkg/flag"
+	"sfDBTools/pkg/globals"
+	"sfDBTools/pkg/parsing"
+
+	"github.com/spf13/cobra"
+)
+
+// BackupVerifyCmd adalah command untuk memverifikasi backup set terhadap file MANIFEST
+var BackupVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verifikasi file backup terhadap MANIFEST checksum",
+	Long: `Command 'verify' menghitung ulang SHA-256 setiap file yang tercatat pada MANIFEST backup set
+dan melaporkan file yang hilang (missing), berubah (changed), atau file backup lain yang ditulis
+selama rentang waktu backup namun tidak tercatat (extra).
+File MANIFEST disimpan di base_directory/summaries/<backup_id>.MANIFEST.`,
+	Example: `  # Verifikasi berdasarkan path MANIFEST
+  sfdbtools backup verify --manifest /mnt/nfs/backup/summaries/backup_20251015_034246.MANIFEST
+
+  # Verifikasi berdasarkan backup ID
+  sfdbtools backup verify --backup-id backup_20251015_034246`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := globals.GetLogger()
+		cfg := globals.GetConfig()
+
+		// Parse flags dari command
+		verifyFlags, err := parsing.ParseBackupVerifyFlags(cmd)
+		if err != nil {
+			return exitcode.AsConfigError(fmt.Errorf("gagal mem-parse flags: %w", err))
+		}
+
+		logger.Debugf("Verify manifest: %s, backup ID: %s", verifyFlags.Manifest, verifyFlags.BackupID)
+
+		svc := backup.NewService(logger, cfg, verifyFlags)
+		defer svc.CloseStorage()
+		return svc.ExecuteVerify(verifyFlags)
+	},
+}
+
+func init() {
+	flags.AddBackupVerifyFlags(BackupVerifyCmd)
+}
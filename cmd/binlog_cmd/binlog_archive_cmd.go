@@ -0,0 +1,62 @@
+// File : cmd/binlog_cmd/binlog_archive_cmd.go
+// Deskripsi : Command untuk mengarsipkan binary log yang sudah ditutup
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package binlog_cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sfDBTools/internal/binlog"
+	"sfDBTools/pkg/exitcode"
+	flags "sfDBTools/pkg/flag"
+	"sfDBTools/pkg/globals"
+	"sfDBTools/pkg/parsing"
+	"syscall"
+
+	"github.com/spf13/cobra"
+)
+
+// BinlogArchiveCmd adalah command untuk mengarsipkan binlog yang sudah ditutup ke direktori backup
+var BinlogArchiveCmd = &cobra.Command{
+	Use:   "archive",
+	Short: "Arsipkan binary log yang sudah ditutup ke direktori backup",
+	Long: `Command 'archive' menyalin setiap binary log yang sudah ditutup (semua kecuali binlog aktif)
+ke <output>/binlogs/<host>_<port>/ menggunakan kompresi dan enkripsi yang sama dengan backup.
+Binlog yang sudah diarsipkan dicatat pada index.json sehingga tidak disalin ulang.
+
+Binlog dibaca dari --binlog-dir jika tersedia, atau diunduh dari server menggunakan
+'mysqlbinlog --read-from-remote-server'. Gunakan --watch untuk berjalan terus-menerus.`,
+	Example: `  # Arsipkan binlog yang sudah ditutup satu kali
+  sfdbtools binlog archive --config /etc/sfDBTools/config/prod.cnf.enc
+
+  # Berjalan terus dan periksa binlog baru setiap 60 detik
+  sfdbtools binlog archive --config /etc/sfDBTools/config/prod.cnf.enc --watch --interval 60
+
+  # Selalu unduh binlog dari server
+  sfdbtools binlog archive --source remote`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := globals.GetLogger()
+		cfg := globals.GetConfig()
+
+		archiveFlags, err := parsing.ParseBinlogArchiveFlags(cmd)
+		if err != nil {
+			return exitcode.AsConfigError(fmt.Errorf("gagal mem-parse flags: %w", err))
+		}
+
+		// Hentikan pengarsipan dengan rapi saat menerima SIGINT/SIGTERM
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		svc := binlog.NewService(logger, cfg, archiveFlags)
+		return svc.ExecuteArchive(ctx)
+	},
+}
+
+func init() {
+	flags.AddBinlogArchiveFlags(BinlogArchiveCmd)
+}
@@ -0,0 +1,28 @@
+// File : cmd/binlog_cmd/binlog_main_cmd.go
+// Deskripsi : Command utama 'binlog' untuk mengelola arsip binary log
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package binlog_cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// BinlogCMD adalah perintah induk (parent command) untuk semua perintah 'binlog'.
+var BinlogCMD = &cobra.Command{
+	Use:   "binlog",
+	Short: "Mengelola arsip binary log untuk point-in-time recovery",
+	Long: `Perintah 'binlog' digunakan untuk mengarsipkan binary log MariaDB/MySQL ke direktori backup.
+Arsip binlog bersama posisi GTID pada summary backup memungkinkan restore ke titik waktu tertentu
+melalui 'backup restore --until' atau '--until-gtid'.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+func init() {
+	// Tambahkan sub-command ke parent command
+	BinlogCMD.AddCommand(BinlogArchiveCmd)
+}
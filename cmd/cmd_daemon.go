@@ -0,0 +1,61 @@
+// File : cmd/cmd_daemon.go
+// Deskripsi : Sub-command untuk menjalankan penjadwal job bawaan (backup, cleanup, dbscan, arsip binlog)
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sfDBTools/internal/daemon"
+	"sfDBTools/internal/structs"
+	"sfDBTools/pkg/exitcode"
+	flags "sfDBTools/pkg/flag"
+	"sfDBTools/pkg/globals"
+	"sfDBTools/pkg/parsing"
+	"syscall"
+
+	"github.com/spf13/cobra"
+)
+
+// daemonCmd menjalankan job dari bagian 'schedule' pada config sampai menerima SIGINT/SIGTERM
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Jalankan penjadwal job bawaan (backup, cleanup, dbscan, arsip binlog)",
+	Long: `Perintah 'daemon' menjalankan job terjadwal dari bagian 'schedule' pada config menggunakan ekspresi cron.
+Setiap job dijalankan sebagai proses sfdbtools terpisah. Job backup dan cleanup dijalankan bergantian karena
+berbagi lock backup, begitu juga job dbscan; job binlog archive berjalan di antreannya sendiri sehingga tidak
+tertunda oleh backup yang berjalan lama. Job yang sama tidak pernah tumpang tindih.
+Output job ditulis ke <log dir>/daemon/jobs/<nama job>.log dan status terakhir ke <log dir>/daemon/state.json.
+
+Jadwal yang terlewat saat daemon tidak berjalan dijalankan sekali saat daemon dimulai jika keterlambatannya
+masih di dalam schedule.misfire_grace. Saat menerima SIGTERM, daemon berhenti menjadwalkan job baru, meneruskan
+SIGTERM ke job yang sedang berjalan, dan menunggunya selama schedule.shutdown_timeout.
+
+Jika backup.retention.cleanup_enabled aktif dan tidak ada job bertipe cleanup, daemon membuat job
+'retention-cleanup' dari backup.retention.cleanup_schedule.`,
+	Example: `  # Jalankan daemon (biasanya melalui systemd)
+  sfdbtools daemon
+
+  # Tampilkan daftar job, jadwal berikutnya, dan hasil terakhir
+  sfdbtools daemon --list`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		daemonFlags := &structs.DaemonFlags{}
+		if err := parsing.DynamicParseFlags(cmd, daemonFlags); err != nil {
+			return exitcode.AsConfigError(fmt.Errorf("gagal mem-parse flags: %w", err))
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		svc := daemon.NewService(globals.GetLogger(), globals.GetConfig())
+		return svc.Run(ctx, daemonFlags.List)
+	},
+}
+
+func init() {
+	flags.DynamicAddFlags(daemonCmd, &structs.DaemonFlags{})
+}
@@ -2,17 +2,24 @@
 // Deskripsi : Root command untuk aplikasi sfDBTools
 // Author : Hadiyatna Muflihun
 // Tanggal : 2024-10-03
-// Last Modified : 2024-10-03
+// Last Modified : 16 Oktober 2026
 package cmd
 
 import (
 	"fmt"
 	"os"
 	"sfDBTools/cmd/backup_cmd"
+	"sfDBTools/cmd/binlog_cmd"
 	"sfDBTools/cmd/dbconfig_cmd"
 	"sfDBTools/cmd/dbscan_cmd"
 	"sfDBTools/cmd/encrypt_cmd"
+	"sfDBTools/pkg/cmdresult"
+	"sfDBTools/pkg/exitcode"
 	"sfDBTools/pkg/globals"
+	"sfDBTools/pkg/input"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	// Import globals dan sub-command
@@ -23,7 +30,23 @@ var rootCmd = &cobra.Command{
 	Use:   "sfdbtools",
 	Short: "SFDBTools: Database Backup and Management Utility",
 	Long: `SFDBTools adalah utilitas manajemen dan backup MariaDB/MySQL.
-Didesain untuk keandalan dan penggunaan di lingkungan produksi.`,
+Didesain untuk keandalan dan penggunaan di lingkungan produksi.
+
+Exit code:
+  0  berhasil
+  1  gagal
+  2  berhasil sebagian (misalnya sebagian database gagal di-backup atau di-scan)
+  3  konfigurasi, file konfigurasi database, flag, atau argumen perintah tidak valid
+  4  dibatalkan oleh pengguna
+  5  membutuhkan input user pada mode non-interaktif
+  6  proses lain sedang berjalan (lock dipegang)
+
+Gunakan --result-json <path> untuk menulis hasil eksekusi (status, exit code, error, durasi,
+dan detail perintah) dalam format JSON.`,
+
+	// Error dan usage tidak dicetak oleh cobra; finish() satu-satunya tempat yang melaporkan error
+	SilenceUsage:  true,
+	SilenceErrors: true,
 
 	// PersistentPreRunE akan dijalankan SEBELUM perintah apapun.
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
@@ -43,22 +66,139 @@ Didesain untuk keandalan dan penggunaan di lingkungan produksi.`,
 }
 
 // Execute adalah fungsi eksekusi utama yang dipanggil dari main.go.
+// Proses keluar dengan exit code sesuai skema pada pkg/exitcode.
 func Execute(deps *globals.Dependencies) {
 	// 1. INJEKSI DEPENDENSI
 	globals.Deps = deps
 
 	// 2. Eksekusi perintah Cobra
-	if err := rootCmd.Execute(); err != nil {
-		if globals.Deps != nil && globals.Deps.Logger != nil {
-			globals.Deps.Logger.Fatalf("Gagal menjalankan perintah: %v", err)
-		} else {
+	startedAt := time.Now()
+	executed, err := rootCmd.ExecuteC()
+
+	// 3. Tulis hasil JSON jika diminta lalu keluar dengan exit code yang sesuai
+	finish(executed, startedAt, usageError(err))
+}
+
+// cobraUsageErrors adalah awalan pesan error validasi argumen dan flag dari cobra. Cobra tidak
+// menyediakan tipe error untuk kasus ini dan tidak meneruskannya ke FlagErrorFunc.
+var cobraUsageErrors = []string{
+	"unknown command ",
+	"invalid argument ",
+	"requires at least ",
+	"accepts ",
+	"required flag(s) ",
+	"if any flags in the group ",
+	"at least one of the flags in the group ",
+}
+
+// usageError menandai error validasi argumen/flag dari cobra sebagai kesalahan konfigurasi (exit code 3)
+func usageError(err error) error {
+	if err == nil || exitcode.Code(err) != exitcode.Failure {
+		return err
+	}
+	for _, prefix := range cobraUsageErrors {
+		if strings.HasPrefix(err.Error(), prefix) {
+			return exitcode.AsConfigError(err)
+		}
+	}
+	return err
+}
+
+// ExecuteStartupError dipanggil dari main.go jika dependency gagal diinisialisasi sebelum perintah
+// dijalankan (misalnya konfigurasi aplikasi tidak dapat dimuat). Flag tetap di-parse agar hasil
+// --result-json tetap ditulis, lalu proses keluar dengan exit code dari err.
+func ExecuteStartupError(err error) {
+	startedAt := time.Now()
+	executed, flagArgs, findErr := rootCmd.Find(os.Args[1:])
+	if findErr != nil {
+		executed = nil
+	} else {
+		// Error parsing flag diabaikan; yang dilaporkan adalah err dari startup
+		_ = executed.ParseFlags(flagArgs)
+	}
+	finish(executed, startedAt, err)
+}
+
+// finish menulis hasil --result-json, mencatat error, dan keluar dengan exit code dari err
+func finish(executed *cobra.Command, startedAt time.Time, err error) {
+	code := exitcode.Code(err)
+	writeResultJSON(executed, startedAt, code, err)
+
+	if err != nil {
+		switch {
+		case globals.Deps == nil || globals.Deps.Logger == nil:
 			fmt.Fprintf(os.Stderr, "Gagal menjalankan perintah: %v\n", err)
-			os.Exit(1)
+		case code == exitcode.Cancelled:
+			globals.Deps.Logger.Warn("Perintah dibatalkan oleh pengguna")
+		default:
+			globals.Deps.Logger.Errorf("Gagal menjalankan perintah: %v", err)
 		}
 	}
+	if code != exitcode.Success {
+		os.Exit(code)
+	}
+}
+
+// writeResultJSON menulis hasil eksekusi ke path dari --result-json (atau env SFDB_RESULT_JSON)
+func writeResultJSON(executed *cobra.Command, startedAt time.Time, code int, runErr error) {
+	path, _ := rootCmd.PersistentFlags().GetString("result-json")
+	if path == "" {
+		path = os.Getenv("SFDB_RESULT_JSON")
+	}
+	if path == "" {
+		return
+	}
+
+	command := rootCmd.Name()
+	if executed != nil {
+		command = strings.TrimPrefix(executed.CommandPath(), rootCmd.Name()+" ")
+	}
+	finishedAt := time.Now()
+	result := cmdresult.Result{
+		Command:         command,
+		Status:          exitcode.Status(code),
+		ExitCode:        code,
+		StartedAt:       startedAt,
+		FinishedAt:      finishedAt,
+		DurationSeconds: finishedAt.Sub(startedAt).Seconds(),
+		PID:             os.Getpid(),
+		Details:         cmdresult.Details(),
+	}
+	if runErr != nil {
+		result.Error = runErr.Error()
+	}
+	if err := cmdresult.Write(path, result); err != nil {
+		fmt.Fprintf(os.Stderr, "Gagal menulis hasil perintah ke %s: %v\n", path, err)
+	}
+}
+
+// applyInteractiveMode mengaktifkan mode non-interaktif dari --non-interactive/--yes, env, atau
+// secara otomatis jika stdin bukan terminal (cron, systemd, daemon).
+func applyInteractiveMode() {
+	nonInteractive, _ := rootCmd.PersistentFlags().GetBool("non-interactive")
+	yes, _ := rootCmd.PersistentFlags().GetBool("yes")
+	if v, err := strconv.ParseBool(os.Getenv("SFDB_NON_INTERACTIVE")); err == nil && !rootCmd.PersistentFlags().Changed("non-interactive") {
+		nonInteractive = v
+	}
+	if v, err := strconv.ParseBool(os.Getenv("SFDB_ASSUME_YES")); err == nil && !rootCmd.PersistentFlags().Changed("yes") {
+		yes = v
+	}
+	if !nonInteractive && !rootCmd.PersistentFlags().Changed("non-interactive") && !input.StdinIsTerminal() {
+		nonInteractive = true
+	}
+	input.SetNonInteractive(nonInteractive, yes)
 }
 
 func init() {
+	rootCmd.PersistentFlags().Bool("non-interactive", false, "Jangan tampilkan prompt; gunakan nilai default atau gagal dengan exit code 5 (otomatis aktif jika stdin bukan terminal)")
+	rootCmd.PersistentFlags().BoolP("yes", "y", false, "Mode non-interaktif dan jawab ya untuk semua konfirmasi")
+	rootCmd.PersistentFlags().String("result-json", "", "Tulis hasil eksekusi (status, exit code, error, detail) ke file JSON ini (env SFDB_RESULT_JSON)")
+	cobra.OnInitialize(applyInteractiveMode)
+	// Flag yang tidak dikenal atau nilainya tidak valid termasuk kesalahan konfigurasi
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return exitcode.AsConfigError(err)
+	})
+
 	// Tambahkan sub-command yang sudah dibuat
 	// Kita anggap 'versionCmd' ada di cmd/version.go
 	rootCmd.AddCommand(versionCmd) // (Perlu diinisialisasi di cmd/version.go)
@@ -66,4 +206,7 @@ func init() {
 	rootCmd.AddCommand(backup_cmd.BackupCMD)   // (Perlu diinisialisasi di cmd/backup_cmd/backup.go)
 	rootCmd.AddCommand(encrypt_cmd.EncryptCMD) // Command untuk enkripsi dan dekripsi file
 	rootCmd.AddCommand(dbscan_cmd.DbScanCmd)   // Command untuk database scanning
+	rootCmd.AddCommand(binlog_cmd.BinlogCMD)   // Command untuk arsip binlog
+	rootCmd.AddCommand(serveCmd)               // Layanan jangka panjang (endpoint metrik)
+	rootCmd.AddCommand(daemonCmd)              // Penjadwal job bawaan
 }
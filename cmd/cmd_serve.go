@@ -0,0 +1,95 @@
+// File : cmd/cmd_serve.go
+// Deskripsi : Sub-command untuk menjalankan layanan jangka panjang (endpoint metrik Prometheus)
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sfDBTools/internal/backup"
+	"sfDBTools/internal/dbscan"
+	"sfDBTools/internal/structs"
+	"sfDBTools/pkg/exitcode"
+	flags "sfDBTools/pkg/flag"
+	"sfDBTools/pkg/globals"
+	"sfDBTools/pkg/metrics"
+	"sfDBTools/pkg/parsing"
+	"syscall"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultMetricsListen dan defaultMetricsPath dipakai jika bagian metrics pada config tidak diisi
+const (
+	defaultMetricsListen = ":9105"
+	defaultMetricsPath   = "/metrics"
+)
+
+// serveCmd menjalankan layanan yang tetap hidup sampai menerima SIGINT/SIGTERM
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Jalankan layanan sfDBTools (endpoint metrik Prometheus)",
+	Long: `Perintah 'serve' menjalankan layanan jangka panjang sampai dihentikan dengan SIGINT/SIGTERM.
+Dengan --metrics, metrik backup (dihitung dari summary backup setiap scrape) dan metrik database scan
+(dari hasil scan terakhir) disajikan dalam format Prometheus pada metrics.listen dan metrics.path.`,
+	Example: `  # Jalankan endpoint metrik pada alamat dari config (default :9105/metrics)
+  sfdbtools serve --metrics
+
+  # Jalankan endpoint metrik pada alamat lain
+  sfdbtools serve --metrics --listen 127.0.0.1:9200`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := globals.GetLogger()
+		cfg := globals.GetConfig()
+
+		serveFlags := &structs.ServeFlags{}
+		if err := parsing.DynamicParseFlags(cmd, serveFlags); err != nil {
+			return exitcode.AsConfigError(fmt.Errorf("gagal mem-parse flags: %w", err))
+		}
+		if !serveFlags.Metrics {
+			return exitcode.AsConfigError(fmt.Errorf("tidak ada layanan yang diaktifkan, gunakan --metrics"))
+		}
+
+		listen := serveFlags.Listen
+		if listen == "" {
+			listen = cfg.Metrics.Listen
+		}
+		if listen == "" {
+			listen = defaultMetricsListen
+		}
+		path := cfg.Metrics.Path
+		if path == "" {
+			path = defaultMetricsPath
+		}
+
+		backupSvc := backup.NewService(logger, cfg, &structs.BackupSummaryFlags{})
+		defer backupSvc.CloseStorage()
+		scanSvc := dbscan.NewService(logger, cfg)
+
+		gather := func(name string, fn metrics.GatherFunc) metrics.GatherFunc {
+			return func(c *metrics.Collector) error {
+				err := fn(c)
+				if err != nil {
+					logger.Warnf("Gagal mengumpulkan metrik %s: %v", name, err)
+				}
+				return err
+			}
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		logger.Infof("Endpoint metrik berjalan di %s%s", listen, path)
+		return metrics.Serve(ctx, listen, path,
+			gather("backup", backupSvc.GatherBackupMetrics),
+			gather("dbscan", scanSvc.GatherScanMetrics),
+		)
+	},
+}
+
+func init() {
+	flags.DynamicAddFlags(serveCmd, &structs.ServeFlags{})
+}
@@ -2,7 +2,7 @@
 // Deskripsi perintah 'create' untuk membuat file konfigurasi database baru
 // Author: Hadiyatna Muflihun
 // Tanggal: 2024-10-03
-// Last Modified: 2024-10-03
+// Last Modified: 16 Oktober 2026
 
 package dbconfig_cmd
 
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"sfDBTools/internal/dbconfig"
+	"sfDBTools/pkg/exitcode"
 	flags "sfDBTools/pkg/flag"
 	"sfDBTools/pkg/parsing"
 
@@ -38,7 +39,7 @@ Gunakan 'dbconfig create --help' untuk informasi lebih lanjut tentang opsi yang
 		// Resolve configuration from flags
 		DBConfig, err := parsing.ParseDBConfigCreateFlags(cmd)
 		if err != nil {
-			return fmt.Errorf("gagal parse flags: %w", err)
+			return exitcode.AsConfigError(fmt.Errorf("gagal parse flags: %w", err))
 		}
 
 		// Buat service dbconfig dengan state dari flags
@@ -47,7 +48,7 @@ Gunakan 'dbconfig create --help' untuk informasi lebih lanjut tentang opsi yang
 		// Jalankan proses create
 		if err := service.CreateDatabaseConfig(); err != nil {
 			if errors.Is(err, dbconfig.ErrUserCancelled) {
-				return nil
+				return err
 			}
 			return fmt.Errorf("create konfigurasi gagal: %w", err)
 		}
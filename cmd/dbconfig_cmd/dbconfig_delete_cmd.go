@@ -2,7 +2,7 @@
 // Deskripsi perintah 'delete' untuk menghapus file konfigurasi database yang sudah ada
 // Author: Hadiyatna Muflihun
 // Tanggal: 2024-10-03
-// Last Modified: 2024-10-03
+// Last Modified: 16 Oktober 2026
 
 package dbconfig_cmd
 
@@ -31,8 +31,7 @@ var DBConfigDeleteCMD = &cobra.Command{
 		// Jalankan proses delete dengan prompt konfirmasi
 		if err := service.PromptDeleteConfigs(); err != nil {
 			if errors.Is(err, dbconfig.ErrUserCancelled) {
-				logger.Warn("Dibatalkan oleh pengguna.")
-				return nil
+				return err
 			}
 			return fmt.Errorf("penghapusan konfigurasi gagal: %w", err)
 		}
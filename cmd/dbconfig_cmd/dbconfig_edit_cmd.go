@@ -2,7 +2,7 @@
 // Deskripsi perintah 'edit' untuk mengedit file konfigurasi database yang sudah ada
 // Author: Hadiyatna Muflihun
 // Tanggal: 2024-10-03
-// Last Modified: 2024-10-03
+// Last Modified: 16 Oktober 2026
 
 package dbconfig_cmd
 
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"sfDBTools/internal/dbconfig"
+	"sfDBTools/pkg/exitcode"
 	flags "sfDBTools/pkg/flag"
 	"sfDBTools/pkg/parsing"
 
@@ -20,7 +21,7 @@ var DBConfigEditCMD = &cobra.Command{
 	Use:   "edit",
 	Short: "Mengedit file konfigurasi database yang sudah ada",
 	Long:  `Perintah 'edit' memungkinkan pengguna mengedit file konfigurasi database yang sudah ada, baik interaktif maupun non-interaktif.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		logger := GetLogger()
 		cfg := GetConfig()
 
@@ -29,8 +30,7 @@ var DBConfigEditCMD = &cobra.Command{
 		// Parse flags khusus edit
 		DBConfig, err := parsing.ParseDBConfigEditFlags(cmd)
 		if err != nil {
-			logger.Error(fmt.Errorf("gagal parse flags: %w", err))
-			return
+			return exitcode.AsConfigError(fmt.Errorf("gagal parse flags: %w", err))
 		}
 
 		// Buat service dbconfig dengan state dari flags
@@ -39,11 +39,11 @@ var DBConfigEditCMD = &cobra.Command{
 		// Jalankan proses edit
 		if err := service.EditDatabaseConfig(); err != nil {
 			if errors.Is(err, dbconfig.ErrUserCancelled) {
-				logger.Warn("Dibatalkan oleh pengguna.")
+				return err
 			}
-			logger.Error(fmt.Errorf("edit konfigurasi gagal: %w", err))
-			return
+			return fmt.Errorf("edit konfigurasi gagal: %w", err)
 		}
+		return nil
 	},
 }
 
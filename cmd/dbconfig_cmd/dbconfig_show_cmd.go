@@ -2,13 +2,14 @@
 // Deskripsi : Perintah 'show' untuk melihat file konfigurasi database yang sudah ada
 // Author : Hadiyatna Muflihun
 // Tanggal : 2024-10-03
-// Last Modified : 2024-10-03
+// Last Modified : 16 Oktober 2026
 package dbconfig_cmd
 
 import (
 	"fmt"
 	"sfDBTools/internal/dbconfig"
 	"sfDBTools/internal/structs"
+	"sfDBTools/pkg/exitcode"
 	flags "sfDBTools/pkg/flag"
 	"sfDBTools/pkg/parsing"
 
@@ -18,7 +19,7 @@ import (
 var DBConfigShowCMD = &cobra.Command{
 	Use:   "show",
 	Short: "Melihat file konfigurasi database yang sudah ada",
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Akses logger dan config yang sudah di-inject
 		logger := GetLogger()
 		cfg := GetConfig()
@@ -28,17 +29,16 @@ var DBConfigShowCMD = &cobra.Command{
 		// Parse flags khusus show
 		DBConfigShow := &structs.DBConfigShowFlags{}
 		if err := parsing.DynamicParseFlags(cmd, DBConfigShow); err != nil {
-			logger.Error(fmt.Sprintf("Gagal parse flags: %v", err))
-			return
+			return exitcode.AsConfigError(fmt.Errorf("gagal parse flags: %w", err))
 		}
 
 		// Buat service dbconfig tanpa perlu state khusus
 		service := dbconfig.NewService(logger, cfg, DBConfigShow)
 
 		if err := service.ShowDatabaseConfig(); err != nil {
-			logger.Error(fmt.Sprintf("Melihat konfigurasi gagal: %v", err))
-			return
+			return fmt.Errorf("melihat konfigurasi gagal: %w", err)
 		}
+		return nil
 	},
 }
 
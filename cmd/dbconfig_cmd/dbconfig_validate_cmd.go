@@ -2,7 +2,7 @@
 // Deskripsi : Perintah 'validate' untuk memvalidasi file konfigurasi database yang sudah ada
 // Author : Hadiyatna Muflihun
 // Tanggal : 2024-10-03
-// Last Modified : 2024-10-03
+// Last Modified : 16 Oktober 2026
 package dbconfig_cmd
 
 import (
@@ -10,6 +10,7 @@ import (
 
 	"sfDBTools/internal/dbconfig"
 	"sfDBTools/internal/structs"
+	"sfDBTools/pkg/exitcode"
 	flags "sfDBTools/pkg/flag"
 	"sfDBTools/pkg/parsing"
 
@@ -19,7 +20,7 @@ import (
 var DBConfigValidateCMD = &cobra.Command{
 	Use:   "validate",
 	Short: "Memvalidasi file konfigurasi database yang sudah ada",
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Akses logger dan config yang sudah di-inject
 		logger := GetLogger()
 		cfg := GetConfig()
@@ -29,15 +30,16 @@ var DBConfigValidateCMD = &cobra.Command{
 		// Parse flags for validate (reuse show flags struct since it's file/encryption related)
 		DBConfigShow := &structs.DBConfigShowFlags{}
 		if err := parsing.DynamicParseFlags(cmd, DBConfigShow); err != nil {
-			logger.Error(fmt.Sprintf("Gagal parse flags: %v", err))
-			return
+			return exitcode.AsConfigError(fmt.Errorf("gagal parse flags: %w", err))
 		}
 
 		service := dbconfig.NewService(logger, cfg, DBConfigShow)
 		if err := service.ValidateDatabaseConfig(); err != nil {
-			return
+			// File konfigurasi yang tidak valid dilaporkan sebagai kesalahan konfigurasi (exit code 3)
+			return exitcode.AsConfigError(err)
 		}
 		logger.Debug("Validasi konfigurasi selesai.")
+		return nil
 	},
 }
 
@@ -2,13 +2,14 @@
 // Deskripsi : Command untuk dekripsi file
 // Author : Hadiyatna Muflihun
 // Tanggal : 2024-10-14
-// Last Modified : 2024-10-14
+// Last Modified : 16 Oktober 2026
 
 package encrypt_cmd
 
 import (
 	"fmt"
 	"sfDBTools/internal/encrypt"
+	"sfDBTools/pkg/exitcode"
 	flags "sfDBTools/pkg/flag"
 	"sfDBTools/pkg/globals"
 	"sfDBTools/pkg/parsing"
@@ -21,7 +22,7 @@ var DecryptFileCmd = &cobra.Command{
 	Use:   "decrypt",
 	Short: "Dekripsi file yang dienkripsi dengan AES-256-GCM",
 	Long: `Mendekripsi file yang dienkripsi menggunakan algoritma AES-256-GCM.
-Mendukung file yang dienkripsi dengan format OpenSSL.
+Mendukung format stream per chunk (SFDBENC v1) maupun format lama ("Salted__").
 
 Contoh penggunaan:
   sfdbtools encrypt decrypt --input backup.sql.enc --output backup.sql
@@ -45,8 +46,7 @@ Contoh penggunaan:
 		// Parse flags dari command
 		decryptFlags, err := parsing.ParseDecryptFlags(cmd)
 		if err != nil {
-			logger.Errorf("Gagal mem-parse flags: %v", err)
-			return err
+			return exitcode.AsConfigError(fmt.Errorf("gagal mem-parse flags: %w", err))
 		}
 
 		// Debug: Log flags yang diterima
@@ -59,8 +59,7 @@ Contoh penggunaan:
 
 		// Jalankan dekripsi
 		if err := svc.DecryptFile(); err != nil {
-			logger.Errorf("Dekripsi gagal: %v", err)
-			return err
+			return fmt.Errorf("dekripsi gagal: %w", err)
 		}
 
 		return nil
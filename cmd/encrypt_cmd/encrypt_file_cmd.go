@@ -2,13 +2,14 @@
 // Deskripsi : Command untuk enkripsi file
 // Author : Hadiyatna Muflihun
 // Tanggal : 2024-10-14
-// Last Modified : 2024-10-14
+// Last Modified : 16 Oktober 2026
 
 package encrypt_cmd
 
 import (
 	"fmt"
 	"sfDBTools/internal/encrypt"
+	"sfDBTools/pkg/exitcode"
 	flags "sfDBTools/pkg/flag"
 	"sfDBTools/pkg/globals"
 	"sfDBTools/pkg/parsing"
@@ -21,7 +22,7 @@ var EncryptFileCmd = &cobra.Command{
 	Use:   "file",
 	Short: "Enkripsi file menggunakan AES-256-GCM",
 	Long: `Mengenkripsi file menggunakan algoritma AES-256-GCM.
-File hasil enkripsi kompatibel dengan format OpenSSL.
+File dienkripsi per chunk (format SFDBENC v1) sehingga penggunaan memori tetap konstan.
 
 Contoh penggunaan:
   sfdbtools encrypt file --input backup.sql --output backup.sql.enc
@@ -45,8 +46,7 @@ Contoh penggunaan:
 		// Parse flags dari command
 		encryptFlags, err := parsing.ParseEncryptFlags(cmd)
 		if err != nil {
-			logger.Errorf("Gagal mem-parse flags: %v", err)
-			return err
+			return exitcode.AsConfigError(fmt.Errorf("gagal mem-parse flags: %w", err))
 		}
 
 		// Debug: Log flags yang diterima
@@ -59,8 +59,7 @@ Contoh penggunaan:
 
 		// Jalankan enkripsi
 		if err := svc.EncryptFile(); err != nil {
-			logger.Errorf("Enkripsi gagal: %v", err)
-			return err
+			return fmt.Errorf("enkripsi gagal: %w", err)
 		}
 
 		return nil
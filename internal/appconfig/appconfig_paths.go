@@ -0,0 +1,24 @@
+// File : internal/appconfig/appconfig_paths.go
+// Deskripsi : Direktori absolut untuk lock, PID file, dan state proses agar sama untuk cron, daemon, dan sesi interaktif
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+package appconfig
+
+import "path/filepath"
+
+// DefaultLogDir adalah direktori log bawaan jika log.output.file.dir tidak diisi
+const DefaultLogDir = "/var/log/sfDBTools"
+
+// StateDir mengembalikan direktori absolut <log dir>/<name> untuk lock, PID file, dan state proses.
+// log.output.file.dir yang kosong memakai DefaultLogDir; path relatif di-resolve menjadi absolut.
+func (c *Config) StateDir(name string) string {
+	logDir := DefaultLogDir
+	if c != nil && c.Log.Output.File.Dir != "" {
+		logDir = c.Log.Output.File.Dir
+	}
+	if abs, err := filepath.Abs(logDir); err == nil {
+		logDir = abs
+	}
+	return filepath.Join(logDir, name)
+}
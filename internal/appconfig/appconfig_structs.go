@@ -13,6 +13,8 @@ type Config struct {
 	General     GeneralConfig     `yaml:"general"`
 	Log         LogConfig         `yaml:"log"`
 	Mariadb     MariadbConfig     `yaml:"mariadb"`
+	Metrics     MetricsConfig     `yaml:"metrics"`
+	Schedule    ScheduleConfig    `yaml:"schedule"`
 	SystemUsers SystemUsersConfig `yaml:"system_users"`
 }
 
@@ -20,12 +22,19 @@ type Config struct {
 type BackupConfig struct {
 	Compression   CompressionConfig  `yaml:"compression"`
 	MysqlDumpArgs string             `yaml:"mysqldump_args"`
+	Dumper        string             `yaml:"dumper"`
 	Exclude       ExcludeConfig      `yaml:"exclude"`
 	DBList        DBListConfig       `yaml:"db_list"`
 	Retention     RetentionConfig    `yaml:"retention"`
 	Encryption    EncryptionConfig   `yaml:"encryption"`
 	Output        OutputConfig       `yaml:"output"`
 	Verification  VerificationConfig `yaml:"verification"`
+	Retry         RetryConfig        `yaml:"retry"`
+	Performance   PerformanceConfig  `yaml:"performance"`
+	Storage       StorageConfig      `yaml:"storage"`
+	Hooks         HooksConfig        `yaml:"hooks"`
+	Notifications NotifyConfig       `yaml:"notifications"`
+	Catalog       CatalogConfig      `yaml:"catalog"`
 }
 
 type CompressionConfig struct {
@@ -40,15 +49,116 @@ type DBListConfig struct {
 
 type ExcludeConfig struct {
 	Databases       []string `yaml:"databases"`
+	Tables          []string `yaml:"tables"`
+	TableData       []string `yaml:"table_data"`
 	User            bool     `yaml:"user"`
 	SystemDatabases bool     `yaml:"system_databases"`
 	Data            bool     `yaml:"data"`
 }
 
+type RetryConfig struct {
+	MaxAttempts    int `yaml:"max_attempts"`
+	BackoffSeconds int `yaml:"backoff_seconds"`
+}
+
+type PerformanceConfig struct {
+	Parallel     int    `yaml:"parallel"`
+	MaxBandwidth string `yaml:"max_bandwidth"`
+	Adaptive     struct {
+		Enabled           bool `yaml:"enabled"`
+		MaxThreadsRunning int  `yaml:"max_threads_running"`
+		MaxReplicationLag int  `yaml:"max_replication_lag"`
+		CheckInterval     int  `yaml:"check_interval"`
+	} `yaml:"adaptive"`
+}
+
+// StorageConfig menentukan tujuan penyimpanan file backup: local, s3, atau sftp
+type StorageConfig struct {
+	Type string `yaml:"type"`
+	S3   struct {
+		Endpoint   string `yaml:"endpoint"`
+		Region     string `yaml:"region"`
+		Bucket     string `yaml:"bucket"`
+		Prefix     string `yaml:"prefix"`
+		AccessKey  string `yaml:"access_key"`
+		SecretKey  string `yaml:"secret_key"`
+		UseSSL     bool   `yaml:"use_ssl"`
+		PartSizeMB int    `yaml:"part_size_mb"`
+	} `yaml:"s3"`
+	SFTP struct {
+		Host                  string `yaml:"host"`
+		Port                  int    `yaml:"port"`
+		User                  string `yaml:"user"`
+		Password              string `yaml:"password"`
+		PrivateKeyFile        string `yaml:"private_key_file"`
+		KnownHostsFile        string `yaml:"known_hosts_file"`
+		InsecureIgnoreHostKey bool   `yaml:"insecure_ignore_host_key"`
+	} `yaml:"sftp"`
+}
+
+// HooksConfig berisi perintah shell yang dijalankan pada titik-titik proses backup
+type HooksConfig struct {
+	Timeout         int           `yaml:"timeout"` // Batas waktu default per hook dalam detik
+	PreBackup       []HookCommand `yaml:"pre_backup"`
+	PostBackup      []HookCommand `yaml:"post_backup"`
+	OnFailure       []HookCommand `yaml:"on_failure"`
+	PerDatabasePost []HookCommand `yaml:"per_database_post"`
+}
+
+// HookCommand adalah satu perintah hook (dijalankan dengan sh -c)
+type HookCommand struct {
+	Name    string `yaml:"name"`
+	Command string `yaml:"command"`
+	Timeout int    `yaml:"timeout"` // Detik, 0 = memakai hooks.timeout
+}
+
+// NotifyConfig menentukan tujuan notifikasi yang dikirim setelah summary backup disimpan
+type NotifyConfig struct {
+	Email    EmailNotifyConfig     `yaml:"email"`
+	Webhooks []WebhookNotifyConfig `yaml:"webhooks"`
+}
+
+// EmailNotifyConfig adalah target notifikasi email melalui SMTP
+type EmailNotifyConfig struct {
+	Enabled            bool     `yaml:"enabled"`
+	On                 []string `yaml:"on"` // Status pemicu: success, partial, failed, empty (kosong = semua status)
+	Host               string   `yaml:"host"`
+	Port               int      `yaml:"port"`
+	Username           string   `yaml:"username"`
+	Password           string   `yaml:"password"`
+	From               string   `yaml:"from"`
+	To                 []string `yaml:"to"`
+	Format             string   `yaml:"format"`   // html atau text
+	Security           string   `yaml:"security"` // none, starttls, atau tls
+	InsecureSkipVerify bool     `yaml:"insecure_skip_verify"`
+	Timeout            int      `yaml:"timeout"` // Detik
+}
+
+// WebhookNotifyConfig adalah target notifikasi HTTP POST
+type WebhookNotifyConfig struct {
+	Name     string            `yaml:"name"`
+	Enabled  bool              `yaml:"enabled"`
+	On       []string          `yaml:"on"` // Status pemicu: success, partial, failed, empty (kosong = semua status)
+	URL      string            `yaml:"url"`
+	Template string            `yaml:"template"` // json, slack, atau teams
+	Headers  map[string]string `yaml:"headers"`
+	Timeout  int               `yaml:"timeout"` // Detik
+}
+
+// CatalogConfig mengatur pencatatan setiap backup ke tabel katalog di target database (SFDB_DB_*)
+type CatalogConfig struct {
+	Enabled bool `yaml:"enabled"`
+	Timeout int  `yaml:"timeout"` // Detik, batas waktu koneksi dan penulisan katalog
+}
+
 type RetentionConfig struct {
 	CleanupEnabled  bool   `yaml:"cleanup_enabled"`
 	CleanupSchedule string `yaml:"cleanup_schedule"`
 	Days            int    `yaml:"days"`
+	Policy          string `yaml:"policy"`       // gfs (berbasis backup set) atau age (mtime file); kosong = age
+	KeepDaily       int    `yaml:"keep_daily"`   // Jumlah hari terakhir yang backup set terbarunya disimpan
+	KeepWeekly      int    `yaml:"keep_weekly"`  // Jumlah minggu terakhir yang backup set terbarunya disimpan
+	KeepMonthly     int    `yaml:"keep_monthly"` // Jumlah bulan terakhir yang backup set terbarunya disimpan
 }
 
 type EncryptionConfig struct {
@@ -70,6 +180,7 @@ type OutputConfig struct {
 	} `yaml:"structure"`
 	TempDirectory    string `yaml:"temp_directory"`
 	CaptureGtid      bool   `yaml:"capture_gtid"`
+	Coordinates      string `yaml:"coordinates"`
 	CreateBackupInfo bool   `yaml:"create_backup_info"`
 }
 
@@ -135,6 +246,31 @@ type MariadbConfig struct {
 	Version             string `yaml:"version"`
 }
 
+// Struct untuk bagian 'metrics'
+type MetricsConfig struct {
+	TextfileDir string `yaml:"textfile_dir"` // Direktori textfile collector node_exporter (kosong = tidak menulis file .prom)
+	Listen      string `yaml:"listen"`       // Alamat listen 'sfdbtools serve --metrics'
+	Path        string `yaml:"path"`         // Path endpoint HTTP metrik
+}
+
+// Struct untuk bagian 'schedule' (dijalankan oleh 'sfdbtools daemon')
+type ScheduleConfig struct {
+	Timezone        string              `yaml:"timezone"`         // Zona waktu ekspresi cron (kosong = lokal)
+	MisfireGrace    int                 `yaml:"misfire_grace"`    // Detik keterlambatan maksimal agar job yang terlewat tetap dijalankan
+	ShutdownTimeout int                 `yaml:"shutdown_timeout"` // Detik menunggu job berjalan selesai setelah SIGTERM sebelum dihentikan paksa
+	Jobs            []ScheduleJobConfig `yaml:"jobs"`
+}
+
+// ScheduleJobConfig adalah satu job terjadwal yang menjalankan sub-command sfdbtools
+type ScheduleJobConfig struct {
+	Name    string   `yaml:"name"`
+	Enabled bool     `yaml:"enabled"`
+	Type    string   `yaml:"type"`    // backup, cleanup, dbscan, atau binlog
+	Cron    string   `yaml:"cron"`    // Ekspresi cron 5 field atau @hourly/@daily/@weekly/@monthly
+	Args    []string `yaml:"args"`    // Argumen tambahan setelah sub-command sesuai type
+	Timeout int      `yaml:"timeout"` // Detik, 0 = tanpa batas
+}
+
 // Struct untuk bagian 'system_users'
 type SystemUsersConfig struct {
 	Users []string `yaml:"users"`
@@ -0,0 +1,208 @@
+// File : internal/backup/backup_catalog.go
+// Deskripsi : Mencatat summary backup ke katalog target database dan menampilkan hasil pencarian katalog
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sfDBTools/internal/structs"
+	"sfDBTools/pkg/database"
+	"sfDBTools/pkg/ui"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+)
+
+// defaultCatalogTimeout dipakai jika backup.catalog.timeout tidak diisi
+const defaultCatalogTimeout = 30 * time.Second
+
+// catalogTimeout mengembalikan batas waktu operasi katalog
+func (s *Service) catalogTimeout() time.Duration {
+	if s.Config != nil && s.Config.Backup.Catalog.Timeout > 0 {
+		return time.Duration(s.Config.Backup.Catalog.Timeout) * time.Second
+	}
+	return defaultCatalogTimeout
+}
+
+// openCatalog membuka koneksi ke target database dan memastikan skema katalog sudah terbaru
+func (s *Service) openCatalog(ctx context.Context) (*database.Client, error) {
+	client, err := database.ConnectTargetDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.MigrateCatalog(ctx); err != nil {
+		client.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+// recordCatalog menyimpan summary ke katalog jika backup.catalog.enabled aktif.
+// Kegagalan hanya dicatat sebagai warning karena summary JSON tetap menjadi sumber utama.
+func (s *Service) recordCatalog(ctx context.Context, summary *BackupSummary) {
+	if s.Config == nil || !s.Config.Backup.Catalog.Enabled {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.catalogTimeout())
+	defer cancel()
+
+	client, err := s.openCatalog(ctx)
+	if err != nil {
+		s.Logger.Warnf("Gagal membuka katalog backup: %v", err)
+		return
+	}
+	defer client.Close()
+
+	run, files := catalogRecords(summary)
+	if _, err := client.SaveBackupRun(ctx, run, files); err != nil {
+		s.Logger.Warnf("Gagal mencatat backup %s ke katalog: %v", summary.BackupID, err)
+		return
+	}
+	s.Logger.Infof("Backup %s dicatat ke katalog (%d database)", summary.BackupID, len(files))
+}
+
+// catalogRecords mengubah summary menjadi baris backup_runs dan backup_files
+func catalogRecords(summary *BackupSummary) (database.CatalogRun, []database.CatalogFile) {
+	agentHost, _ := os.Hostname()
+	stats := summary.DatabaseStats
+
+	var duration float64
+	if !summary.EndTime.IsZero() {
+		duration = summary.EndTime.Sub(summary.StartTime).Seconds()
+	}
+
+	run := database.CatalogRun{
+		BackupID:            summary.BackupID,
+		ServerHost:          summary.ServerInfo.Host,
+		ServerPort:          summary.ServerInfo.Port,
+		AgentHost:           agentHost,
+		BackupMode:          summary.BackupMode,
+		Status:              summary.Status,
+		StartTime:           summary.StartTime,
+		EndTime:             summary.EndTime,
+		DurationSeconds:     duration,
+		TotalDatabases:      stats.TotalDatabases,
+		SuccessfulDatabases: stats.SuccessfulBackups,
+		WarningDatabases:    stats.SuccessWithWarnings,
+		FailedDatabases:     stats.FailedBackups,
+		TotalFiles:          summary.OutputInfo.TotalFiles,
+		TotalSizeBytes:      summary.OutputInfo.TotalSize,
+		OutputDirectory:     summary.OutputInfo.OutputDirectory,
+		ManifestFile:        summary.ManifestFile,
+		GTIDPosition:        summary.GTIDPosition,
+		Errors:              strings.Join(summary.Errors, "\n"),
+	}
+
+	files := make([]database.CatalogFile, 0, len(summary.SuccessfulDatabases)+len(summary.FailedDatabases))
+	for _, info := range summary.SuccessfulDatabases {
+		file := database.CatalogFile{
+			DatabaseName:      info.DatabaseName,
+			Status:            info.Status,
+			FilePath:          info.OutputFile,
+			FileSizeBytes:     info.FileSize,
+			OriginalSizeBytes: info.OriginalDBSize,
+			CompressionRatio:  info.CompressionRatio,
+			Duration:          info.Duration,
+			Attempts:          info.Attempts,
+			Warnings:          info.Warnings,
+		}
+		if info.Verification != nil {
+			file.SHA256 = info.Verification.FileSHA256
+		}
+		if info.Coordinates != nil {
+			file.GTIDPosition = info.Coordinates.GTIDPosition
+		}
+		files = append(files, file)
+	}
+	for _, failed := range summary.FailedDatabases {
+		files = append(files, database.CatalogFile{
+			DatabaseName: failed.DatabaseName,
+			Status:       "failed",
+			Attempts:     failed.Attempts,
+			Error:        failed.Error,
+		})
+	}
+	return run, files
+}
+
+// ShowCatalog menampilkan riwayat backup dari katalog sesuai filter pada flags
+func (s *Service) ShowCatalog(ctx context.Context, summaryFlags *structs.BackupSummaryFlags) error {
+	filter := database.CatalogFilter{
+		Host:     summaryFlags.Host,
+		Database: summaryFlags.Database,
+		Status:   summaryFlags.Status,
+		Limit:    summaryFlags.Limit,
+	}
+	var err error
+	if filter.From, err = parseCatalogDate(summaryFlags.From, false); err != nil {
+		return fmt.Errorf("nilai --from tidak valid: %w", err)
+	}
+	if filter.To, err = parseCatalogDate(summaryFlags.To, true); err != nil {
+		return fmt.Errorf("nilai --to tidak valid: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.catalogTimeout())
+	defer cancel()
+
+	client, err := s.openCatalog(ctx)
+	if err != nil {
+		return fmt.Errorf("gagal membuka katalog backup: %w", err)
+	}
+	defer client.Close()
+
+	runs, err := client.QueryBackupRuns(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if len(runs) == 0 {
+		ui.PrintWarning("Tidak ada backup di katalog yang cocok dengan filter.")
+		return nil
+	}
+
+	ui.PrintHeader("KATALOG BACKUP")
+	headers := []string{"Backup ID", "Server", "Status", "Mode", "Mulai", "Durasi", "DB OK/Warn/Gagal", "Total Size"}
+	var rows [][]string
+	for _, run := range runs {
+		rows = append(rows, []string{
+			run.BackupID,
+			fmt.Sprintf("%s:%d", run.ServerHost, run.ServerPort),
+			ui.GetStatusIcon(run.Status) + " " + run.Status,
+			run.BackupMode,
+			run.StartTime.Format("2006-01-02 15:04"),
+			ui.FormatDuration(time.Duration(run.DurationSeconds * float64(time.Second))),
+			fmt.Sprintf("%d/%d/%d", run.SuccessfulDatabases-run.WarningDatabases, run.WarningDatabases, run.FailedDatabases),
+			humanize.Bytes(uint64(run.TotalSizeBytes)),
+		})
+	}
+	ui.FormatTable(headers, rows)
+	if summaryFlags.Limit > 0 && len(runs) == summaryFlags.Limit {
+		ui.PrintInfo(fmt.Sprintf("Menampilkan %d backup terbaru; gunakan --limit untuk menampilkan lebih banyak.", len(runs)))
+	}
+	return nil
+}
+
+// parseCatalogDate mengurai tanggal filter katalog dalam zona waktu lokal.
+// Untuk batas akhir dengan format tanggal saja, seluruh hari tersebut ikut disertakan.
+func parseCatalogDate(value string, endOfRange bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		if endOfRange {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02 15:04", time.RFC3339} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("format tanggal '%s' tidak dikenali (gunakan YYYY-MM-DD atau \"YYYY-MM-DD HH:MM\")", value)
+}
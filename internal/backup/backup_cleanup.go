@@ -2,14 +2,14 @@
 // Deskripsi : Fungsi terpadu untuk cleanup backup berdasarkan retention policy.
 // Author : Hadiyatna Muflihun
 // Tanggal : 2024-10-14
-// Last Modified : 2024-10-15 (Simplified)
+// Last Modified : 16 Oktober 2026
 
 package backup
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
+	"sfDBTools/pkg/storage"
 	"sort"
 	"strings"
 	"time"
@@ -24,12 +24,18 @@ const (
 
 var (
 	// backupExtensions mendefinisikan ekstensi file yang dianggap sebagai file backup.
-	backupExtensions = []string{".sql", ".gz", ".zst", ".lz4", ".enc"}
+	backupExtensions = []string{".sql", ".gz", ".zst", ".zlib", ".lz4", ".xz", ".bz2", ".xbstream", ".enc"}
 )
 
 // CleanupOldBackups menjalankan proses penghapusan semua backup lama di direktori.
 func (s *Service) CleanupOldBackups() error {
-	return s.cleanupCore(false, "") // dryRun=false, tanpa pattern
+	if err := s.cleanupCore(false, ""); err != nil { // dryRun=false, tanpa pattern
+		return err
+	}
+	if s.BackupOptions.Cleanup.Enabled {
+		s.markCleanupDone()
+	}
+	return nil
 }
 
 // CleanupDryRun menampilkan preview semua backup lama yang akan dihapus.
@@ -63,6 +69,15 @@ func (s *Service) cleanupCore(dryRun bool, pattern string) error {
 		s.Logger.Infof("%s proses cleanup backup...", mode)
 	}
 
+	policy, err := s.retentionPolicy()
+	if err != nil {
+		return err
+	}
+	// Pattern selalu memakai pencocokan file; tanpa pattern, policy gfs bekerja per backup set
+	if pattern == "" && policy == retentionPolicyGFS {
+		return s.cleanupGFS(dryRun)
+	}
+
 	retentionDays := s.BackupOptions.Cleanup.RetentionDays
 	if retentionDays <= 0 {
 		s.Logger.Info("Retention days tidak valid, melewati proses.")
@@ -101,33 +116,48 @@ func (s *Service) scanFiles(baseDir string, cutoff time.Time, pattern string) ([
 		pattern = "**/*"
 	}
 
-	// Satu panggilan untuk menemukan semua file yang cocok, di mana pun lokasinya!
-	paths, err := doublestar.Glob(os.DirFS(baseDir), pattern)
+	if !doublestar.ValidatePattern(pattern) {
+		return nil, fmt.Errorf("pattern glob tidak valid: %s", pattern)
+	}
+
+	// Daftar file diambil dari storage backup (local, S3, atau SFTP) lalu dicocokkan dengan pattern
+	store, err := s.getStorage()
 	if err != nil {
-		return nil, fmt.Errorf("gagal memproses pattern glob %s: %w", pattern, err)
+		return nil, err
+	}
+	files, err := store.List(baseDir)
+	if err != nil {
+		if storage.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("gagal membaca daftar file di %s: %w", baseDir, err)
 	}
 
 	var filesToDelete []BackupFileInfo
-	for _, path := range paths {
-		// Karena Glob mengembalikan path relatif, kita gabungkan lagi dengan baseDir
-		fullPath := filepath.Join(baseDir, path)
-
-		info, err := os.Stat(fullPath)
+	for _, file := range files {
+		// Pattern dicocokkan dengan path relatif terhadap baseDir
+		relPath, err := filepath.Rel(baseDir, file.Path)
 		if err != nil {
-			s.Logger.Errorf("Gagal mendapatkan info file %s: %v", fullPath, err)
+			continue
+		}
+		// Arsip binlog hanya dihapus oleh retensi binlog agar index.json tetap sesuai dengan isi direktori
+		if inBinlogArchive(relPath) {
+			continue
+		}
+		if match, _ := doublestar.Match(pattern, filepath.ToSlash(relPath)); !match {
 			continue
 		}
 
-		// Lewati direktori dan file yang tidak sesuai kriteria
-		if info.IsDir() || (pattern == "**/*" && !s.isBackupFile(fullPath)) {
+		// Lewati file yang tidak sesuai kriteria
+		if pattern == "**/*" && !s.isBackupFile(file.Path) {
 			continue
 		}
 
-		if info.ModTime().Before(cutoff) {
+		if file.ModTime.Before(cutoff) {
 			filesToDelete = append(filesToDelete, BackupFileInfo{
-				Path:    fullPath,
-				ModTime: info.ModTime(),
-				Size:    info.Size(),
+				Path:    file.Path,
+				ModTime: file.ModTime,
+				Size:    file.Size,
 			})
 		}
 	}
@@ -146,8 +176,14 @@ func (s *Service) performDeletion(files []BackupFileInfo) {
 	var deletedCount int
 	var totalFreedSize int64
 
+	store, err := s.getStorage()
+	if err != nil {
+		s.Logger.Errorf("Gagal membuka storage backup: %v", err)
+		return
+	}
+
 	for _, file := range files {
-		if err := os.Remove(file.Path); err != nil {
+		if err := store.Remove(file.Path); err != nil {
 			s.Logger.Errorf("Gagal menghapus file %s: %v", file.Path, err)
 			continue
 		}
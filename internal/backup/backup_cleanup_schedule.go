@@ -0,0 +1,70 @@
+// File : internal/backup/backup_cleanup_schedule.go
+// Deskripsi : Penerapan retention.cleanup_schedule pada cleanup otomatis sebelum backup
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package backup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sfDBTools/pkg/cron"
+	"strings"
+	"time"
+)
+
+// cleanupStampFile mencatat waktu cleanup terakhir yang berhasil (di direktori lock backup)
+const cleanupStampFile = "last_cleanup"
+
+// cleanupDue menentukan apakah cleanup sebelum backup perlu dijalankan menurut cleanup_schedule.
+// Jadwal kosong, "always", atau "before_backup" berarti cleanup dijalankan setiap backup.
+// Selain itu cleanup dijalankan jika jadwal cron sudah jatuh tempo sejak cleanup terakhir.
+func (s *Service) cleanupDue(now time.Time) (bool, string) {
+	schedule := strings.TrimSpace(s.BackupOptions.Cleanup.Scheduled)
+	switch strings.ToLower(schedule) {
+	case "", "always", "before_backup":
+		return true, ""
+	}
+
+	sched, err := cron.Parse(schedule)
+	if err != nil {
+		s.Logger.Warnf("cleanup_schedule tidak valid (%v), cleanup dijalankan setiap backup", err)
+		return true, ""
+	}
+	last, err := s.lastCleanup()
+	if err != nil || last.IsZero() {
+		return true, ""
+	}
+	next := sched.Next(last)
+	if next.IsZero() || !next.After(now) {
+		return true, ""
+	}
+	return false, fmt.Sprintf("cleanup terakhir %s, jadwal berikutnya %s (cleanup_schedule: %s)",
+		last.Format(timeFormat), next.Format(timeFormat), schedule)
+}
+
+// lastCleanup membaca waktu cleanup terakhir; waktu nol jika belum pernah
+func (s *Service) lastCleanup() (time.Time, error) {
+	data, err := os.ReadFile(filepath.Join(s.lockDir(), cleanupStampFile))
+	if os.IsNotExist(err) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339, strings.TrimSpace(string(data)))
+}
+
+// markCleanupDone mencatat waktu cleanup yang berhasil agar backup berikutnya mengikuti cleanup_schedule
+func (s *Service) markCleanupDone() {
+	path := filepath.Join(s.lockDir(), cleanupStampFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		s.Logger.Warnf("Gagal mencatat waktu cleanup: %v", err)
+		return
+	}
+	if err := os.WriteFile(path, []byte(time.Now().Format(time.RFC3339)+"\n"), 0644); err != nil {
+		s.Logger.Warnf("Gagal mencatat waktu cleanup: %v", err)
+	}
+}
@@ -0,0 +1,151 @@
+// File : internal/backup/backup_concurrency.go
+// Deskripsi : Pengaturan jumlah worker backup, pembatas bandwidth, dan mode adaptive berdasarkan beban server
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sfDBTools/pkg/fs"
+	"sync"
+	"time"
+)
+
+// defaultAdaptiveCheckInterval dipakai jika backup.performance.adaptive.check_interval tidak diisi
+const defaultAdaptiveCheckInterval = 15 * time.Second
+
+// resolveWorkerCount menentukan jumlah worker dari --parallel (0 = jumlah CPU), tidak lebih dari jumlah pekerjaan
+func (s *Service) resolveWorkerCount(jobCount int) int {
+	workers := s.BackupOptions.Concurrency.Parallel
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if jobCount < workers {
+		workers = jobCount // Tidak perlu lebih banyak worker dari jumlah pekerjaan
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	return workers
+}
+
+// buildBandwidthLimiter membuat pembatas kecepatan tulis bersama untuk semua worker, atau nil jika tanpa batas
+func (s *Service) buildBandwidthLimiter() (*fs.RateLimiter, error) {
+	bytesPerSecond, err := fs.ParseBandwidth(s.BackupOptions.Concurrency.MaxBandwidth)
+	if err != nil || bytesPerSecond == 0 {
+		return nil, err
+	}
+	return fs.NewRateLimiter(bytesPerSecond), nil
+}
+
+// concurrencyGate membatasi jumlah pekerjaan yang berjalan bersamaan; batasnya dapat diubah saat berjalan
+type concurrencyGate struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	limit  int
+	max    int
+	active int
+}
+
+func newConcurrencyGate(max int) *concurrencyGate {
+	g := &concurrencyGate{limit: max, max: max}
+	g.cond = sync.NewCond(&g.mu)
+	return g
+}
+
+// acquire menunggu sampai jumlah pekerjaan aktif di bawah batas
+func (g *concurrencyGate) acquire() {
+	g.mu.Lock()
+	for g.active >= g.limit {
+		g.cond.Wait()
+	}
+	g.active++
+	g.mu.Unlock()
+}
+
+func (g *concurrencyGate) release() {
+	g.mu.Lock()
+	g.active--
+	g.mu.Unlock()
+	g.cond.Broadcast()
+}
+
+// setLimit mengubah batas menjadi antara 1 dan max
+func (g *concurrencyGate) setLimit(limit int) {
+	if limit < 1 {
+		limit = 1
+	}
+	if limit > g.max {
+		limit = g.max
+	}
+	g.mu.Lock()
+	g.limit = limit
+	g.mu.Unlock()
+	g.cond.Broadcast()
+}
+
+func (g *concurrencyGate) currentLimit() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.limit
+}
+
+// monitorServerLoad memeriksa Threads_running dan lag replikasi secara berkala sampai ctx selesai.
+// Batas worker dibagi dua saat ambang batas terlampaui dan dinaikkan satu per satu setelah beban turun.
+func (s *Service) monitorServerLoad(ctx context.Context, gate *concurrencyGate) {
+	opts := s.BackupOptions.Concurrency
+	interval := time.Duration(opts.CheckInterval) * time.Second
+	if interval <= 0 {
+		interval = defaultAdaptiveCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		overloaded, reason := s.serverOverloaded(ctx)
+		limit := gate.currentLimit()
+		switch {
+		case overloaded && limit > 1:
+			gate.setLimit(limit / 2)
+			s.Logger.Warnf("Mode adaptive: %s, worker aktif diturunkan %d -> %d", reason, limit, gate.currentLimit())
+		case !overloaded && limit < gate.max:
+			gate.setLimit(limit + 1)
+			s.Logger.Infof("Mode adaptive: beban server normal, worker aktif dinaikkan %d -> %d", limit, gate.currentLimit())
+		}
+	}
+}
+
+// serverOverloaded mengembalikan true beserta alasannya jika beban server melewati ambang batas
+func (s *Service) serverOverloaded(ctx context.Context) (bool, string) {
+	opts := s.BackupOptions.Concurrency
+
+	if opts.MaxThreadsRunning > 0 {
+		running, err := s.Client.GetThreadsRunning(ctx)
+		if err != nil {
+			s.Logger.Debugf("Mode adaptive: %v", err)
+		} else if running > opts.MaxThreadsRunning {
+			return true, fmt.Sprintf("Threads_running %d melewati %d", running, opts.MaxThreadsRunning)
+		}
+	}
+
+	if opts.MaxReplicationLag > 0 {
+		lag, isReplica, err := s.Client.GetReplicationLag(ctx)
+		if err != nil {
+			s.Logger.Debugf("Mode adaptive: %v", err)
+		} else if isReplica && lag > int64(opts.MaxReplicationLag) {
+			return true, fmt.Sprintf("lag replikasi %d detik melewati %d", lag, opts.MaxReplicationLag)
+		}
+	}
+
+	return false, ""
+}
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"sfDBTools/internal/structs"
 	"sfDBTools/pkg/ui"
+	"strings"
 )
 
 // DisplaySummaryTable adalah "controller" yang mengatur tampilan summary.
@@ -17,8 +18,10 @@ func (s *Service) DisplaySummaryTable(summary *BackupSummary) {
 	s.displayOutputInfo(summary)
 	s.displayConfig(summary)
 	s.displaySuccessfulDBs(summary)
+	s.displayCoordinates(summary)
 	s.displayDatabaseDetails(summary)
 	s.displayFailedDBs(summary)
+	s.displayRetryHistory(summary)
 	s.displayErrors(summary)
 }
 
@@ -33,6 +36,21 @@ func (s *Service) displayGeneralInfo(summary *BackupSummary) {
 		{"Waktu Selesai", summary.EndTime.Format(displayTimeFormat)},
 		{"Durasi", summary.Duration},
 	}
+	if summary.GTIDPosition != "" {
+		data = append(data, []string{"Posisi GTID", summary.GTIDPosition})
+	}
+	if p := summary.Physical; p != nil {
+		data = append(data,
+			[]string{"Tipe Backup Fisik", p.BackupType},
+			[]string{"LSN", fmt.Sprintf("%s -> %s", p.FromLSN, p.ToLSN)},
+		)
+		if p.BaseBackupID != "" {
+			data = append(data, []string{"Basis Incremental", p.BaseBackupID})
+		}
+		if p.Prepared {
+			data = append(data, []string{"Prepared", p.PrepareDir})
+		}
+	}
 	ui.FormatTable([]string{"Property", "Value"}, data)
 }
 
@@ -83,6 +101,12 @@ func (s *Service) displayOutputInfo(summary *BackupSummary) {
 		{"Total File", fmt.Sprintf("%d", summary.OutputInfo.TotalFiles)},
 		{"Total Ukuran", summary.OutputInfo.TotalSizeHuman},
 	}
+	if summary.ManifestFile != "" {
+		data = append(data, []string{"Manifest", summary.ManifestFile})
+	}
+	if u := summary.UsersExport; u != nil {
+		data = append(data, []string{"User & Grant", fmt.Sprintf("%s (%d akun, %s)", u.OutputFile, u.AccountCount, u.FileSizeHuman)})
+	}
 	ui.FormatTable([]string{"Property", "Value"}, data)
 }
 
@@ -103,9 +127,29 @@ func (s *Service) displayConfig(summary *BackupSummary) {
 		cleanupStatus = "✅ Enabled"
 		cleanupDetail = fmt.Sprintf("%d hari", summary.BackupConfig.RetentionDays)
 	}
+	var verifyStatus, verifyDetail = "❌ Disabled", "-"
+	if summary.BackupConfig.VerifyAfterWrite || summary.BackupConfig.CompareChecksums {
+		verifyStatus = "✅ Enabled"
+		verifyDetail = "Trailer dump + SHA-256"
+		if summary.BackupConfig.CompareChecksums {
+			verifyDetail += " (bandingkan checksum)"
+		}
+	}
+	var coordinatesStatus, coordinatesDetail = "❌ Disabled", "-"
+	if summary.BackupConfig.CaptureGtid {
+		coordinatesStatus = "✅ Enabled"
+		coordinatesDetail = summary.BackupConfig.CoordinatesSource
+	}
+	dumperName := summary.BackupConfig.Dumper
+	if dumperName == "" {
+		dumperName = dumperMysqldump
+	}
 	data := [][]string{
+		{"Dumper", dumperName, "-"},
 		{"Kompresi", compressionStatus, compressionDetail},
 		{"Enkripsi", encryptionStatus, encryptionDetail},
+		{"Verifikasi", verifyStatus, verifyDetail},
+		{"Koordinat Replikasi", coordinatesStatus, coordinatesDetail},
 		{"Auto Cleanup", cleanupStatus, cleanupDetail},
 	}
 	ui.FormatTable([]string{"Fitur", "Status", "Detail"}, data)
@@ -116,8 +160,8 @@ func (s *Service) displaySuccessfulDBs(summary *BackupSummary) {
 		return
 	}
 
-	// Tampilan berbeda untuk mode combined vs separate
-	if summary.BackupMode == "combined" {
+	// Tampilan berbeda untuk mode combined (termasuk backup fisik satu file) vs separate
+	if summary.BackupMode == "combined" || summary.BackupMode == backupModePhysical {
 		s.displayCombinedBackupFiles(summary)
 	} else {
 		s.displaySeparateBackupFiles(summary)
@@ -395,3 +439,53 @@ func (s *Service) ringkasanDiskCheck(dbFiltered []string) error {
 
 	return nil
 }
+
+// displayCoordinates menampilkan koordinat replikasi (binlog file/posisi dan GTID) yang tercatat pada dump
+func (s *Service) displayCoordinates(summary *BackupSummary) {
+	var rows [][]string
+	for _, db := range summary.SuccessfulDatabases {
+		if db.Coordinates == nil {
+			continue
+		}
+		name := db.DatabaseName
+		if summary.BackupMode == "combined" {
+			name = fmt.Sprintf("%d databases", len(summary.SuccessfulDatabases))
+		}
+		rows = append(rows, []string{
+			name,
+			db.Coordinates.Source,
+			fmt.Sprintf("%s:%d", db.Coordinates.BinlogFile, db.Coordinates.BinlogPosition),
+			db.Coordinates.GTIDPosition,
+		})
+		if summary.BackupMode == "combined" {
+			break
+		}
+	}
+	if len(rows) == 0 {
+		return
+	}
+
+	ui.PrintSubHeader("Koordinat Replikasi")
+	ui.FormatTable([]string{"Database", "Sumber", "Binlog File:Posisi", "GTID"}, rows)
+}
+
+// displayRetryHistory menampilkan riwayat 'backup retry' yang pernah dijalankan pada summary ini
+func (s *Service) displayRetryHistory(summary *BackupSummary) {
+	if len(summary.RetryHistory) == 0 {
+		return
+	}
+
+	ui.PrintSubHeader("Riwayat Retry")
+	var rows [][]string
+	for i, attempt := range summary.RetryHistory {
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", i+1),
+			attempt.Time.Format(displayTimeFormat),
+			strings.Join(attempt.Databases, ", "),
+			fmt.Sprintf("%d", len(attempt.Succeeded)),
+			fmt.Sprintf("%d", len(attempt.Failed)),
+			attempt.Duration,
+		})
+	}
+	ui.FormatTable([]string{"No", "Waktu", "Database", "Berhasil", "Gagal", "Durasi"}, rows)
+}
@@ -0,0 +1,139 @@
+// File : internal/backup/backup_dumper.go
+// Deskripsi : Abstraksi dumper logis (mysqldump atau native Go) dan implementasi berbasis binary mysqldump
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sfDBTools/pkg/database"
+	"strings"
+)
+
+// Nama implementasi dumper yang didukung (backup.dumper / --dumper)
+const (
+	dumperMysqldump = "mysqldump"
+	dumperNative    = "native"
+)
+
+// dumpJob menjelaskan isi satu file backup secara independen dari implementasi dumper
+type dumpJob struct {
+	BaseDumpArgs string                  // mysqldump_args dari konfigurasi (hanya dipakai dumper mysqldump)
+	Databases    []string                // Database dalam satu file gabungan (mode combined)
+	SingleDB     string                  // Database tunggal (mode separate)
+	Coordinates  *ReplicationCoordinates // Tidak nil jika capture koordinat aktif; diisi oleh dumper
+}
+
+// databases mengembalikan daftar database yang di-dump oleh job
+func (j *dumpJob) databases() []string {
+	if j.SingleDB != "" {
+		return []string{j.SingleDB}
+	}
+	return j.Databases
+}
+
+// capturedCoordinates mengembalikan koordinat yang berhasil dicatat dumper, atau nil
+func (j *dumpJob) capturedCoordinates() *ReplicationCoordinates {
+	if j.Coordinates != nil && (j.Coordinates.BinlogFile != "" || j.Coordinates.GTIDPosition != "") {
+		return j.Coordinates
+	}
+	return nil
+}
+
+// newDumpJob menyusun dumpJob untuk satu file backup
+func (s *Service) newDumpJob(config BackupConfig, dbFiltered []string, singleDB string) *dumpJob {
+	job := &dumpJob{
+		BaseDumpArgs: config.BaseDumpArgs,
+		Databases:    dbFiltered,
+		SingleDB:     singleDB,
+	}
+	if s.BackupOptions.CaptureGtid {
+		job.Coordinates = &ReplicationCoordinates{Source: s.BackupOptions.Coordinates}
+	}
+	return job
+}
+
+// dumper menulis dump SQL logis sebuah dumpJob ke w.
+// Mengembalikan warning non-fatal (misal view rusak) dan error untuk kegagalan fatal.
+type dumper interface {
+	Name() string
+	Dump(ctx context.Context, job *dumpJob, w io.Writer) (string, error)
+}
+
+// dumperName mengembalikan nama dumper yang dipilih, default mysqldump
+func (s *Service) dumperName() string {
+	name := strings.ToLower(strings.TrimSpace(s.BackupOptions.Dumper))
+	if name == "" {
+		return dumperMysqldump
+	}
+	return name
+}
+
+// newDumper membuat dumper sesuai --dumper / backup.dumper
+func (s *Service) newDumper() (dumper, error) {
+	switch s.dumperName() {
+	case dumperMysqldump:
+		return &mysqldumpDumper{s: s}, nil
+	case dumperNative:
+		return &nativeDumper{s: s}, nil
+	default:
+		return nil, fmt.Errorf("dumper tidak dikenal: %s (gunakan %s atau %s)", s.BackupOptions.Dumper, dumperMysqldump, dumperNative)
+	}
+}
+
+// mysqldumpDumper menjalankan binary mysqldump untuk setiap pass dan menulis output-nya ke stream yang sama
+type mysqldumpDumper struct {
+	s *Service
+}
+
+func (d *mysqldumpDumper) Name() string { return dumperMysqldump }
+
+func (d *mysqldumpDumper) Dump(ctx context.Context, job *dumpJob, w io.Writer) (string, error) {
+	s := d.s
+	var stderrParts []string
+	for _, pass := range s.buildDumpPasses(job) {
+		if pass.Preamble != "" {
+			if _, err := io.WriteString(w, pass.Preamble); err != nil {
+				return strings.Join(stderrParts, "\n"), fmt.Errorf("gagal menulis preamble dump: %w", err)
+			}
+		}
+
+		s.Logger.Debugf("Menjalankan mysqldump %s", strings.Join(s.sanitizeArgsForLogging(pass.Args), " "))
+		cmd, cleanup, err := database.ClientCommand(ctx, s.BackupOptions.DBConfig.ServerDBConnection, "mysqldump", pass.Args...)
+		if err != nil {
+			return strings.Join(stderrParts, "\n"), err
+		}
+		cmd.Stdout = w
+
+		// Header dump disalin untuk mengurai koordinat --master-data/--dump-slave
+		var header *headerCapture
+		if pass.Capture != nil {
+			header = &headerCapture{limit: coordinatesHeaderLimit}
+			cmd.Stdout = io.MultiWriter(w, header)
+		}
+
+		// Capture stderr untuk menangkap warnings dan errors
+		var stderrBuf strings.Builder
+		cmd.Stderr = &stderrBuf
+
+		runErr := cmd.Run()
+		cleanup()
+		if stderrBuf.Len() > 0 {
+			stderrParts = append(stderrParts, stderrBuf.String())
+		}
+		// Cek apakah ini error fatal atau hanya warning
+		if runErr != nil && s.isFatalMysqldumpError(runErr, stderrBuf.String()) {
+			return strings.Join(stderrParts, "\n"), fmt.Errorf("mysqldump gagal: %w: %s", runErr, strings.TrimSpace(stderrBuf.String()))
+		}
+		// Jika bukan fatal error, stderr dikembalikan sebagai warning
+
+		if header != nil && !parseReplicationCoordinates(header.buf, pass.Capture) {
+			s.Logger.Warn("Koordinat replikasi tidak ditemukan pada header dump")
+		}
+	}
+	return strings.Join(stderrParts, "\n"), nil
+}
@@ -0,0 +1,537 @@
+// File : internal/backup/backup_dumper_native.go
+// Deskripsi : Dumper logis native Go yang membaca skema dan data melalui database.Client dalam satu consistent snapshot
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sfDBTools/pkg/database"
+	"strings"
+	"time"
+)
+
+// nativeInsertChunkSize adalah batas ukuran satu extended INSERT, setara net_buffer_length mysqldump
+const nativeInsertChunkSize = 1 << 20
+
+// nativeHeader dan nativeFooter sama dengan pengaturan sesi yang ditulis mysqldump
+const nativeHeader = `/*!40101 SET @OLD_CHARACTER_SET_CLIENT=@@CHARACTER_SET_CLIENT */;
+/*!40101 SET @OLD_CHARACTER_SET_RESULTS=@@CHARACTER_SET_RESULTS */;
+/*!40101 SET @OLD_COLLATION_CONNECTION=@@COLLATION_CONNECTION */;
+/*!40101 SET NAMES utf8mb4 */;
+/*!40103 SET @OLD_TIME_ZONE=@@TIME_ZONE */;
+/*!40103 SET TIME_ZONE='+00:00' */;
+/*!40014 SET @OLD_UNIQUE_CHECKS=@@UNIQUE_CHECKS, UNIQUE_CHECKS=0 */;
+/*!40014 SET @OLD_FOREIGN_KEY_CHECKS=@@FOREIGN_KEY_CHECKS, FOREIGN_KEY_CHECKS=0 */;
+/*!40101 SET @OLD_SQL_MODE=@@SQL_MODE, SQL_MODE='NO_AUTO_VALUE_ON_ZERO' */;
+/*!40111 SET @OLD_SQL_NOTES=@@SQL_NOTES, SQL_NOTES=0 */;
+`
+
+const nativeFooter = `
+/*!40103 SET TIME_ZONE=@OLD_TIME_ZONE */;
+/*!40101 SET SQL_MODE=@OLD_SQL_MODE */;
+/*!40014 SET FOREIGN_KEY_CHECKS=@OLD_FOREIGN_KEY_CHECKS */;
+/*!40014 SET UNIQUE_CHECKS=@OLD_UNIQUE_CHECKS */;
+/*!40101 SET CHARACTER_SET_CLIENT=@OLD_CHARACTER_SET_CLIENT */;
+/*!40101 SET CHARACTER_SET_RESULTS=@OLD_CHARACTER_SET_RESULTS */;
+/*!40101 SET COLLATION_CONNECTION=@OLD_COLLATION_CONNECTION */;
+/*!40111 SET SQL_NOTES=@OLD_SQL_NOTES */;
+
+`
+
+// nativeDumper menulis dump SQL tanpa binary mysqldump, kompatibel untuk di-restore dengan client mysql
+type nativeDumper struct {
+	s *Service
+}
+
+func (d *nativeDumper) Name() string { return dumperNative }
+
+// Dump membuka koneksi snapshot khusus per file backup. Jika koordinat replikasi diminta,
+// FLUSH TABLES WITH READ LOCK dipegang hanya selama snapshot dimulai dan koordinat dibaca.
+func (d *nativeDumper) Dump(ctx context.Context, job *dumpJob, w io.Writer) (string, error) {
+	s := d.s
+	client, err := database.NewDumpClient(s.BackupOptions.DBConfig.ServerDBConnection)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	snap, err := client.BeginSnapshot(ctx, job.Coordinates != nil)
+	if err != nil {
+		return "", fmt.Errorf("gagal memulai consistent snapshot: %w", err)
+	}
+	defer snap.Close()
+
+	nd := &nativeDump{s: s, snap: snap, w: w}
+	if job.Coordinates != nil {
+		nd.captureCoordinates(ctx, job.Coordinates)
+		if err := snap.Unlock(ctx); err != nil {
+			return nd.warningText(), err
+		}
+	}
+
+	if err := nd.writeHeader(job); err != nil {
+		return nd.warningText(), err
+	}
+	for _, dbName := range job.databases() {
+		if err := nd.dumpDatabase(ctx, dbName); err != nil {
+			return nd.warningText(), err
+		}
+	}
+	if err := nd.write(nativeFooter + dumpCompletedTrailer + " on " + time.Now().Format("2006-01-02 15:04:05") + "\n"); err != nil {
+		return nd.warningText(), err
+	}
+	return nd.warningText(), nil
+}
+
+// nativeDump menyimpan state satu eksekusi dumper native
+type nativeDump struct {
+	s        *Service
+	snap     *database.Snapshot
+	w        io.Writer
+	warnings []string
+}
+
+// warn mencatat masalah non-fatal; hasilnya diperlakukan seperti stderr mysqldump (success_with_warnings)
+func (nd *nativeDump) warn(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	nd.warnings = append(nd.warnings, msg)
+	nd.s.Logger.Warn(msg)
+}
+
+func (nd *nativeDump) warningText() string {
+	return strings.Join(nd.warnings, "\n")
+}
+
+func (nd *nativeDump) write(text string) error {
+	if _, err := io.WriteString(nd.w, text); err != nil {
+		return fmt.Errorf("gagal menulis output dump: %w", err)
+	}
+	return nil
+}
+
+// captureCoordinates membaca koordinat selama read lock dipegang; kegagalan dicatat sebagai warning
+func (nd *nativeDump) captureCoordinates(ctx context.Context, coords *ReplicationCoordinates) {
+	var binlog database.BinlogCoordinates
+	var err error
+	if coords.Source == coordinatesSourceSlave {
+		binlog, err = nd.snap.SlaveCoordinates(ctx)
+	} else {
+		binlog, err = nd.snap.MasterCoordinates(ctx)
+	}
+	if err != nil {
+		nd.warn("Koordinat replikasi tidak dapat dibaca: %v", err)
+		return
+	}
+	coords.BinlogFile = binlog.File
+	coords.BinlogPosition = binlog.Position
+	coords.GTIDPosition = binlog.GTID
+}
+
+// writeHeader menulis pengaturan sesi dan koordinat dalam format komentar --master-data=2 mysqldump
+// sehingga file tetap dapat diurai oleh parseReplicationCoordinates
+func (nd *nativeDump) writeHeader(job *dumpJob) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "-- sfDBTools native dump\n--\n-- Host: %s    Database: %s\n", nd.s.BackupOptions.DBConfig.ServerDBConnection.Host, strings.Join(job.databases(), ", "))
+	b.WriteString("-- ------------------------------------------------------\n\n")
+	b.WriteString(nativeHeader)
+
+	if coords := job.capturedCoordinates(); coords != nil {
+		if coords.BinlogFile != "" {
+			b.WriteString("\n--\n-- Position to start replication or point-in-time recovery from\n--\n\n")
+			fmt.Fprintf(&b, "-- CHANGE MASTER TO MASTER_LOG_FILE='%s', MASTER_LOG_POS=%d;\n", coords.BinlogFile, coords.BinlogPosition)
+		}
+		if coords.GTIDPosition != "" {
+			b.WriteString("\n--\n-- GTID to start replication from\n--\n\n")
+			fmt.Fprintf(&b, "-- SET GLOBAL gtid_slave_pos='%s';\n", coords.GTIDPosition)
+		}
+	}
+	return nd.write(b.String())
+}
+
+// dumpDatabase menulis satu database: tabel beserta data dan trigger, sequence, view, lalu routine
+func (nd *nativeDump) dumpDatabase(ctx context.Context, dbName string) error {
+	startTime := time.Now()
+	quotedDB := database.QuoteIdentifier(dbName)
+
+	createDB, err := nd.snap.ShowCreateDatabase(ctx, dbName)
+	if err != nil {
+		return err
+	}
+	createDB = strings.Replace(createDB, "CREATE DATABASE ", "CREATE DATABASE /*!32312 IF NOT EXISTS*/ ", 1)
+	if err := nd.write(fmt.Sprintf("\n--\n-- Current Database: %s\n--\n\n%s;\n\nUSE %s;\n", quotedDB, createDB, quotedDB)); err != nil {
+		return err
+	}
+
+	tables, err := nd.snap.ListTables(ctx, dbName)
+	if err != nil {
+		return err
+	}
+	triggers, err := nd.snap.ListTriggers(ctx, dbName)
+	if err != nil {
+		return err
+	}
+	triggersByTable := make(map[string][]string)
+	for _, trg := range triggers {
+		triggersByTable[trg.Table] = append(triggersByTable[trg.Table], trg.Name)
+	}
+
+	filter := nd.s.TableFilters[dbName]
+	ignored := stringSet(filter.IgnoreTables)
+	noData := stringSet(filter.NoDataTables)
+
+	var views, sequences []string
+	var tableCount int
+	var rowCount int64
+	for _, table := range tables {
+		if ignored[table.Name] {
+			continue
+		}
+		switch table.Type {
+		case "VIEW":
+			views = append(views, table.Name)
+			continue
+		case "SEQUENCE":
+			sequences = append(sequences, table.Name)
+			continue
+		}
+
+		tableCount++
+		if err := nd.dumpTableStructure(ctx, dbName, table.Name); err != nil {
+			return err
+		}
+		if !nd.s.BackupOptions.Exclude.Data && !noData[table.Name] {
+			rows, err := nd.dumpTableData(ctx, dbName, table.Name)
+			if err != nil {
+				return err
+			}
+			rowCount += rows
+		}
+		for _, trigger := range triggersByTable[table.Name] {
+			if err := nd.dumpTrigger(ctx, dbName, trigger); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, sequence := range sequences {
+		if err := nd.dumpSequence(ctx, dbName, sequence); err != nil {
+			return err
+		}
+	}
+
+	// View ditulis dua tahap seperti mysqldump: stand-in lebih dulu agar view yang saling
+	// bergantung dapat dibuat tanpa memperhatikan urutan, lalu definisi sebenarnya di akhir
+	var validViews []string
+	for _, view := range views {
+		ok, err := nd.dumpViewStandIn(ctx, dbName, view)
+		if err != nil {
+			return err
+		}
+		if ok {
+			validViews = append(validViews, view)
+		}
+	}
+
+	if err := nd.dumpRoutines(ctx, dbName); err != nil {
+		return err
+	}
+
+	for _, view := range validViews {
+		if err := nd.dumpView(ctx, dbName, view); err != nil {
+			return err
+		}
+	}
+
+	nd.s.Logger.Infof("Dump native database %s selesai: %d tabel, %d view, %d baris (%s)",
+		dbName, tableCount, len(validViews), rowCount, time.Since(startTime).Round(time.Millisecond))
+	return nil
+}
+
+// dumpTableStructure menulis DROP TABLE dan CREATE TABLE
+func (nd *nativeDump) dumpTableStructure(ctx context.Context, dbName, table string) error {
+	row, err := nd.snap.ShowCreate(ctx, "TABLE", dbName, table)
+	if err != nil {
+		return err
+	}
+	quoted := database.QuoteIdentifier(table)
+	return nd.write(fmt.Sprintf("\n--\n-- Table structure for table %s\n--\n\nDROP TABLE IF EXISTS %s;\n%s;\n", quoted, quoted, row["Create Table"]))
+}
+
+// dumpTableData menulis seluruh baris tabel sebagai extended INSERT berukuran maksimal nativeInsertChunkSize.
+// Kolom generated dilewati dan daftar kolom ditulis eksplisit agar kolom invisible ikut ter-backup.
+func (nd *nativeDump) dumpTableData(ctx context.Context, dbName, table string) (int64, error) {
+	startTime := time.Now()
+	columns, err := nd.snap.ListColumns(ctx, dbName, table)
+	if err != nil {
+		return 0, err
+	}
+
+	var names, quotedNames []string
+	var kinds []nativeValueKind
+	for _, col := range columns {
+		if col.Generated {
+			continue
+		}
+		names = append(names, col.Name)
+		quotedNames = append(quotedNames, database.QuoteIdentifier(col.Name))
+		kinds = append(kinds, nativeColumnKind(col.DataType))
+	}
+	if len(names) == 0 {
+		return 0, nil
+	}
+
+	rows, err := nd.snap.QueryTableRows(ctx, dbName, table, names)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	quoted := database.QuoteIdentifier(table)
+	if err := nd.write(fmt.Sprintf("\n--\n-- Dumping data for table %s\n--\n\nLOCK TABLES %s WRITE;\n/*!40000 ALTER TABLE %s DISABLE KEYS */;\n", quoted, quoted, quoted)); err != nil {
+		return 0, err
+	}
+
+	insertPrefix := fmt.Sprintf("INSERT INTO %s (%s) VALUES ", quoted, strings.Join(quotedNames, ", "))
+	values := make([]sql.RawBytes, len(names))
+	dest := make([]interface{}, len(names))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	buf := make([]byte, 0, nativeInsertChunkSize+64*1024)
+	var rowCount int64
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return rowCount, fmt.Errorf("gagal membaca baris %s.%s: %w", dbName, table, err)
+		}
+		if len(buf) == 0 {
+			buf = append(buf, insertPrefix...)
+		} else {
+			buf = append(buf, ',')
+		}
+		buf = append(buf, '(')
+		for i, value := range values {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			buf = appendSQLValue(buf, value, kinds[i])
+		}
+		buf = append(buf, ')')
+		rowCount++
+
+		if len(buf) >= nativeInsertChunkSize {
+			buf = append(buf, ";\n"...)
+			if _, err := nd.w.Write(buf); err != nil {
+				return rowCount, fmt.Errorf("gagal menulis output dump: %w", err)
+			}
+			buf = buf[:0]
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return rowCount, fmt.Errorf("gagal membaca data %s.%s: %w", dbName, table, err)
+	}
+	if len(buf) > 0 {
+		buf = append(buf, ";\n"...)
+		if _, err := nd.w.Write(buf); err != nil {
+			return rowCount, fmt.Errorf("gagal menulis output dump: %w", err)
+		}
+	}
+
+	if err := nd.write(fmt.Sprintf("/*!40000 ALTER TABLE %s ENABLE KEYS */;\nUNLOCK TABLES;\n", quoted)); err != nil {
+		return rowCount, err
+	}
+	nd.s.Logger.Debugf("Dump native %s.%s: %d baris (%s)", dbName, table, rowCount, time.Since(startTime).Round(time.Millisecond))
+	return rowCount, nil
+}
+
+// dumpTrigger menulis satu trigger dengan sql_mode aslinya
+func (nd *nativeDump) dumpTrigger(ctx context.Context, dbName, trigger string) error {
+	row, err := nd.snap.ShowCreate(ctx, "TRIGGER", dbName, trigger)
+	if err != nil {
+		return err
+	}
+	return nd.writeCompoundStatement(row["SQL Original Statement"], row["sql_mode"])
+}
+
+// dumpSequence menulis struktur SEQUENCE MariaDB dan posisi nilainya
+func (nd *nativeDump) dumpSequence(ctx context.Context, dbName, sequence string) error {
+	row, err := nd.snap.ShowCreate(ctx, "TABLE", dbName, sequence)
+	if err != nil {
+		return err
+	}
+	quoted := database.QuoteIdentifier(sequence)
+	text := fmt.Sprintf("\n--\n-- Sequence structure for %s\n--\n\nDROP SEQUENCE IF EXISTS %s;\n%s;\n", quoted, quoted, row["Create Table"])
+	if !nd.s.BackupOptions.Exclude.Data {
+		next, err := nd.snap.SequenceNextValue(ctx, dbName, sequence)
+		if err != nil {
+			return err
+		}
+		text += fmt.Sprintf("DO SETVAL(%s, %s, 0);\n", quoted, next)
+	}
+	return nd.write(text)
+}
+
+// dumpViewStandIn menulis view sementara dengan kolom yang sama. View yang tidak valid
+// (misal tabel dasarnya sudah dihapus) dicatat sebagai warning dan dilewati.
+func (nd *nativeDump) dumpViewStandIn(ctx context.Context, dbName, view string) (bool, error) {
+	columns, err := nd.snap.ListColumns(ctx, dbName, view)
+	if err != nil || len(columns) == 0 {
+		nd.warn("View %s.%s dilewati: kolom tidak dapat dibaca (%v)", dbName, view, err)
+		return false, nil
+	}
+
+	fields := make([]string, len(columns))
+	for i, col := range columns {
+		fields[i] = "1 AS " + database.QuoteIdentifier(col.Name)
+	}
+	quoted := database.QuoteIdentifier(view)
+	text := fmt.Sprintf("\n--\n-- Temporary view structure for view %s\n--\n\nDROP TABLE IF EXISTS %s;\n/*!50001 DROP VIEW IF EXISTS %s*/;\n/*!50001 CREATE VIEW %s AS SELECT %s */;\n",
+		quoted, quoted, quoted, quoted, strings.Join(fields, ", "))
+	return true, nd.write(text)
+}
+
+// dumpView menulis definisi view sebenarnya menggantikan stand-in
+func (nd *nativeDump) dumpView(ctx context.Context, dbName, view string) error {
+	row, err := nd.snap.ShowCreate(ctx, "VIEW", dbName, view)
+	if err != nil {
+		nd.warn("View %s.%s dilewati: %v", dbName, view, err)
+		return nil
+	}
+	quoted := database.QuoteIdentifier(view)
+	return nd.write(fmt.Sprintf("\n--\n-- Final view structure for view %s\n--\n\n/*!50001 DROP VIEW IF EXISTS %s*/;\n/*!50001 %s */;\n", quoted, quoted, row["Create View"]))
+}
+
+// dumpRoutines menulis stored procedure dan function database
+func (nd *nativeDump) dumpRoutines(ctx context.Context, dbName string) error {
+	routines, err := nd.snap.ListRoutines(ctx, dbName)
+	if err != nil {
+		return err
+	}
+	if len(routines) == 0 {
+		return nil
+	}
+	if err := nd.write(fmt.Sprintf("\n--\n-- Dumping routines for database %s\n--\n", database.QuoteIdentifier(dbName))); err != nil {
+		return err
+	}
+
+	for _, routine := range routines {
+		row, err := nd.snap.ShowCreate(ctx, routine.Type, dbName, routine.Name)
+		if err != nil {
+			return err
+		}
+		// Definisi kosong jika user tidak memiliki hak akses ke routine tersebut
+		definitionKey := "Create Procedure"
+		if routine.Type == "FUNCTION" {
+			definitionKey = "Create Function"
+		}
+		definition := row[definitionKey]
+		if definition == "" {
+			nd.warn("Definisi %s %s.%s kosong (hak akses kurang), dilewati", strings.ToLower(routine.Type), dbName, routine.Name)
+			continue
+		}
+		drop := fmt.Sprintf("/*!50003 DROP %s IF EXISTS %s */;\n", routine.Type, database.QuoteIdentifier(routine.Name))
+		if err := nd.write(drop); err != nil {
+			return err
+		}
+		if err := nd.writeCompoundStatement(definition, row["sql_mode"]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeCompoundStatement menulis trigger/routine dengan DELIMITER ;; dan sql_mode saat objek dibuat
+func (nd *nativeDump) writeCompoundStatement(statement, sqlMode string) error {
+	return nd.write(fmt.Sprintf("/*!50003 SET @saved_sql_mode = @@sql_mode */;\n/*!50003 SET sql_mode = '%s' */;\nDELIMITER ;;\n%s ;;\nDELIMITER ;\n/*!50003 SET sql_mode = @saved_sql_mode */;\n",
+		escapeSQLString(sqlMode), statement))
+}
+
+// nativeValueKind menentukan cara satu nilai kolom ditulis pada INSERT
+type nativeValueKind int
+
+const (
+	valueString  nativeValueKind = iota // String dengan escape
+	valueNumeric                        // Ditulis apa adanya
+	valueBinary                         // Heksadesimal 0x.. (setara --hex-blob)
+)
+
+// nativeColumnKind memetakan DATA_TYPE information_schema ke cara penulisan nilai
+func nativeColumnKind(dataType string) nativeValueKind {
+	switch dataType {
+	case "tinyint", "smallint", "mediumint", "int", "integer", "bigint",
+		"decimal", "numeric", "float", "double", "real", "year":
+		return valueNumeric
+	case "binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob", "bit",
+		"geometry", "point", "linestring", "polygon", "multipoint", "multilinestring",
+		"multipolygon", "geometrycollection":
+		return valueBinary
+	default:
+		return valueString
+	}
+}
+
+// appendSQLValue menambahkan literal SQL untuk satu nilai; nil berarti NULL
+func appendSQLValue(buf []byte, value sql.RawBytes, kind nativeValueKind) []byte {
+	if value == nil {
+		return append(buf, "NULL"...)
+	}
+	switch kind {
+	case valueNumeric:
+		return append(buf, value...)
+	case valueBinary:
+		if len(value) == 0 {
+			return append(buf, "''"...)
+		}
+		buf = append(buf, "0x"...)
+		return hex.AppendEncode(buf, value)
+	default:
+		buf = append(buf, '\'')
+		buf = appendEscapedString(buf, value)
+		return append(buf, '\'')
+	}
+}
+
+// appendEscapedString meng-escape string dengan aturan yang sama seperti mysql_real_escape_string
+func appendEscapedString(buf []byte, value []byte) []byte {
+	for _, c := range value {
+		switch c {
+		case 0:
+			buf = append(buf, '\\', '0')
+		case '\n':
+			buf = append(buf, '\\', 'n')
+		case '\r':
+			buf = append(buf, '\\', 'r')
+		case '\\':
+			buf = append(buf, '\\', '\\')
+		case '\'':
+			buf = append(buf, '\\', '\'')
+		case '"':
+			buf = append(buf, '\\', '"')
+		case 0x1a:
+			buf = append(buf, '\\', 'Z')
+		default:
+			buf = append(buf, c)
+		}
+	}
+	return buf
+}
+
+func escapeSQLString(value string) string {
+	return string(appendEscapedString(nil, []byte(value)))
+}
+
+// stringSet membuat set dari daftar string
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
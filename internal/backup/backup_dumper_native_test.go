@@ -0,0 +1,108 @@
+package backup
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestNativeColumnKind(t *testing.T) {
+	tests := []struct {
+		dataType string
+		want     nativeValueKind
+	}{
+		{"int", valueNumeric},
+		{"bigint", valueNumeric},
+		{"tinyint", valueNumeric},
+		{"decimal", valueNumeric},
+		{"double", valueNumeric},
+		{"year", valueNumeric},
+		{"blob", valueBinary},
+		{"longblob", valueBinary},
+		{"binary", valueBinary},
+		{"varbinary", valueBinary},
+		{"bit", valueBinary},
+		{"geometry", valueBinary},
+		{"point", valueBinary},
+		{"varchar", valueString},
+		{"text", valueString},
+		{"json", valueString},
+		{"enum", valueString},
+		{"datetime", valueString},
+		{"timestamp", valueString},
+		{"uuid", valueString},   // Tipe yang tidak dikenal ditulis sebagai string
+		{"vector", valueString}, // Tipe baru di server yang lebih baru juga jatuh ke string
+		{"", valueString},
+	}
+
+	for _, tt := range tests {
+		if got := nativeColumnKind(tt.dataType); got != tt.want {
+			t.Errorf("nativeColumnKind(%q) = %d, ingin %d", tt.dataType, got, tt.want)
+		}
+	}
+}
+
+func TestAppendSQLValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value sql.RawBytes
+		kind  nativeValueKind
+		want  string
+	}{
+		{"NULL string", nil, valueString, "NULL"},
+		{"NULL numeric", nil, valueNumeric, "NULL"},
+		{"NULL binary", nil, valueBinary, "NULL"},
+		{"numeric apa adanya", sql.RawBytes("-42"), valueNumeric, "-42"},
+		{"decimal apa adanya", sql.RawBytes("12345.6700"), valueNumeric, "12345.6700"},
+		{"float eksponen", sql.RawBytes("1.5e-7"), valueNumeric, "1.5e-7"},
+		{"blob sebagai hex", sql.RawBytes{0x00, 0xff, 0x10, 'A'}, valueBinary, "0x00ff1041"},
+		{"bit sebagai hex", sql.RawBytes{0x05}, valueBinary, "0x05"},
+		{"binary kosong", sql.RawBytes{}, valueBinary, "''"},
+		{"string kosong", sql.RawBytes{}, valueString, "''"},
+		{"string biasa", sql.RawBytes("Jakarta"), valueString, "'Jakarta'"},
+		{"string dengan escape", sql.RawBytes("O'Brien \"x\"\n"), valueString, `'O\'Brien \"x\"\n'`},
+		{"UTF-8 multibyte", sql.RawBytes("Bandung — 東京 🚀"), valueString, "'Bandung — 東京 🚀'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(appendSQLValue([]byte("("), tt.value, tt.kind))
+			if want := "(" + tt.want; got != want {
+				t.Errorf("appendSQLValue = %s, ingin %s", got, want)
+			}
+		})
+	}
+}
+
+func TestAppendEscapedString(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"tanpa karakter khusus", "abc 123", "abc 123"},
+		{"NUL", "a\x00b", `a\0b`},
+		{"newline", "a\nb", `a\nb`},
+		{"carriage return", "a\rb", `a\rb`},
+		{"backslash", `a\b`, `a\\b`},
+		{"petik tunggal", "a'b", `a\'b`},
+		{"petik ganda", `a"b`, `a\"b`},
+		{"Ctrl-Z", "a\x1ab", `a\Zb`},
+		{"gabungan", "\x00\n\r\\'\"\x1a", `\0\n\r\\\'\"\Z`},
+		{"tab tidak di-escape", "a\tb", "a\tb"},
+		{"UTF-8 multibyte utuh", "naïve 日本語 ü", "naïve 日本語 ü"},
+		// Byte lanjutan UTF-8 tidak pernah bernilai < 0x80 sehingga tidak ikut di-escape
+		{"UTF-8 dengan petik", "café's", `café\'s`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(appendEscapedString(nil, []byte(tt.value))); got != tt.want {
+				t.Errorf("appendEscapedString(%q) = %q, ingin %q", tt.value, got, tt.want)
+			}
+		})
+	}
+
+	if got := escapeSQLString("it's"); got != `it\'s` {
+		t.Errorf("escapeSQLString = %q", got)
+	}
+}
@@ -2,7 +2,7 @@
 // Deskripsi : Entry points untuk semua jenis backup database
 // Author : Hadiyatna Muflihun
 // Tanggal : 2024-10-15
-// Last Modified : 2024-10-15
+// Last Modified : 16 Oktober 2026
 
 package backup
 
@@ -15,6 +15,13 @@ import (
 func (s *Service) ExecuteBackupCommand(config BackupEntryConfig) error {
 	ctx := context.Background()
 
+	// Cegah dua backup berjalan bersamaan pada direktori output yang sama
+	release, err := s.AcquireBackupLock(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	// Setup session (koneksi database, filter database, dll)
 	dbFiltered, originalMaxStatementsTime, err := s.PrepareBackupSession(ctx, config.HeaderTitle, config.ShowOptions)
 	if err != nil {
@@ -23,13 +30,11 @@ func (s *Service) ExecuteBackupCommand(config BackupEntryConfig) error {
 	//Pastikan koneksi ditutup di akhir
 	defer s.Client.Close()
 
-	// Check flag capture GTID jika diaktifkan
-	if config.EnableGTID {
-		if err := s.CaptureGTIDIfNeeded(ctx, s.Client); err != nil {
-			s.Logger.Warn("Gagal menangani opsi capture GTID: " + err.Error())
-			s.KembalikanMaxStatementsTime(ctx, originalMaxStatementsTime)
-			return err
-		}
+	// Check flag capture GTID jika diaktifkan (berlaku untuk semua mode backup)
+	if err := s.CaptureGTIDIfNeeded(ctx, s.Client); err != nil {
+		s.Logger.Warn("Gagal menangani opsi capture GTID: " + err.Error())
+		s.KembalikanMaxStatementsTime(ctx, originalMaxStatementsTime)
+		return err
 	}
 
 	// Lakukan backup dengan mode yang ditentukan
@@ -57,7 +62,6 @@ func (s *Service) BackupDatabase() error {
 		HeaderTitle: "Backup Database (Tiap Database Terpisah)",
 		ShowOptions: true,
 		BackupMode:  "separate",
-		EnableGTID:  false,
 		SuccessMsg:  "", // No success message for database backup
 		LogPrefix:   "Proses backup database",
 	}
@@ -70,9 +74,20 @@ func (s *Service) BackupAllDatabases() error {
 		HeaderTitle: "Backup Database (Tiap Database Digabung)",
 		ShowOptions: true,
 		BackupMode:  "combined",
-		EnableGTID:  true,
 		SuccessMsg:  "Proses backup semua database selesai.",
 		LogPrefix:   "Proses backup semua database",
 	}
 	return s.ExecuteBackupCommand(config)
 }
+
+// BackupSelectedDatabasesCombined melakukan backup database pilihan (--db) dalam satu file
+func (s *Service) BackupSelectedDatabasesCombined() error {
+	config := BackupEntryConfig{
+		HeaderTitle: "Backup Database Pilihan (Digabung)",
+		ShowOptions: true,
+		BackupMode:  "combined",
+		SuccessMsg:  "Proses backup database pilihan selesai.",
+		LogPrefix:   "Proses backup database pilihan",
+	}
+	return s.ExecuteBackupCommand(config)
+}
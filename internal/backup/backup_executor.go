@@ -3,9 +3,7 @@ package backup
 import (
 	"context"
 	"fmt"
-	"os"
 	"path/filepath"
-	"runtime" // Diperlukan untuk konkurensi
 	"sfDBTools/pkg/database"
 	"sfDBTools/pkg/input"
 	"sfDBTools/pkg/ui"
@@ -23,8 +21,17 @@ func (s *Service) ExecuteBackup(ctx context.Context, dbFiltered []string, backup
 	}
 
 	startTime := time.Now()
+	backupID := s.uniqueBackupID(startTime)
 	var result backupResult
 
+	// Resolusi pola filter tabel terhadap daftar tabel aktual di server
+	if s.hasTableFilters() {
+		s.TableFilters, err = s.resolveTableFilters(ctx, dbFiltered)
+		if err != nil {
+			return fmt.Errorf("gagal memproses filter tabel: %w", err)
+		}
+	}
+
 	// 2. (Opsional) Kumpulkan detail database jika diminta — lakukan sebelum backup
 	// Detail database diperlukan untuk pengecekan disk space dan summary
 	needDatabaseDetails := collectDetails || s.BackupOptions.DiskCheck
@@ -88,7 +95,10 @@ func (s *Service) ExecuteBackup(ctx context.Context, dbFiltered []string, backup
 	var estimatesMap map[string]uint64 // Map database name ke estimasi ukuran
 
 	// Periksa apakah pengecekan disk diaktifkan dan backup data tidak dikecualikan
-	if s.BackupOptions.DiskCheck && !s.BackupOptions.Exclude.Data {
+	if s.BackupOptions.DiskCheck && s.isRemoteStorage() {
+		s.Logger.Info("Pengecekan ruang disk dilewati karena file backup disimpan di remote storage")
+		estimatesMap = make(map[string]uint64)
+	} else if s.BackupOptions.DiskCheck && !s.BackupOptions.Exclude.Data {
 		var err error
 		estimatesMap, err = s.checkDiskSpaceBeforeBackup(ctx, config, dbFiltered, backupMode)
 		if err != nil {
@@ -99,6 +109,18 @@ func (s *Service) ExecuteBackup(ctx context.Context, dbFiltered []string, backup
 		estimatesMap = make(map[string]uint64)
 	}
 
+	// Hook pre_backup dijalankan tepat sebelum dump; kegagalan membatalkan backup
+	hc := hookContext{BackupID: backupID, BackupMode: backupMode, OutputDir: config.OutputDir}
+	if err := s.runHooks(ctx, hookPreBackup, hc); err != nil {
+		abortErr := fmt.Errorf("backup dibatalkan oleh hook pre_backup: %w", err)
+		hc.Status = "aborted"
+		hc.Error = abortErr.Error()
+		if hookErr := s.runHooks(ctx, hookOnFailure, hc); hookErr != nil {
+			s.Logger.Warnf("%v", hookErr)
+		}
+		return abortErr
+	}
+
 	ui.PrintSubHeader("Memulai Proses Backup")
 	// 4. Lakukan backup berdasarkan mode
 	if backupMode == "separate" {
@@ -106,9 +128,24 @@ func (s *Service) ExecuteBackup(ctx context.Context, dbFiltered []string, backup
 	} else {
 		result = s.executeBackupCombined(ctx, config, dbFiltered, estimatesMap)
 	}
+	s.runDatabaseHooks(ctx, hc, result)
+
+	// 5. Export akun user dan grant ke file terpisah kecuali exclude.user aktif
+	var usersExport *UsersExportInfo
+	var usersErr error
+	if !s.BackupOptions.Exclude.Users && s.Client != nil {
+		usersExport, usersErr = s.exportUsersAndGrants(ctx, config, startTime)
+		if usersErr != nil {
+			s.Logger.Errorf("Export user dan grant gagal: %v", usersErr)
+			result.errors = append(result.errors, usersErr.Error())
+		}
+	}
 
-	// 5. Buat, simpan, dan tampilkan summary
-	summary := s.CreateBackupSummary(backupMode, dbFiltered, result.successful, result.failed, startTime, result.errors)
+	// 6. Buat, simpan, dan tampilkan summary
+	summary := s.CreateBackupSummary(backupID, backupMode, dbFiltered, result.successful, result.failed, startTime, result.errors)
+	if usersExport != nil {
+		s.addUsersFileToSummary(summary, usersExport)
+	}
 	// Populate server version using the active client if available
 	if s.Client != nil {
 		if ver, err := s.Client.GetVersion(ctx); err == nil {
@@ -118,21 +155,40 @@ func (s *Service) ExecuteBackup(ctx context.Context, dbFiltered []string, backup
 		}
 	}
 
+	if len(summary.OutputInfo.Files) > 0 {
+		manifestPath, err := s.SaveManifest(summary)
+		if err != nil {
+			s.Logger.Errorf("Gagal menyimpan manifest backup: %v", err)
+		} else {
+			summary.ManifestFile = manifestPath
+		}
+	}
+
 	if err := s.SaveSummaryToJSON(summary); err != nil {
 		s.Logger.Errorf("Gagal menyimpan summary ke JSON: %v", err)
 	}
 	s.DisplaySummaryTable(summary)
+	s.recordCatalog(ctx, summary)
+	s.notifyBackupResult(ctx, summary)
+	s.writeBackupMetrics()
+	s.setResultDetails(summary)
 
-	// 6. Kembalikan error jika ada kegagalan
+	// 7. Jalankan hook penyelesaian dan kembalikan error jika ada kegagalan
+	var backupErr error
 	if len(result.failed) > 0 {
 		var failedNames []string
 		for _, failed := range result.failed {
 			failedNames = append(failedNames, failed.DatabaseName)
 		}
-		return fmt.Errorf("beberapa database gagal di-backup: %v", failedNames)
+		backupErr = fmt.Errorf("beberapa database gagal di-backup: %v", failedNames)
+	} else if usersErr != nil {
+		backupErr = fmt.Errorf("backup database selesai namun export user dan grant gagal: %w", usersErr)
 	}
 
-	return nil
+	hc.Status = summary.Status
+	s.runCompletionHooks(ctx, hc, backupErr)
+
+	return backupOutcomeError(summary, backupErr)
 }
 
 // executeBackupSeparate melakukan backup dengan file terpisah per database secara paralel menggunakan worker pool.
@@ -140,13 +196,21 @@ func (s *Service) executeBackupSeparate(ctx context.Context, config BackupConfig
 	dbCount := len(dbFiltered)
 	s.Logger.Infof("Total database yang akan di-backup: %d", dbCount)
 
-	// Tentukan jumlah worker, idealnya sejumlah core CPU untuk efisiensi.
-	numWorkers := runtime.NumCPU()
-	if dbCount < numWorkers {
-		numWorkers = dbCount // Tidak perlu lebih banyak worker dari jumlah pekerjaan
-	}
+	// Tentukan jumlah worker dari --parallel, default sejumlah core CPU.
+	numWorkers := s.resolveWorkerCount(dbCount)
 	s.Logger.Infof("Menggunakan %d worker untuk backup paralel.", numWorkers)
 
+	// Mode adaptive: jumlah worker yang boleh menjalankan mysqldump diatur ulang sesuai beban server
+	var gate *concurrencyGate
+	if s.BackupOptions.Concurrency.Adaptive && s.Client != nil && numWorkers > 1 {
+		gate = newConcurrencyGate(numWorkers)
+		monitorCtx, stopMonitor := context.WithCancel(ctx)
+		defer stopMonitor()
+		go s.monitorServerLoad(monitorCtx, gate)
+		s.Logger.Infof("Mode adaptive aktif (Threads_running > %d atau lag replikasi > %d detik menurunkan worker)",
+			s.BackupOptions.Concurrency.MaxThreadsRunning, s.BackupOptions.Concurrency.MaxReplicationLag)
+	}
+
 	jobs := make(chan string, dbCount)
 	results := make(chan jobResult, dbCount)
 	var wg sync.WaitGroup
@@ -157,10 +221,22 @@ func (s *Service) executeBackupSeparate(ctx context.Context, config BackupConfig
 		go func(workerID int) {
 			defer wg.Done()
 			for dbName := range jobs {
+				if gate != nil {
+					gate.acquire()
+				}
 				s.Logger.Infof("[Worker %d] Memproses database: %s", workerID, dbName)
 				estimatedSize := estimatesMap[dbName]
-				info, err := s.backupSingleDatabase(ctx, config, dbName, estimatedSize)
-				results <- jobResult{info: info, err: err, dbName: dbName}
+				var info DatabaseBackupInfo
+				attempts, err := s.retryTransient(ctx, "Backup database "+dbName, func() error {
+					var backupErr error
+					info, backupErr = s.backupSingleDatabase(ctx, config, dbName, estimatedSize)
+					return backupErr
+				})
+				info.Attempts = attempts
+				if gate != nil {
+					gate.release()
+				}
+				results <- jobResult{info: info, err: err, dbName: dbName, attempts: attempts}
 			}
 		}(w)
 	}
@@ -180,7 +256,7 @@ func (s *Service) executeBackupSeparate(ctx context.Context, config BackupConfig
 	for result := range results {
 		if result.err != nil {
 			errorMsg := fmt.Sprintf("Gagal backup database %s: %v", result.dbName, result.err)
-			res.failed = append(res.failed, FailedDatabaseInfo{DatabaseName: result.dbName, Error: result.err.Error()})
+			res.failed = append(res.failed, FailedDatabaseInfo{DatabaseName: result.dbName, Error: result.err.Error(), Attempts: result.attempts})
 			res.errors = append(res.errors, errorMsg)
 			s.Logger.Error(errorMsg)
 		} else {
@@ -206,15 +282,26 @@ func (s *Service) backupSingleDatabase(ctx context.Context, config BackupConfig,
 	outputFile := s.addFileExtensions(baseOutputFile+".sql", config)
 	fullOutputPath := filepath.Join(config.OutputDir, outputFile)
 
-	mysqldumpArgs := s.buildMysqldumpArgs(config.BaseDumpArgs, nil, dbName)
-	stderrOutput, err := s.executeMysqldumpWithPipe(ctx, mysqldumpArgs, fullOutputPath, config.CompressionRequired, config.CompressionType)
+	var written *dumpChecksums
+	if config.shouldVerifyBackup() {
+		written = &dumpChecksums{}
+	}
+
+	job := s.newDumpJob(config, nil, dbName)
+	stderrOutput, err := s.executeDumpWithPipe(ctx, config.Dumper, job, fullOutputPath, config.CompressionRequired, config.CompressionType, written)
 
 	// Tentukan status berdasarkan hasil eksekusi
 	backupStatus := "success"
 	var errorLogFile string
 
 	if err != nil {
-		// Fatal error - backup gagal total
+		// Fatal error - backup gagal total; file yang terpotong sudah dibuang oleh executeDumpWithPipe
+		return DatabaseBackupInfo{}, err
+	}
+
+	// File yang terpotong atau rusak dianggap gagal meskipun mysqldump selesai
+	verification, err := s.runPostWriteVerification(config, fullOutputPath, written)
+	if err != nil {
 		return DatabaseBackupInfo{}, err
 	}
 
@@ -225,11 +312,7 @@ func (s *Service) backupSingleDatabase(ctx context.Context, config BackupConfig,
 		s.Logger.Warnf("Database %s di-backup dengan warning (lihat: %s)", dbName, errorLogFile)
 	}
 
-	fileInfo, err := os.Stat(fullOutputPath)
-	var fileSize int64
-	if err == nil {
-		fileSize = fileInfo.Size()
-	}
+	fileSize := s.outputFileSize(fullOutputPath)
 
 	// Hitung compression ratio dan akurasi estimasi
 	// CompressionRatio = BackupFileSize / OriginalDBSize
@@ -270,6 +353,8 @@ func (s *Service) backupSingleDatabase(ctx context.Context, config BackupConfig,
 		Status:              backupStatus,
 		Warnings:            stderrOutput,
 		ErrorLogFile:        errorLogFile,
+		Verification:        verification,
+		Coordinates:         job.capturedCoordinates(),
 	}, nil
 }
 
@@ -293,16 +378,36 @@ func (s *Service) executeBackupCombined(ctx context.Context, config BackupConfig
 	outputFile := s.addFileExtensions(baseOutputFile+".sql", config)
 	fullOutputPath := filepath.Join(config.OutputDir, outputFile)
 
-	mysqldumpArgs := s.buildMysqldumpArgs(config.BaseDumpArgs, dbFiltered, "")
+	job := s.newDumpJob(config, dbFiltered, "")
 	s.Logger.Debug("Direktori output: " + config.OutputDir)
 	s.Logger.Debug("File output: " + fullOutputPath)
 
-	stderrOutput, err := s.executeMysqldumpWithPipe(ctx, mysqldumpArgs, fullOutputPath, config.CompressionRequired, config.CompressionType)
+	var written *dumpChecksums
+	if config.shouldVerifyBackup() {
+		written = &dumpChecksums{}
+	}
+
+	var stderrOutput string
+	attempts, err := s.retryTransient(ctx, "Backup combined", func() error {
+		var dumpErr error
+		stderrOutput, dumpErr = s.executeDumpWithPipe(ctx, config.Dumper, job, fullOutputPath, config.CompressionRequired, config.CompressionType, written)
+		return dumpErr
+	})
 	if err != nil {
-		errorMsg := fmt.Errorf("gagal menjalankan mysqldump: %w", err)
+		errorMsg := fmt.Errorf("gagal menjalankan %s: %w", config.Dumper.Name(), err)
 		res.errors = append(res.errors, errorMsg.Error())
 		for _, dbName := range dbFiltered {
-			res.failed = append(res.failed, FailedDatabaseInfo{DatabaseName: dbName, Error: errorMsg.Error()})
+			res.failed = append(res.failed, FailedDatabaseInfo{DatabaseName: dbName, Error: errorMsg.Error(), Attempts: attempts})
+		}
+		return res
+	}
+
+	// Satu file gabungan: jika verifikasi gagal, semua database di dalamnya dianggap gagal
+	verification, err := s.runPostWriteVerification(config, fullOutputPath, written)
+	if err != nil {
+		res.errors = append(res.errors, err.Error())
+		for _, dbName := range dbFiltered {
+			res.failed = append(res.failed, FailedDatabaseInfo{DatabaseName: dbName, Error: err.Error()})
 		}
 		return res
 	}
@@ -317,11 +422,7 @@ func (s *Service) executeBackupCombined(ctx context.Context, config BackupConfig
 	}
 
 	backupDuration := time.Since(backupStartTime)
-	fileInfo, err := os.Stat(fullOutputPath)
-	var fileSize int64
-	if err == nil {
-		fileSize = fileInfo.Size()
-	}
+	fileSize := s.outputFileSize(fullOutputPath)
 
 	// Hitung total estimasi dan total ukuran database asli untuk combined backup
 	var totalEstimated uint64
@@ -353,6 +454,7 @@ func (s *Service) executeBackupCombined(ctx context.Context, config BackupConfig
 		estimatedSizeHuman = s.formatFileSize(int64(totalEstimated))
 	}
 
+	coordinates := job.capturedCoordinates()
 	for _, dbName := range dbFiltered {
 		res.successful = append(res.successful, DatabaseBackupInfo{
 			DatabaseName:        dbName,
@@ -369,6 +471,9 @@ func (s *Service) executeBackupCombined(ctx context.Context, config BackupConfig
 			Status:              backupStatus,
 			Warnings:            stderrOutput,
 			ErrorLogFile:        errorLogFile,
+			Verification:        verification,
+			Coordinates:         coordinates,
+			Attempts:            attempts,
 		})
 	}
 
@@ -50,34 +50,18 @@ func (s *Service) AturMaxStatementsTime(ctx context.Context, client *database.Cl
 	return originalMaxStatementsTime, nil
 }
 
-// buildMysqldumpArgs membangun argumen mysqldump dengan kredensial database
+// buildConnectionArgs membangun argumen koneksi (host, port, user) untuk client MariaDB/MySQL
+// (mysqldump, mysql). Password tidak termasuk; dikirim lewat --defaults-extra-file oleh database.ClientCommand.
+func (s *Service) buildConnectionArgs(dbConn structs.ServerDBConnection) []string {
+	return database.BuildClientArgs(dbConn)
+}
+
+// buildMysqldumpArgs membangun argumen mysqldump tanpa password (lihat database.ClientCommand)
 // Parameter singleDB: jika tidak kosong, akan backup database tunggal tersebut
 // Parameter dbFiltered: list database untuk backup multiple (diabaikan jika singleDB diisi)
 func (s *Service) buildMysqldumpArgs(baseDumpArgs string, dbFiltered []string, singleDB string) []string {
-	var args []string
-
-	// Tambahkan kredensial database
-	dbConn := s.BackupOptions.DBConfig.ServerDBConnection
-
-	// Host
-	if dbConn.Host != "" {
-		args = append(args, "--host="+dbConn.Host)
-	}
-
-	// Port
-	if dbConn.Port != 0 {
-		args = append(args, fmt.Sprintf("--port=%d", dbConn.Port))
-	}
-
-	// User
-	if dbConn.User != "" {
-		args = append(args, "--user="+dbConn.User)
-	}
-
-	// Password
-	if dbConn.Password != "" {
-		args = append(args, "--password="+dbConn.Password)
-	}
+	// Tambahkan argumen koneksi (host, port, user)
+	args := s.buildConnectionArgs(s.BackupOptions.DBConfig.ServerDBConnection)
 
 	// Tambahkan argumen mysqldump dari konfigurasi
 	if baseDumpArgs != "" {
@@ -90,6 +74,16 @@ func (s *Service) buildMysqldumpArgs(baseDumpArgs string, dbFiltered []string, s
 		args = append(args, "--no-data")
 	}
 
+	// Koordinat replikasi ditulis ke header dump agar konsisten dengan snapshot
+	args = append(args, s.buildCoordinateArgs()...)
+
+	// Tambahkan --ignore-table hasil resolusi filter tabel
+	if singleDB != "" {
+		args = append(args, s.buildTableFilterArgs([]string{singleDB})...)
+	} else {
+		args = append(args, s.buildTableFilterArgs(dbFiltered)...)
+	}
+
 	// Mode single database
 	if singleDB != "" {
 		args = append(args, "--databases")
@@ -117,8 +111,13 @@ func (s *Service) buildMysqldumpArgs(baseDumpArgs string, dbFiltered []string, s
 func (s *Service) GetAndFilterDatabases(ctx context.Context, client *database.Client) ([]string, error) {
 	ui.PrintSubHeader("Mendapatkan dan Memfilter Database")
 	var DBList string
+	var includeDatabases []string
 	// Setup filter options
-	if s.BackupOptions.UseDBList && s.BackupOptions.DBList != "" {
+	if s.BackupDB != nil && len(s.BackupDB.DBName) > 0 {
+		// Daftar --db dari command 'backup database' menggantikan file database list
+		s.Logger.Infof("Menggunakan daftar database dari flag --db: %s", strings.Join(s.BackupDB.DBName, ", "))
+		includeDatabases = s.BackupDB.DBName
+	} else if s.BackupOptions.UseDBList && s.BackupOptions.DBList != "" {
 		s.Logger.Info("Menggunakan file database list untuk memfilter database...")
 		DBList = s.BackupOptions.DBList
 	} else {
@@ -129,7 +128,8 @@ func (s *Service) GetAndFilterDatabases(ctx context.Context, client *database.Cl
 	filterOpts := database.FilterOptions{
 		ExcludeSystem:    s.BackupOptions.Exclude.SystemsDB,
 		ExcludeDatabases: s.BackupOptions.Exclude.Databases,
-		IncludeFile:      DBList, // Whitelist file (jika ada)
+		IncludeDatabases: includeDatabases, // Whitelist dari flag --db (jika ada)
+		IncludeFile:      DBList,           // Whitelist file (jika ada)
 	}
 
 	// Execute filtering
@@ -140,6 +140,11 @@ func (s *Service) GetAndFilterDatabases(ctx context.Context, client *database.Cl
 		return nil, err
 	}
 
+	// Database yang diminta secara eksplisit harus ada di server
+	if missing := missingDatabases(includeDatabases, validDatabases); len(missing) > 0 {
+		return nil, fmt.Errorf("database tidak ditemukan di server: %s", strings.Join(missing, ", "))
+	}
+
 	// Log statistics
 	s.Logger.Infof("Ditemukan %d database di server.", stats.TotalFound)
 	s.Logger.Infof("Total database: %d, Untuk backup: %d, Dikecualikan: %d",
@@ -159,3 +164,20 @@ func (s *Service) GetAndFilterDatabases(ctx context.Context, client *database.Cl
 
 	return validDatabases, nil
 }
+
+// missingDatabases mengembalikan nama database pada requested yang tidak ada di found
+func missingDatabases(requested, found []string) []string {
+	foundSet := make(map[string]bool, len(found))
+	for _, name := range found {
+		foundSet[name] = true
+	}
+
+	var missing []string
+	for _, name := range requested {
+		name = strings.TrimSpace(name)
+		if name != "" && !foundSet[name] {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
@@ -1,44 +1,127 @@
 // File : internal/backup/backup_gtid.go
-// Deskripsi : GTID (Global Transaction Identifier) operations untuk backup
+// Deskripsi : GTID (Global Transaction Identifier) dan koordinat binlog untuk backup
 // Author : Hadiyatna Muflihun
 // Tanggal : 2024-10-08
-// Last Modified : 2024-10-15
+// Last Modified : 16 Oktober 2026
 
 package backup
 
 import (
 	"context"
+	"fmt"
+	"regexp"
 	"sfDBTools/pkg/database"
+	"strconv"
 )
 
-// CaptureGTIDIfNeeded menangani pengambilan GTID jika opsi diaktifkan
+const (
+	// coordinatesSourceMaster mencatat posisi binlog server yang di-backup (--master-data=2)
+	coordinatesSourceMaster = "master"
+	// coordinatesSourceSlave mencatat posisi master dari server replika yang di-backup (--dump-slave=2)
+	coordinatesSourceSlave = "slave"
+	// coordinatesHeaderLimit adalah jumlah byte awal output dump yang diperiksa untuk mencari koordinat
+	coordinatesHeaderLimit = 1 << 20
+)
+
+var (
+	// changeMasterPattern mencocokkan baris "-- CHANGE MASTER TO MASTER_LOG_FILE='...', MASTER_LOG_POS=..."
+	changeMasterPattern = regexp.MustCompile(`CHANGE MASTER TO\s+MASTER_LOG_FILE\s*=\s*'([^']+)'\s*,\s*MASTER_LOG_POS\s*=\s*(\d+)`)
+	// mariadbGTIDPattern mencocokkan baris "-- SET GLOBAL gtid_slave_pos='...'" dari mysqldump MariaDB
+	mariadbGTIDPattern = regexp.MustCompile(`SET GLOBAL gtid_slave_pos\s*=\s*'([^']*)'`)
+	// mysqlGTIDPattern mencocokkan baris "SET @@GLOBAL.GTID_PURGED=..." dari mysqldump MySQL
+	mysqlGTIDPattern = regexp.MustCompile(`SET @@GLOBAL\.GTID_PURGED\s*=\s*(?:/\*!80000 '\+'\*/\s*)?'([^']*)'`)
+)
+
+// CaptureGTIDIfNeeded memeriksa prasyarat capture koordinat dan mencatat posisi GTID sebelum dump dimulai.
+// Posisi ini menjadi referensi awal; koordinat yang konsisten dengan dump diurai dari header mysqldump.
 func (s *Service) CaptureGTIDIfNeeded(ctx context.Context, client *database.Client) error {
-	if s.BackupAll != nil && s.BackupAll.CaptureGtid {
-		// Cek dukungan GTID
-		enabled, pos, err := client.GetGTID(ctx)
+	if !s.BackupOptions.CaptureGtid {
+		return nil
+	}
 
-		if err != nil {
-			s.Logger.Warn("Gagal mendapatkan status GTID: " + err.Error())
-			s.Logger.Warn("Menonaktifkan opsi capture GTID.")
-			if s.BackupAll != nil {
-				s.BackupAll.CaptureGtid = false
+	switch s.BackupOptions.Coordinates {
+	case "":
+		s.BackupOptions.Coordinates = coordinatesSourceMaster
+	case coordinatesSourceMaster, coordinatesSourceSlave:
+	default:
+		return fmt.Errorf("sumber koordinat tidak valid: %s. Gunakan '%s' atau '%s'", s.BackupOptions.Coordinates, coordinatesSourceMaster, coordinatesSourceSlave)
+	}
+
+	// --master-data membutuhkan binary log aktif pada server yang di-backup
+	if s.BackupOptions.Coordinates == coordinatesSourceMaster {
+		enabled, err := client.IsBinlogEnabled(ctx)
+		if err != nil || !enabled {
+			if err != nil {
+				s.Logger.Warn("Gagal memeriksa status binary log: " + err.Error())
+			} else {
+				s.Logger.Warn("Binary log tidak aktif pada server ini.")
 			}
+			s.Logger.Warn("Menonaktifkan opsi capture GTID.")
+			s.BackupOptions.CaptureGtid = false
 			return nil
 		}
+	}
 
-		if enabled {
-			s.Logger.Info("GTID saat ini: " + pos)
-			// Simpan posisi GTID awal untuk referensi
-			if s.BackupAll != nil {
-				s.BackupAll.BackupInfo.GTIDCaptured = pos
-			}
+	// Cek dukungan GTID; tanpa GTID, koordinat file/posisi binlog tetap dicatat
+	enabled, pos, err := client.GetGTID(ctx)
+	switch {
+	case err != nil:
+		s.Logger.Warn("Gagal mendapatkan status GTID: " + err.Error())
+		s.Logger.Warn("Hanya koordinat file/posisi binlog yang akan dicatat.")
+	case enabled:
+		s.Logger.Info("GTID saat ini: " + pos)
+		// Simpan posisi GTID awal untuk referensi
+		s.BackupInfo.GTIDCaptured = pos
+	default:
+		s.Logger.Warn("GTID tidak diaktifkan pada server ini.")
+		s.Logger.Warn("Hanya koordinat file/posisi binlog yang akan dicatat.")
+	}
+	return nil
+}
+
+// buildCoordinateArgs mengembalikan argumen mysqldump untuk menulis koordinat replikasi (sebagai komentar) ke header dump
+func (s *Service) buildCoordinateArgs() []string {
+	if !s.BackupOptions.CaptureGtid {
+		return nil
+	}
+	if s.BackupOptions.Coordinates == coordinatesSourceSlave {
+		return []string{"--dump-slave=2"}
+	}
+	return []string{"--master-data=2"}
+}
+
+// headerCapture menyimpan byte awal output dump sampai batas tertentu
+type headerCapture struct {
+	buf   []byte
+	limit int
+}
+
+func (h *headerCapture) Write(p []byte) (int, error) {
+	if remaining := h.limit - len(h.buf); remaining > 0 {
+		if len(p) > remaining {
+			h.buf = append(h.buf, p[:remaining]...)
 		} else {
-			s.Logger.Warn("GTID tidak diaktifkan pada server ini.")
-			s.Logger.Warn("Menonaktifkan opsi capture GTID.")
-			if s.BackupAll != nil {
-				s.BackupAll.CaptureGtid = false
-			}
+			h.buf = append(h.buf, p...)
 		}
 	}
-	return nil
+	return len(p), nil
+}
+
+// parseReplicationCoordinates mengurai koordinat binlog dan GTID dari header dump mysqldump.
+// Mengembalikan false jika tidak ada koordinat yang ditemukan.
+func parseReplicationCoordinates(header []byte, coords *ReplicationCoordinates) bool {
+	found := false
+	if m := changeMasterPattern.FindSubmatch(header); m != nil {
+		coords.BinlogFile = string(m[1])
+		coords.BinlogPosition, _ = strconv.ParseInt(string(m[2]), 10, 64)
+		found = true
+	}
+	if m := mariadbGTIDPattern.FindSubmatch(header); m != nil {
+		coords.GTIDPosition = string(m[1])
+		found = true
+	} else if m := mysqlGTIDPattern.FindSubmatch(header); m != nil {
+		coords.GTIDPosition = string(m[1])
+		found = true
+	}
+	return found
 }
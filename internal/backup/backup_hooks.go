@@ -0,0 +1,195 @@
+// File : internal/backup/backup_hooks.go
+// Deskripsi : Menjalankan hook shell (pre_backup, post_backup, on_failure, per_database_post) dengan environment konteks backup
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sfDBTools/internal/appconfig"
+	"strings"
+	"time"
+)
+
+const (
+	hookPreBackup       = "pre_backup"
+	hookPostBackup      = "post_backup"
+	hookOnFailure       = "on_failure"
+	hookPerDatabasePost = "per_database_post"
+
+	// defaultHookTimeout dipakai jika hooks.timeout maupun timeout per hook tidak diisi
+	defaultHookTimeout = 5 * time.Minute
+	// hookOutputTail adalah ukuran ekor output hook yang dicatat ke log dan pesan error
+	hookOutputTail = 2048
+)
+
+// hookContext adalah nilai yang diteruskan ke hook sebagai environment variable SFDB_*
+type hookContext struct {
+	BackupID    string
+	BackupMode  string
+	OutputDir   string
+	Status      string
+	SummaryFile string
+	Database    string
+	OutputFile  string
+	Error       string
+}
+
+// environ mengembalikan environment proses ditambah variabel SFDB_* untuk stage hook
+func (h hookContext) environ(stage string) []string {
+	return append(os.Environ(),
+		"SFDB_HOOK="+stage,
+		"SFDB_BACKUP_ID="+h.BackupID,
+		"SFDB_BACKUP_MODE="+h.BackupMode,
+		"SFDB_OUTPUT_DIR="+h.OutputDir,
+		"SFDB_STATUS="+h.Status,
+		"SFDB_SUMMARY_FILE="+h.SummaryFile,
+		"SFDB_DATABASE="+h.Database,
+		"SFDB_OUTPUT_FILE="+h.OutputFile,
+		"SFDB_ERROR="+h.Error,
+	)
+}
+
+// hookCommands mengembalikan daftar hook dari konfigurasi untuk stage tertentu
+func (s *Service) hookCommands(stage string) []appconfig.HookCommand {
+	if s.Config == nil {
+		return nil
+	}
+	hooks := s.Config.Backup.Hooks
+	switch stage {
+	case hookPreBackup:
+		return hooks.PreBackup
+	case hookPostBackup:
+		return hooks.PostBackup
+	case hookOnFailure:
+		return hooks.OnFailure
+	case hookPerDatabasePost:
+		return hooks.PerDatabasePost
+	}
+	return nil
+}
+
+// hookTimeout menentukan batas waktu hook: timeout per hook, lalu hooks.timeout, lalu default
+func (s *Service) hookTimeout(hook appconfig.HookCommand) time.Duration {
+	if hook.Timeout > 0 {
+		return time.Duration(hook.Timeout) * time.Second
+	}
+	if s.Config != nil && s.Config.Backup.Hooks.Timeout > 0 {
+		return time.Duration(s.Config.Backup.Hooks.Timeout) * time.Second
+	}
+	return defaultHookTimeout
+}
+
+// runHooks menjalankan semua hook pada stage secara berurutan dan berhenti pada hook pertama yang gagal
+func (s *Service) runHooks(ctx context.Context, stage string, hc hookContext) error {
+	for i, hook := range s.hookCommands(stage) {
+		if strings.TrimSpace(hook.Command) == "" {
+			continue
+		}
+		name := hook.Name
+		if name == "" {
+			name = fmt.Sprintf("%s#%d", stage, i+1)
+		}
+		if err := s.runHook(ctx, stage, name, hook, hc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runHook menjalankan satu hook dengan sh -c dan batas waktu, mencatat ekor output-nya ke log
+func (s *Service) runHook(ctx context.Context, stage, name string, hook appconfig.HookCommand, hc hookContext) error {
+	timeout := s.hookTimeout(hook)
+	hookCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	s.Logger.Infof("Menjalankan hook %s '%s'", stage, name)
+	start := time.Now()
+
+	output := &tailBuffer{size: hookOutputTail}
+	cmd := exec.CommandContext(hookCtx, "sh", "-c", hook.Command)
+	cmd.Env = hc.environ(stage)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	// Proses anak hook tidak boleh menahan pipe output setelah sh dihentikan karena timeout
+	cmd.WaitDelay = 5 * time.Second
+
+	err := cmd.Run()
+	tail := strings.TrimSpace(string(output.buf))
+	if tail != "" {
+		s.Logger.Debugf("Output hook %s '%s':\n%s", stage, name, tail)
+	}
+
+	if errors.Is(hookCtx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("hook %s '%s' melewati batas waktu %s", stage, name, timeout)
+	}
+	if err != nil {
+		return fmt.Errorf("hook %s '%s' gagal: %w: %s", stage, name, err, tail)
+	}
+	s.Logger.Infof("Hook %s '%s' selesai dalam %s", stage, name, time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// runDatabaseHooks menjalankan per_database_post untuk setiap hasil backup.
+// Pada mode combined semua database berada di satu file, sehingga hook hanya dijalankan sekali.
+func (s *Service) runDatabaseHooks(ctx context.Context, hc hookContext, result backupResult) {
+	if len(s.hookCommands(hookPerDatabasePost)) == 0 {
+		return
+	}
+	run := func(dbHC hookContext) {
+		if err := s.runHooks(ctx, hookPerDatabasePost, dbHC); err != nil {
+			s.Logger.Warnf("%v", err)
+		}
+	}
+
+	if hc.BackupMode != "separate" {
+		dbHC := hc
+		dbHC.Database = "all_databases"
+		dbHC.Status = determineBackupStatus(len(result.successful), len(result.failed))
+		if len(result.successful) > 0 {
+			dbHC.OutputFile = result.successful[0].OutputFile
+		}
+		if len(result.failed) > 0 {
+			dbHC.Error = result.failed[0].Error
+		}
+		run(dbHC)
+		return
+	}
+
+	for _, info := range result.successful {
+		dbHC := hc
+		dbHC.Database = info.DatabaseName
+		dbHC.OutputFile = info.OutputFile
+		dbHC.Status = info.Status
+		run(dbHC)
+	}
+	for _, failed := range result.failed {
+		dbHC := hc
+		dbHC.Database = failed.DatabaseName
+		dbHC.Status = "failed"
+		dbHC.Error = failed.Error
+		run(dbHC)
+	}
+}
+
+// runCompletionHooks menjalankan post_backup setelah summary disimpan, lalu on_failure jika backup tidak sukses penuh
+func (s *Service) runCompletionHooks(ctx context.Context, hc hookContext, backupErr error) {
+	hc.SummaryFile = filepath.Join(s.getSummaryDir(), hc.BackupID+".json")
+	if err := s.runHooks(ctx, hookPostBackup, hc); err != nil {
+		s.Logger.Warnf("%v", err)
+	}
+	if backupErr == nil {
+		return
+	}
+	hc.Error = backupErr.Error()
+	if err := s.runHooks(ctx, hookOnFailure, hc); err != nil {
+		s.Logger.Warnf("%v", err)
+	}
+}
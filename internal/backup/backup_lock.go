@@ -0,0 +1,77 @@
+// File : internal/backup/backup_lock.go
+// Deskripsi : Lock antar proses agar backup, retry, dan cleanup tidak berjalan bersamaan pada direktori output yang sama
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package backup
+
+import (
+	"context"
+	"path/filepath"
+	"sfDBTools/pkg/fs"
+	"sfDBTools/pkg/storage"
+	"strings"
+	"time"
+)
+
+// backupLockName adalah nama lock bersama untuk semua perintah backup dan cleanup
+const backupLockName = "backup"
+
+// backupLockSubdir adalah subdirektori lock di dalam direktori output backup
+const backupLockSubdir = ".sfdbtools"
+
+// AcquireBackupLock mengambil lock backup sehingga dua backup (atau backup dan cleanup) tidak
+// menulis ke direktori output yang sama secara bersamaan. Mengembalikan fungsi untuk melepas lock.
+func (s *Service) AcquireBackupLock(ctx context.Context) (func(), error) {
+	opts := s.BackupOptions.Lock
+	policy := fs.LockPolicy{
+		Wait:    opts.Wait,
+		NoWait:  opts.NoWait,
+		Timeout: time.Duration(opts.WaitTimeout) * time.Second,
+	}
+
+	lock, err := fs.AcquireLockWithPolicy(ctx, s.lockDir(), backupLockName, "backup atau cleanup", policy, s.Logger)
+	if err != nil {
+		return nil, err
+	}
+	s.Logger.Debugf("Lock backup diperoleh: %s", lock.Path)
+
+	return func() {
+		if err := lock.Release(); err != nil {
+			s.Logger.Warnf("Gagal melepas lock backup: %v", err)
+		}
+	}, nil
+}
+
+// lockDir mengembalikan direktori lock dan PID file backup. Untuk storage local, lock berada di
+// direktori output backup agar host lain yang menulis ke output yang sama (misalnya NFS) ikut
+// terserialisasi. Untuk storage remote (s3, sftp) dipakai direktori state lokal di bawah direktori log.
+func (s *Service) lockDir() string {
+	baseDir := ""
+	if s.Config != nil {
+		baseDir = s.Config.Backup.Output.BaseDirectory
+	}
+	if baseDir == "" && s.BackupOptions != nil {
+		baseDir = s.BackupOptions.OutputDirectory
+	}
+	if baseDir == "" || !s.storageIsLocal() {
+		return s.Config.StateDir("backup")
+	}
+	if abs, err := filepath.Abs(baseDir); err == nil {
+		baseDir = abs
+	}
+	return filepath.Join(baseDir, backupLockSubdir)
+}
+
+// storageIsLocal mengembalikan true jika file backup ditulis ke filesystem lokal
+func (s *Service) storageIsLocal() bool {
+	if s.Storage != nil {
+		return s.Storage.Type() == storage.TypeLocal
+	}
+	if s.Config == nil {
+		return true
+	}
+	storageType := strings.ToLower(strings.TrimSpace(s.Config.Backup.Storage.Type))
+	return storageType == "" || storageType == storage.TypeLocal
+}
@@ -11,6 +11,9 @@ import (
 	log "sfDBTools/internal/applog"
 	"sfDBTools/internal/structs"
 	"sfDBTools/pkg/database"
+	"sfDBTools/pkg/fs"
+	"sfDBTools/pkg/storage"
+	"sync"
 )
 
 // Service adalah layanan inti yang menjalankan logika dbconfig.
@@ -21,15 +24,21 @@ type Service struct {
 	BackupAll            *structs.BackupAllFlags
 	BackupInfo           *structs.BackupInfo
 	BackupOptions        *structs.BackupOptions
+	RestoreOptions       *structs.BackupRestoreFlags
+	PhysicalOptions      *structs.PhysicalOptions // Opsi backup fisik (hanya untuk 'backup physical')
 	DBConfigInfo         *structs.DBConfigInfo
 	DatabaseDetail       map[string]structs.DatabaseDetail
 	DatabaseInfoDetail   map[string]database.DatabaseDetailInfo
 	DBDetail             structs.DatabaseDetail
 	DiskSpaceCheckResult *structs.DiskSpaceCheckResult
 	EstimateOptions      *structs.EstimateOptions
-	FilterInfo           *structs.FilterInfo  // Informasi statistik filtering database
-	FilterStats          *DatabaseFilterStats // Statistik filtering database
-	Client               *database.Client     // Client database aktif selama backup
+	FilterInfo           *structs.FilterInfo      // Informasi statistik filtering database
+	FilterStats          *DatabaseFilterStats     // Statistik filtering database
+	TableFilters         map[string]dbTableFilter // Hasil resolusi filter tabel per database
+	Client               *database.Client         // Client database aktif selama backup
+	BandwidthLimiter     *fs.RateLimiter          // Pembatas kecepatan tulis bersama untuk semua worker (nil = tanpa batas)
+	Storage              storage.Storage          // Tujuan penyimpanan file backup, dibuka dari backup.storage saat dibutuhkan
+	storageMu            sync.Mutex
 }
 
 // NewService membuat instance baru dari Service dengan dependensi yang di-inject.
@@ -55,6 +64,47 @@ func NewService(logger log.Logger, cfg *config.Config, dbConfig interface{}) *Se
 			svc.BackupOptions = &v.BackupOptions
 			svc.DBConfigInfo = &v.BackupOptions.DBConfig
 			svc.DBConfigInfo.ServerDBConnection = v.BackupOptions.DBConfig.ServerDBConnection
+		case *structs.BackupRestoreFlags:
+			// Untuk restore, koneksi tujuan diambil dari profil dbconfig pada flags restore
+			svc.RestoreOptions = v
+			svc.BackupInfo = &structs.BackupInfo{}
+			svc.BackupOptions = &structs.BackupOptions{}
+			svc.DBConfigInfo = &v.DBConfig
+		case *structs.BackupRetryFlags:
+			// Retry memakai koneksi dan kebijakan retry dari flags; opsi backup lain diambil dari summary
+			svc.BackupInfo = &structs.BackupInfo{}
+			svc.BackupOptions = &structs.BackupOptions{
+				DBConfig:    v.DBConfig,
+				Retry:       v.Retry,
+				Concurrency: v.Concurrency,
+				Lock:        v.Lock,
+				Encryption:  structs.EncryptionOptions{Key: v.EncryptionKey},
+			}
+			svc.DBConfigInfo = &svc.BackupOptions.DBConfig
+		case *structs.BackupPhysicalFlags:
+			// Backup fisik hanya memakai opsi output, kompresi, enkripsi, verifikasi, cleanup, dan throttling
+			svc.PhysicalOptions = &v.Physical
+			svc.BackupInfo = &structs.BackupInfo{}
+			svc.BackupOptions = &structs.BackupOptions{
+				OutputDirectory: v.OutputDirectory,
+				DBConfig:        v.DBConfig,
+				Encryption:      v.Encryption,
+				Compression:     v.Compression,
+				Verification:    v.Verification,
+				Cleanup:         v.Cleanup,
+				Lock:            v.Lock,
+				Concurrency: structs.ConcurrencyOptions{
+					Parallel:     v.Parallel,
+					MaxBandwidth: v.MaxBandwidth,
+				},
+			}
+			svc.DBConfigInfo = &svc.BackupOptions.DBConfig
+		case *structs.BackupVerifyFlags:
+			// Verifikasi manifest hanya membaca file, tidak memerlukan koneksi database
+			svc.BackupOptions = &structs.BackupOptions{}
+		case *structs.BackupNotifyFlags:
+			// Notifikasi hanya membaca summary dari storage, tidak memerlukan koneksi database
+			svc.BackupOptions = &structs.BackupOptions{}
 		case *structs.BackupSummaryFlags:
 			// Untuk summary command, tidak perlu BackupOptions karena langsung menggunakan config
 			svc.BackupOptions = &structs.BackupOptions{}
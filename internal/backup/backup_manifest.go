@@ -0,0 +1,431 @@
+// File : internal/backup/backup_manifest.go
+// Deskripsi : Pembuatan, pembacaan, dan verifikasi file MANIFEST checksum untuk setiap backup set
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package backup
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sfDBTools/internal/structs"
+	"sfDBTools/pkg/compress"
+	"sfDBTools/pkg/storage"
+	"sfDBTools/pkg/ui"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// manifestHeader adalah baris pertama file MANIFEST untuk mengenali format
+	manifestHeader = "# sfDBTools backup manifest v1"
+	// manifestExtension adalah ekstensi file MANIFEST di direktori summary
+	manifestExtension = ".MANIFEST"
+	// manifestFieldCount adalah jumlah kolom per baris entry: sha256, size, compression, encrypted, databases, path
+	manifestFieldCount = 6
+	// manifestExtraGrace adalah toleransi waktu modifikasi file saat mencari file "extra"
+	manifestExtraGrace = time.Minute
+)
+
+// ManifestEntry adalah satu file output di dalam MANIFEST
+type ManifestEntry struct {
+	SHA256      string
+	Size        int64
+	Compression string
+	Encrypted   bool
+	Databases   []string
+	Path        string
+}
+
+// BackupManifest adalah isi file MANIFEST dari satu backup set
+type BackupManifest struct {
+	BackupID        string
+	StartTime       time.Time
+	EndTime         time.Time
+	OutputDirectory string
+	Entries         []ManifestEntry
+}
+
+// ManifestVerifyResult adalah hasil verifikasi satu file terhadap MANIFEST
+type ManifestVerifyResult struct {
+	Path   string
+	Status string // "ok", "missing", "changed", "extra"
+	Detail string
+}
+
+// SaveManifest membuat file MANIFEST untuk backup set di samping file summary.
+// Checksum yang sudah dihitung saat verifikasi dipakai ulang; selain itu file di-hash ulang.
+func (s *Service) SaveManifest(summary *BackupSummary) (string, error) {
+	manifest, err := s.buildManifest(summary)
+	if err != nil {
+		return "", err
+	}
+
+	store, err := s.getStorage()
+	if err != nil {
+		return "", err
+	}
+	summaryDir := s.getSummaryDir()
+	if err := store.MkdirAll(summaryDir); err != nil {
+		return "", fmt.Errorf("gagal membuat direktori summary: %w", err)
+	}
+
+	manifestPath := filepath.Join(summaryDir, summary.BackupID+manifestExtension)
+	if err := writeManifestFile(store, manifestPath, manifest); err != nil {
+		return "", err
+	}
+
+	s.Logger.Infof("Manifest backup disimpan ke: %s", manifestPath)
+	return manifestPath, nil
+}
+
+// buildManifest menyusun MANIFEST dari file output yang tercatat pada summary
+func (s *Service) buildManifest(summary *BackupSummary) (*BackupManifest, error) {
+	// Kelompokkan database berdasarkan file output (mode combined: banyak database per file)
+	databasesByFile := make(map[string][]string)
+	for _, db := range summary.SuccessfulDatabases {
+		databasesByFile[db.OutputFile] = append(databasesByFile[db.OutputFile], db.DatabaseName)
+	}
+
+	manifest := &BackupManifest{
+		BackupID:        summary.BackupID,
+		StartTime:       summary.StartTime,
+		EndTime:         summary.EndTime,
+		OutputDirectory: summary.OutputInfo.OutputDirectory,
+	}
+
+	for _, file := range summary.OutputInfo.Files {
+		sum := file.SHA256
+		size := file.Size
+		if sum == "" {
+			var err error
+			sum, size, err = s.hashFileSHA256(file.FilePath)
+			if err != nil {
+				return nil, fmt.Errorf("gagal menghitung checksum %s: %w", file.FilePath, err)
+			}
+		}
+
+		encrypted, err := s.isEncryptedBackupFile(file.FilePath)
+		if err != nil {
+			return nil, fmt.Errorf("gagal memeriksa enkripsi %s: %w", file.FilePath, err)
+		}
+
+		databases := databasesByFile[file.FilePath]
+		sort.Strings(databases)
+
+		manifest.Entries = append(manifest.Entries, ManifestEntry{
+			SHA256:      sum,
+			Size:        size,
+			Compression: string(compress.DetectCompressionTypeFromFile(file.FilePath)),
+			Encrypted:   encrypted,
+			Databases:   databases,
+			Path:        file.FilePath,
+		})
+	}
+
+	sort.Slice(manifest.Entries, func(i, j int) bool {
+		return manifest.Entries[i].Path < manifest.Entries[j].Path
+	})
+	return manifest, nil
+}
+
+// writeManifestFile menulis MANIFEST dalam format teks: metadata sebagai komentar, satu baris per file (dipisah tab)
+func writeManifestFile(store storage.Storage, path string, manifest *BackupManifest) error {
+	var sb strings.Builder
+	sb.WriteString(manifestHeader + "\n")
+	fmt.Fprintf(&sb, "# backup_id: %s\n", manifest.BackupID)
+	fmt.Fprintf(&sb, "# start_time: %s\n", manifest.StartTime.Format(time.RFC3339))
+	fmt.Fprintf(&sb, "# end_time: %s\n", manifest.EndTime.Format(time.RFC3339))
+	fmt.Fprintf(&sb, "# output_directory: %s\n", manifest.OutputDirectory)
+	sb.WriteString("# sha256\tsize\tcompression\tencrypted\tdatabases\tpath\n")
+
+	for _, entry := range manifest.Entries {
+		fmt.Fprintf(&sb, "%s\t%d\t%s\t%t\t%s\t%s\n",
+			entry.SHA256,
+			entry.Size,
+			entry.Compression,
+			entry.Encrypted,
+			strings.Join(entry.Databases, ","),
+			entry.Path,
+		)
+	}
+
+	if err := storage.WriteFile(store, path, []byte(sb.String())); err != nil {
+		return fmt.Errorf("gagal menulis file manifest: %w", err)
+	}
+	return nil
+}
+
+// readManifestFile membaca dan mengurai file MANIFEST
+func readManifestFile(store storage.Storage, path string) (*BackupManifest, error) {
+	file, err := store.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuka file manifest: %w", err)
+	}
+	defer file.Close()
+
+	manifest := &BackupManifest{}
+	scanner := bufio.NewScanner(file)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Text()
+
+		if lineNo == 1 {
+			if line != manifestHeader {
+				return nil, fmt.Errorf("file %s bukan manifest sfDBTools yang valid", path)
+			}
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "#") {
+			key, value, found := strings.Cut(strings.TrimSpace(strings.TrimPrefix(line, "#")), ": ")
+			if !found {
+				continue
+			}
+			switch key {
+			case "backup_id":
+				manifest.BackupID = value
+			case "start_time":
+				manifest.StartTime, _ = time.Parse(time.RFC3339, value)
+			case "end_time":
+				manifest.EndTime, _ = time.Parse(time.RFC3339, value)
+			case "output_directory":
+				manifest.OutputDirectory = value
+			}
+			continue
+		}
+
+		fields := strings.SplitN(line, "\t", manifestFieldCount)
+		if len(fields) != manifestFieldCount {
+			return nil, fmt.Errorf("baris manifest %d tidak valid", lineNo)
+		}
+		size, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("ukuran file pada baris manifest %d tidak valid: %w", lineNo, err)
+		}
+		encrypted, err := strconv.ParseBool(fields[3])
+		if err != nil {
+			return nil, fmt.Errorf("status enkripsi pada baris manifest %d tidak valid: %w", lineNo, err)
+		}
+
+		var databases []string
+		if fields[4] != "" {
+			databases = strings.Split(fields[4], ",")
+		}
+
+		manifest.Entries = append(manifest.Entries, ManifestEntry{
+			SHA256:      fields[0],
+			Size:        size,
+			Compression: fields[2],
+			Encrypted:   encrypted,
+			Databases:   databases,
+			Path:        fields[5],
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("gagal membaca file manifest: %w", err)
+	}
+	if lineNo == 0 {
+		return nil, fmt.Errorf("file manifest %s kosong", path)
+	}
+
+	return manifest, nil
+}
+
+// hashFileSHA256 menghitung SHA-256 dan ukuran sebuah file pada storage secara streaming
+func (s *Service) hashFileSHA256(path string) (string, int64, error) {
+	store, err := s.getStorage()
+	if err != nil {
+		return "", 0, err
+	}
+	file, err := store.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	size, err := io.Copy(hasher, file)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), size, nil
+}
+
+// ExecuteVerify menjalankan verifikasi manifest berdasarkan flags command 'backup verify'
+func (s *Service) ExecuteVerify(verifyFlags *structs.BackupVerifyFlags) error {
+	switch {
+	case verifyFlags.Manifest != "" && verifyFlags.BackupID != "":
+		return fmt.Errorf("--manifest dan --backup-id tidak dapat digunakan bersamaan")
+	case verifyFlags.Manifest != "":
+		return s.VerifyManifest(verifyFlags.Manifest)
+	case verifyFlags.BackupID != "":
+		return s.VerifyManifestByBackupID(verifyFlags.BackupID)
+	default:
+		return fmt.Errorf("salah satu dari --manifest atau --backup-id wajib diisi")
+	}
+}
+
+// VerifyManifestByBackupID memverifikasi backup set berdasarkan backup ID (MANIFEST di direktori summary)
+func (s *Service) VerifyManifestByBackupID(backupID string) error {
+	return s.VerifyManifest(filepath.Join(s.getSummaryDir(), backupID+manifestExtension))
+}
+
+// VerifyManifest menghitung ulang checksum setiap file pada MANIFEST dan melaporkan file
+// yang hilang (missing), berubah (changed), atau file backup lain di direktori yang sama
+// yang ditulis selama rentang waktu backup namun tidak tercatat (extra).
+func (s *Service) VerifyManifest(manifestPath string) error {
+	ui.Headers("Verifikasi Manifest Backup")
+
+	store, err := s.getStorage()
+	if err != nil {
+		return err
+	}
+	manifest, err := readManifestFile(store, manifestPath)
+	if err != nil {
+		return err
+	}
+
+	ui.PrintInfo(fmt.Sprintf("Manifest: %s (backup ID: %s, %d file)", manifestPath, manifest.BackupID, len(manifest.Entries)))
+
+	results := s.checkManifestEntries(store, manifest)
+	extras, err := s.findManifestExtras(store, manifest)
+	if err != nil {
+		s.Logger.Warnf("Gagal memeriksa file extra: %v", err)
+	}
+	results = append(results, extras...)
+
+	return s.displayManifestResults(results)
+}
+
+// checkManifestEntries memeriksa keberadaan, ukuran, dan checksum setiap entry
+func (s *Service) checkManifestEntries(store storage.Storage, manifest *BackupManifest) []ManifestVerifyResult {
+	results := make([]ManifestVerifyResult, 0, len(manifest.Entries))
+	for _, entry := range manifest.Entries {
+		result := ManifestVerifyResult{Path: entry.Path, Status: "ok"}
+
+		info, err := store.Stat(entry.Path)
+		switch {
+		case storage.IsNotExist(err):
+			result.Status = "missing"
+			result.Detail = "file tidak ditemukan"
+		case err != nil:
+			result.Status = "missing"
+			result.Detail = err.Error()
+		case info.Size != entry.Size:
+			// Ukuran berbeda sudah cukup untuk menandai file berubah tanpa perlu hashing
+			result.Status = "changed"
+			result.Detail = fmt.Sprintf("ukuran %d, seharusnya %d", info.Size, entry.Size)
+		default:
+			sum, _, err := s.hashFileSHA256(entry.Path)
+			if err != nil {
+				result.Status = "changed"
+				result.Detail = fmt.Sprintf("gagal membaca file: %v", err)
+			} else if sum != entry.SHA256 {
+				result.Status = "changed"
+				result.Detail = "checksum SHA-256 tidak cocok"
+			}
+		}
+
+		s.Logger.Debugf("Verifikasi manifest %s: %s", entry.Path, result.Status)
+		results = append(results, result)
+	}
+	return results
+}
+
+// findManifestExtras mencari file backup di direktori entry manifest yang dimodifikasi
+// selama rentang waktu backup namun tidak tercatat di manifest.
+func (s *Service) findManifestExtras(store storage.Storage, manifest *BackupManifest) ([]ManifestVerifyResult, error) {
+	if manifest.StartTime.IsZero() || manifest.EndTime.IsZero() {
+		return nil, nil
+	}
+
+	listed := make(map[string]bool, len(manifest.Entries))
+	dirs := make(map[string]bool)
+	for _, entry := range manifest.Entries {
+		listed[filepath.Clean(entry.Path)] = true
+		dirs[filepath.Dir(entry.Path)] = true
+	}
+	if manifest.OutputDirectory != "" {
+		dirs[filepath.Clean(manifest.OutputDirectory)] = true
+	}
+
+	windowStart := manifest.StartTime.Add(-manifestExtraGrace)
+	windowEnd := manifest.EndTime.Add(manifestExtraGrace)
+
+	var extras []ManifestVerifyResult
+	for dir := range dirs {
+		files, err := store.List(dir)
+		if err != nil {
+			if storage.IsNotExist(err) {
+				continue
+			}
+			return extras, fmt.Errorf("gagal membaca direktori %s: %w", dir, err)
+		}
+
+		for _, file := range files {
+			// List bersifat rekursif; hanya file yang langsung berada di dir yang diperiksa
+			path := filepath.Clean(file.Path)
+			if filepath.Dir(path) != dir || !s.isBackupFile(file.Name()) || listed[path] {
+				continue
+			}
+			if file.ModTime.Before(windowStart) || file.ModTime.After(windowEnd) {
+				continue
+			}
+			extras = append(extras, ManifestVerifyResult{
+				Path:   path,
+				Status: "extra",
+				Detail: "tidak tercatat di manifest",
+			})
+		}
+	}
+
+	sort.Slice(extras, func(i, j int) bool { return extras[i].Path < extras[j].Path })
+	return extras, nil
+}
+
+// displayManifestResults menampilkan hasil verifikasi dan mengembalikan error jika ada file bermasalah
+func (s *Service) displayManifestResults(results []ManifestVerifyResult) error {
+	counts := make(map[string]int)
+	var rows [][]string
+	for _, r := range results {
+		counts[r.Status]++
+		icon := "✅"
+		if r.Status != "ok" {
+			icon = "❌"
+		}
+		detail := r.Detail
+		if detail == "" {
+			detail = "-"
+		}
+		rows = append(rows, []string{icon + " " + r.Status, r.Path, detail})
+	}
+
+	ui.PrintSubHeader("Hasil Verifikasi")
+	if len(rows) > 0 {
+		ui.FormatTable([]string{"Status", "File", "Detail"}, rows)
+	}
+
+	ui.PrintInfo(fmt.Sprintf("OK: %d, Missing: %d, Changed: %d, Extra: %d",
+		counts["ok"], counts["missing"], counts["changed"], counts["extra"]))
+
+	if counts["missing"] > 0 || counts["changed"] > 0 || counts["extra"] > 0 {
+		ui.PrintError("Backup set tidak sesuai dengan manifest")
+		return fmt.Errorf("verifikasi manifest gagal: %d missing, %d changed, %d extra",
+			counts["missing"], counts["changed"], counts["extra"])
+	}
+
+	ui.PrintSuccess("Semua file backup sesuai dengan manifest")
+	return nil
+}
@@ -0,0 +1,204 @@
+// File : internal/backup/backup_metrics.go
+// Deskripsi : Metrik Prometheus dari summary backup (durasi, ukuran, rasio kompresi, status per database)
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package backup
+
+import (
+	"fmt"
+	"path/filepath"
+	"sfDBTools/pkg/metrics"
+	"sfDBTools/pkg/storage"
+	"sort"
+	"strings"
+	"time"
+)
+
+// backupMetricsFile adalah nama file textfile collector untuk metrik backup
+const backupMetricsFile = "sfdbtools_backup.prom"
+
+// databaseMetricState adalah kondisi terakhir satu database yang dihimpun dari semua summary
+type databaseMetricState struct {
+	lastSuccess      time.Time
+	lastFailure      time.Time
+	sizeBytes        int64
+	compressionRatio float64
+	warnings         bool
+}
+
+// loadAllSummaries membaca semua summary JSON di direktori summary, diurutkan dari yang paling lama
+func (s *Service) loadAllSummaries() ([]*BackupSummary, error) {
+	store, err := s.getStorage()
+	if err != nil {
+		return nil, err
+	}
+	summaryDir := s.getSummaryDir()
+	entries, err := store.List(summaryDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var summaries []*BackupSummary
+	for _, entry := range entries {
+		filePath := filepath.Clean(entry.Path)
+		if filepath.Dir(filePath) != filepath.Clean(summaryDir) || !strings.HasSuffix(strings.ToLower(entry.Name()), ".json") {
+			continue
+		}
+		summary, err := s.readSummaryFromJSON(filePath)
+		if err != nil {
+			s.Logger.Warnf("Gagal memproses file summary %s: %v", entry.Name(), err)
+			continue
+		}
+		summaries = append(summaries, summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].StartTime.Before(summaries[j].StartTime)
+	})
+	return summaries, nil
+}
+
+// GatherBackupMetrics mengisi collector dengan metrik backup dari semua summary yang tersimpan
+func (s *Service) GatherBackupMetrics(c *metrics.Collector) error {
+	summaries, err := s.loadAllSummaries()
+	if storage.IsNotExist(err) {
+		return nil // Belum ada backup sama sekali
+	}
+	if err != nil {
+		return fmt.Errorf("gagal membaca summary backup: %w", err)
+	}
+
+	runsByStatus := make(map[string]int)
+	latestByMode := make(map[string]*BackupSummary)
+	lastSuccessByMode := make(map[string]time.Time)
+	databases := make(map[string]*databaseMetricState)
+
+	for _, summary := range summaries {
+		runsByStatus[summary.Status]++
+		latestByMode[summary.BackupMode] = summary
+		if summary.Status == "success" {
+			lastSuccessByMode[summary.BackupMode] = summary.EndTime
+		}
+
+		// Database yang berhasil lewat 'backup retry' memakai waktu retry, bukan waktu backup awal
+		retriedAt := make(map[string]time.Time)
+		for _, attempt := range summary.RetryHistory {
+			for _, dbName := range attempt.Succeeded {
+				retriedAt[dbName] = attempt.Time
+			}
+		}
+
+		for _, info := range summary.SuccessfulDatabases {
+			state := databaseState(databases, info.DatabaseName)
+			at := summary.EndTime
+			if t, ok := retriedAt[info.DatabaseName]; ok {
+				at = t
+			}
+			if at.Before(state.lastSuccess) {
+				continue
+			}
+			state.lastSuccess = at
+			state.sizeBytes = info.FileSize
+			state.compressionRatio = info.CompressionRatio
+			state.warnings = info.Status == "success_with_warnings"
+		}
+		for _, failed := range summary.FailedDatabases {
+			state := databaseState(databases, failed.DatabaseName)
+			if summary.EndTime.After(state.lastFailure) {
+				state.lastFailure = summary.EndTime
+			}
+		}
+	}
+
+	for _, status := range sortedKeys(runsByStatus) {
+		c.Gauge("sfdbtools_backup_runs", "Jumlah backup yang summary-nya masih tersimpan, per status",
+			float64(runsByStatus[status]), metrics.L("status", status))
+	}
+
+	for _, mode := range sortedKeys(latestByMode) {
+		summary := latestByMode[mode]
+		modeLabel := metrics.L("mode", mode)
+		success := 0.0
+		if summary.Status == "success" {
+			success = 1
+		}
+		c.Gauge("sfdbtools_backup_last_run_timestamp_seconds", "Waktu selesai backup terakhir (unix)", unixSeconds(summary.EndTime), modeLabel)
+		c.Gauge("sfdbtools_backup_last_run_duration_seconds", "Durasi backup terakhir", summary.EndTime.Sub(summary.StartTime).Seconds(), modeLabel)
+		c.Gauge("sfdbtools_backup_last_run_bytes_written", "Total ukuran file yang ditulis backup terakhir", float64(summary.OutputInfo.TotalSize), modeLabel)
+		c.Gauge("sfdbtools_backup_last_run_success", "1 jika backup terakhir berstatus success", success, modeLabel)
+
+		stats := summary.DatabaseStats
+		c.Gauge("sfdbtools_backup_last_run_databases", "Jumlah database pada backup terakhir per hasil",
+			float64(stats.SuccessfulBackups-stats.SuccessWithWarnings), modeLabel, metrics.L("result", "success"))
+		c.Gauge("sfdbtools_backup_last_run_databases", "Jumlah database pada backup terakhir per hasil",
+			float64(stats.SuccessWithWarnings), modeLabel, metrics.L("result", "warning"))
+		c.Gauge("sfdbtools_backup_last_run_databases", "Jumlah database pada backup terakhir per hasil",
+			float64(stats.FailedBackups), modeLabel, metrics.L("result", "failed"))
+
+		if t, ok := lastSuccessByMode[mode]; ok {
+			c.Gauge("sfdbtools_backup_last_success_timestamp_seconds", "Waktu selesai backup terakhir yang berstatus success (unix)", unixSeconds(t), modeLabel)
+		}
+	}
+
+	for _, dbName := range sortedKeys(databases) {
+		state := databases[dbName]
+		dbLabel := metrics.L("database", dbName)
+		if !state.lastSuccess.IsZero() {
+			warnings := 0.0
+			if state.warnings {
+				warnings = 1
+			}
+			c.Gauge("sfdbtools_backup_database_last_success_timestamp_seconds", "Waktu backup berhasil terakhir per database (unix)", unixSeconds(state.lastSuccess), dbLabel)
+			c.Gauge("sfdbtools_backup_database_last_size_bytes", "Ukuran file backup berhasil terakhir per database", float64(state.sizeBytes), dbLabel)
+			c.Gauge("sfdbtools_backup_database_last_warnings", "1 jika backup berhasil terakhir database memiliki warning", warnings, dbLabel)
+			if state.compressionRatio > 0 {
+				c.Gauge("sfdbtools_backup_database_compression_ratio", "Rasio ukuran file backup terhadap ukuran database asli", state.compressionRatio, dbLabel)
+			}
+		}
+		if !state.lastFailure.IsZero() {
+			c.Gauge("sfdbtools_backup_database_last_failure_timestamp_seconds", "Waktu backup gagal terakhir per database (unix)", unixSeconds(state.lastFailure), dbLabel)
+		}
+	}
+	return nil
+}
+
+// writeBackupMetrics menulis ulang file textfile metrik backup jika metrics.textfile_dir diisi
+func (s *Service) writeBackupMetrics() {
+	if s.Config == nil || s.Config.Metrics.TextfileDir == "" {
+		return
+	}
+	c := metrics.NewCollector()
+	if err := s.GatherBackupMetrics(c); err != nil {
+		s.Logger.Warnf("Gagal mengumpulkan metrik backup: %v", err)
+		return
+	}
+	path, err := c.WriteTextfile(s.Config.Metrics.TextfileDir, backupMetricsFile)
+	if err != nil {
+		s.Logger.Warnf("Gagal menulis metrik backup: %v", err)
+		return
+	}
+	s.Logger.Debugf("Metrik backup ditulis ke %s", path)
+}
+
+func databaseState(states map[string]*databaseMetricState, dbName string) *databaseMetricState {
+	state, ok := states[dbName]
+	if !ok {
+		state = &databaseMetricState{}
+		states[dbName] = state
+	}
+	return state
+}
+
+func unixSeconds(t time.Time) float64 {
+	return float64(t.UnixNano()) / float64(time.Second)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
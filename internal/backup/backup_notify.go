@@ -0,0 +1,173 @@
+// File : internal/backup/backup_notify.go
+// Deskripsi : Merender BackupSummary menjadi notifikasi dan mengirimnya ke target email/webhook yang dikonfigurasi
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"sfDBTools/internal/structs"
+	"sfDBTools/pkg/notify"
+	"sfDBTools/pkg/ui"
+	"strconv"
+	"strings"
+)
+
+// notifyHTMLTemplate adalah tampilan HTML summary backup untuk email
+var notifyHTMLTemplate = template.Must(template.New("backup").Parse(`<!DOCTYPE html>
+<html><body style="font-family:Arial,sans-serif;font-size:14px;color:#222">
+<h2 style="color:#{{.Color}}">{{.Subject}}</h2>
+<table cellpadding="6" style="border-collapse:collapse">
+{{range .Fields}}<tr><td style="border:1px solid #ddd;background:#f5f5f5"><b>{{.Name}}</b></td><td style="border:1px solid #ddd">{{.Value}}</td></tr>
+{{end}}</table>
+{{if .Failed}}<h3>Database Gagal</h3>
+<table cellpadding="6" style="border-collapse:collapse">
+<tr><th style="border:1px solid #ddd">Database</th><th style="border:1px solid #ddd">Error</th></tr>
+{{range .Failed}}<tr><td style="border:1px solid #ddd">{{.DatabaseName}}</td><td style="border:1px solid #ddd">{{.Error}}</td></tr>
+{{end}}</table>{{end}}
+{{if .Errors}}<h3>Error</h3><ul>{{range .Errors}}<li>{{.}}</li>{{end}}</ul>{{end}}
+</body></html>`))
+
+// buildNotifyMessage merender summary backup menjadi subject, text, HTML, dan ringkasan field
+func (s *Service) buildNotifyMessage(summary *BackupSummary) (notify.Message, error) {
+	host := summary.ServerInfo.Host
+	if summary.ServerInfo.Port > 0 {
+		host += ":" + strconv.Itoa(summary.ServerInfo.Port)
+	}
+	subject := fmt.Sprintf("[sfDBTools] Backup %s - %s (%s)", strings.ToUpper(summary.Status), host, summary.BackupID)
+
+	fields := []notify.Field{
+		{Name: "Backup ID", Value: summary.BackupID},
+		{Name: "Status", Value: summary.Status},
+		{Name: "Mode", Value: summary.BackupMode},
+		{Name: "Server", Value: host},
+		{Name: "Mulai", Value: summary.StartTime.Format(displayTimeFormat)},
+		{Name: "Durasi", Value: summary.Duration},
+		{Name: "Database", Value: fmt.Sprintf("%d berhasil, %d gagal dari %d",
+			summary.DatabaseStats.SuccessfulBackups, summary.DatabaseStats.FailedBackups, summary.DatabaseStats.TotalDatabases)},
+		{Name: "Total Ukuran", Value: fmt.Sprintf("%s (%d file)", summary.OutputInfo.TotalSizeHuman, summary.OutputInfo.TotalFiles)},
+		{Name: "Direktori Output", Value: summary.OutputInfo.OutputDirectory},
+	}
+	if len(summary.FailedDatabases) > 0 {
+		names := make([]string, 0, len(summary.FailedDatabases))
+		for _, failed := range summary.FailedDatabases {
+			names = append(names, failed.DatabaseName)
+		}
+		fields = append(fields, notify.Field{Name: "Database Gagal", Value: strings.Join(names, ", ")})
+	}
+
+	var text strings.Builder
+	text.WriteString(subject + "\n\n")
+	for _, f := range fields {
+		fmt.Fprintf(&text, "%-18s: %s\n", f.Name, f.Value)
+	}
+	if len(summary.FailedDatabases) > 0 {
+		text.WriteString("\nDatabase gagal:\n")
+		for _, failed := range summary.FailedDatabases {
+			fmt.Fprintf(&text, "- %s: %s\n", failed.DatabaseName, failed.Error)
+		}
+	}
+	if len(summary.Errors) > 0 {
+		text.WriteString("\nError:\n")
+		for _, e := range summary.Errors {
+			text.WriteString("- " + e + "\n")
+		}
+	}
+
+	var html strings.Builder
+	err := notifyHTMLTemplate.Execute(&html, map[string]any{
+		"Subject": subject,
+		"Color":   notify.StatusColor(summary.Status),
+		"Fields":  fields,
+		"Failed":  summary.FailedDatabases,
+		"Errors":  summary.Errors,
+	})
+	if err != nil {
+		return notify.Message{}, fmt.Errorf("gagal merender HTML notifikasi: %w", err)
+	}
+
+	// Detail per database bisa sangat besar; webhook cukup menerima summary tanpa detail tersebut
+	payload := *summary
+	payload.DatabaseDetails = nil
+
+	return notify.Message{
+		Subject: subject,
+		Status:  summary.Status,
+		Text:    text.String(),
+		HTML:    html.String(),
+		Fields:  fields,
+		Payload: payload,
+	}, nil
+}
+
+// sendNotifications mengirim notifikasi summary ke semua target yang aturannya cocok dengan status backup.
+// Jika force true, aturan 'on' diabaikan. Mengembalikan jumlah notifikasi terkirim;
+// kegagalan pengiriman dikembalikan sebagai satu error gabungan.
+func (s *Service) sendNotifications(ctx context.Context, summary *BackupSummary, force bool) (int, error) {
+	if s.Config == nil {
+		return 0, nil
+	}
+	notifiers, err := notify.New(s.Config.Backup.Notifications)
+	if err != nil {
+		return 0, fmt.Errorf("konfigurasi notifikasi tidak valid: %w", err)
+	}
+	if len(notifiers) == 0 {
+		return 0, nil
+	}
+
+	msg, err := s.buildNotifyMessage(summary)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var failures []string
+	for _, n := range notifiers {
+		if !force && !n.Accepts(summary.Status) {
+			s.Logger.Debugf("Notifikasi %s dilewati untuk status %s", n.Name(), summary.Status)
+			continue
+		}
+		if err := n.Send(ctx, msg); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", n.Name(), err))
+			continue
+		}
+		sent++
+		s.Logger.Infof("Notifikasi backup %s terkirim via %s", summary.BackupID, n.Name())
+	}
+	if len(failures) > 0 {
+		return sent, fmt.Errorf("gagal mengirim notifikasi: %s", strings.Join(failures, "; "))
+	}
+	return sent, nil
+}
+
+// notifyBackupResult mengirim notifikasi setelah summary disimpan; kegagalan hanya dicatat sebagai warning
+func (s *Service) notifyBackupResult(ctx context.Context, summary *BackupSummary) {
+	if _, err := s.sendNotifications(ctx, summary, false); err != nil {
+		s.Logger.Warnf("%v", err)
+	}
+}
+
+// ExecuteNotify mengirim ulang notifikasi untuk summary backup yang sudah ada (misalnya untuk menguji konfigurasi)
+func (s *Service) ExecuteNotify(ctx context.Context, notifyFlags *structs.BackupNotifyFlags) error {
+	if notifyFlags.BackupID == "" {
+		return fmt.Errorf("--backup-id wajib diisi")
+	}
+	summary, err := s.loadSummaryByID(notifyFlags.BackupID)
+	if err != nil {
+		return err
+	}
+	sent, err := s.sendNotifications(ctx, summary, notifyFlags.Force)
+	if err != nil {
+		return err
+	}
+	if sent == 0 {
+		ui.PrintWarning(fmt.Sprintf("Tidak ada notifikasi terkirim untuk backup %s (status %s): periksa backup.notifications atau gunakan --force", summary.BackupID, summary.Status))
+		return nil
+	}
+	ui.PrintSuccess(fmt.Sprintf("%d notifikasi untuk backup %s terkirim", sent, summary.BackupID))
+	return nil
+}
@@ -0,0 +1,421 @@
+// File : internal/backup/backup_physical.go
+// Deskripsi : Backup fisik instance MariaDB menggunakan mariabackup (stream xbstream, prepare, dan incremental LSN)
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package backup
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sfDBTools/pkg/database"
+	"sfDBTools/pkg/fs"
+	"sfDBTools/pkg/ui"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// backupModePhysical adalah BackupMode pada summary untuk backup fisik
+	backupModePhysical = "physical"
+	// dumperMariabackup adalah nama "dumper" yang dicatat pada summary backup fisik
+	dumperMariabackup = "mariabackup"
+	// physicalBackupName dipakai sebagai nama database pada nama file dan summary (seluruh instance)
+	physicalBackupName = "all_databases"
+	// mariabackupLogTail adalah ukuran ekor log stderr mariabackup yang disertakan pada pesan error
+	mariabackupLogTail = 2048
+	// checkpointsFile ditulis mariabackup ke --extra-lsndir dan --target-dir, berisi LSN backup
+	checkpointsFile = "xtrabackup_checkpoints"
+)
+
+// mariabackupDumper menjalankan 'mariabackup --backup --stream=xbstream' dan menulis stream-nya ke w.
+// Jika extractDir diisi, stream yang sama juga diekstrak dengan 'mbstream -x' untuk langkah prepare.
+type mariabackupDumper struct {
+	s          *Service
+	info       *PhysicalBackupInfo
+	lsnDir     string // --extra-lsndir: salinan xtrabackup_checkpoints untuk membaca LSN
+	extractDir string
+}
+
+func (d *mariabackupDumper) Name() string { return dumperMariabackup }
+
+func (d *mariabackupDumper) Dump(ctx context.Context, job *dumpJob, w io.Writer) (string, error) {
+	s := d.s
+	args := s.buildMariabackupArgs(d.info, d.lsnDir)
+	s.Logger.Debugf("Menjalankan mariabackup %s", strings.Join(s.sanitizeArgsForLogging(args), " "))
+
+	cmd, cleanup, err := database.ClientCommand(ctx, s.DBConfigInfo.ServerDBConnection, "mariabackup", args...)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+	cmd.Stdout = w
+
+	var extract *exec.Cmd
+	var extractStdin io.WriteCloser
+	var extractStderr strings.Builder
+	if d.extractDir != "" {
+		extract = exec.CommandContext(ctx, "mbstream", "-x", "-C", d.extractDir)
+		extract.Stderr = &extractStderr
+		extractStdin, err = extract.StdinPipe()
+		if err != nil {
+			return "", fmt.Errorf("gagal membuat pipe mbstream: %w", err)
+		}
+		if err := extract.Start(); err != nil {
+			return "", fmt.Errorf("gagal menjalankan mbstream: %w", err)
+		}
+		cmd.Stdout = io.MultiWriter(w, extractStdin)
+	}
+
+	// mariabackup menulis log progres ke stderr; hanya bagian akhirnya yang relevan saat gagal
+	stderrTail := &tailBuffer{size: mariabackupLogTail}
+	cmd.Stderr = stderrTail
+
+	runErr := cmd.Run()
+
+	var extractErr error
+	if extract != nil {
+		extractStdin.Close()
+		extractErr = extract.Wait()
+	}
+
+	if runErr != nil {
+		return "", fmt.Errorf("mariabackup gagal: %w: %s", runErr, strings.TrimSpace(string(stderrTail.buf)))
+	}
+	if extractErr != nil {
+		return "", fmt.Errorf("mbstream gagal mengekstrak stream ke %s: %w: %s", d.extractDir, extractErr, strings.TrimSpace(extractStderr.String()))
+	}
+	return "", nil
+}
+
+// buildMariabackupArgs menyusun argumen 'mariabackup --backup' untuk stream xbstream
+func (s *Service) buildMariabackupArgs(info *PhysicalBackupInfo, lsnDir string) []string {
+	args := []string{"--backup", "--stream=xbstream"}
+	args = append(args, s.buildConnectionArgs(s.DBConfigInfo.ServerDBConnection)...)
+	if info.DataDir != "" {
+		args = append(args, "--datadir="+info.DataDir)
+	}
+	if lsnDir != "" {
+		args = append(args, "--extra-lsndir="+lsnDir)
+	}
+	if s.BackupOptions.Concurrency.Parallel > 0 {
+		args = append(args, fmt.Sprintf("--parallel=%d", s.BackupOptions.Concurrency.Parallel))
+	}
+	if info.BackupType == "incremental" {
+		args = append(args, "--incremental-lsn="+info.FromLSN)
+	}
+	return append(args, s.mariabackupKeyArgs()...)
+}
+
+// mariabackupKeyArgs mengembalikan argumen plugin file_key_management agar tablespace terenkripsi
+// (mariadb.encryption_key_file) dapat dibaca saat backup maupun prepare.
+func (s *Service) mariabackupKeyArgs() []string {
+	keyFile := s.Config.Mariadb.EncryptionKeyFile
+	if keyFile == "" {
+		return nil
+	}
+	return []string{
+		"--plugin-load-add=file_key_management",
+		"--file-key-management-filename=" + keyFile,
+	}
+}
+
+// ExecutePhysicalBackup menjalankan backup fisik seluruh instance dengan mariabackup.
+// Stream xbstream melewati rantai kompresi, enkripsi, throttling, dan verifikasi yang sama dengan backup logis,
+// lalu hasilnya dicatat pada summary dengan mode 'physical'.
+func (s *Service) ExecutePhysicalBackup(ctx context.Context) error {
+	ui.Headers("Backup Fisik (mariabackup)")
+
+	if _, err := exec.LookPath("mariabackup"); err != nil {
+		return fmt.Errorf("mariabackup tidak ditemukan di PATH: %w", err)
+	}
+	release, err := s.AcquireBackupLock(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if err := s.CheckAndSelectConfigFile(); err != nil {
+		return err
+	}
+
+	info := &PhysicalBackupInfo{
+		BackupType: "full",
+		DataDir:    s.Config.Mariadb.DataDir,
+	}
+	if err := s.resolveIncrementalBase(info); err != nil {
+		return err
+	}
+
+	config, err := s.SetupBackupExecution()
+	if err != nil {
+		return fmt.Errorf("gagal setup backup execution: %w", err)
+	}
+	config.NoDumpTrailer = true
+
+	lsnDir, err := os.MkdirTemp("", "sfdbtools_lsn_")
+	if err != nil {
+		return fmt.Errorf("gagal membuat direktori sementara LSN: %w", err)
+	}
+	defer os.RemoveAll(lsnDir)
+
+	dumpImpl := &mariabackupDumper{s: s, info: info, lsnDir: lsnDir}
+	if s.PhysicalOptions.Prepare {
+		dumpImpl.extractDir, err = s.preparePhysicalExtractDir(info)
+		if err != nil {
+			return err
+		}
+		if dumpImpl.extractDir != s.PhysicalOptions.PrepareDir {
+			defer os.RemoveAll(dumpImpl.extractDir)
+		}
+	}
+	config.Dumper = dumpImpl
+
+	if info.BackupType == "incremental" {
+		s.Logger.Infof("Backup incremental dari LSN %s (basis: %s)", info.FromLSN, info.BaseBackupID)
+	} else {
+		s.Logger.Info("Backup fisik full seluruh instance")
+	}
+
+	startTime := time.Now()
+	ui.PrintSubHeader("Memulai Proses Backup")
+	result := s.executePhysicalStream(ctx, config, info)
+
+	// Prepare hanya dijalankan jika stream berhasil ditulis dan diekstrak utuh
+	if s.PhysicalOptions.Prepare && len(result.failed) == 0 {
+		if err := s.runMariabackupPrepare(ctx, info, dumpImpl.extractDir); err != nil {
+			s.Logger.Errorf("Prepare backup fisik gagal: %v", err)
+			result.errors = append(result.errors, err.Error())
+		}
+	}
+
+	summary := s.CreateBackupSummary(s.uniqueBackupID(startTime), backupModePhysical, []string{physicalBackupName}, result.successful, result.failed, startTime, result.errors)
+	summary.Physical = info
+	summary.BackupConfig.Dumper = dumperMariabackup
+
+	if len(summary.OutputInfo.Files) > 0 {
+		manifestPath, err := s.SaveManifest(summary)
+		if err != nil {
+			s.Logger.Errorf("Gagal menyimpan manifest backup: %v", err)
+		} else {
+			summary.ManifestFile = manifestPath
+		}
+	}
+
+	if err := s.SaveSummaryToJSON(summary); err != nil {
+		s.Logger.Errorf("Gagal menyimpan summary ke JSON: %v", err)
+	}
+	s.DisplaySummaryTable(summary)
+	s.recordCatalog(ctx, summary)
+	s.notifyBackupResult(ctx, summary)
+	s.writeBackupMetrics()
+	s.setResultDetails(summary)
+
+	if len(result.failed) > 0 {
+		return fmt.Errorf("backup fisik gagal: %s", result.failed[0].Error)
+	}
+	if s.PhysicalOptions.Prepare && !info.Prepared {
+		return backupOutcomeError(summary, fmt.Errorf("backup fisik tersimpan namun prepare gagal, lihat log untuk detail"))
+	}
+
+	ui.PrintSuccess(fmt.Sprintf("Backup fisik %s selesai (LSN %s)", info.BackupType, info.ToLSN))
+	return nil
+}
+
+// executePhysicalStream menulis stream mariabackup ke satu file melalui executeDumpWithPipe
+func (s *Service) executePhysicalStream(ctx context.Context, config BackupConfig, info *PhysicalBackupInfo) backupResult {
+	var res backupResult
+	fail := func(err error) backupResult {
+		res.errors = append(res.errors, err.Error())
+		res.failed = append(res.failed, FailedDatabaseInfo{DatabaseName: physicalBackupName, Error: err.Error(), Attempts: 1})
+		return res
+	}
+
+	startTime := time.Now()
+	baseOutputFile, err := fs.GenerateBackupFilename(
+		s.Config.Backup.Output.Naming.Pattern,
+		physicalBackupName,
+		info.BackupType,
+		s.Config.Backup.Output.Naming.IncludeClientCode,
+		s.Config.Backup.Output.Naming.IncludeHostname,
+		s.Config.General.ClientCode,
+		"",
+	)
+	if err != nil {
+		return fail(fmt.Errorf("gagal generate nama file backup: %w", err))
+	}
+	fullOutputPath := filepath.Join(config.OutputDir, s.addFileExtensions(baseOutputFile+".xbstream", config))
+	s.Logger.Debug("File output: " + fullOutputPath)
+
+	var written *dumpChecksums
+	if config.shouldVerifyBackup() {
+		written = &dumpChecksums{}
+	}
+
+	if _, err := s.executeDumpWithPipe(ctx, config.Dumper, nil, fullOutputPath, config.CompressionRequired, config.CompressionType, written); err != nil {
+		return fail(err)
+	}
+
+	verification, err := s.runPostWriteVerification(config, fullOutputPath, written)
+	if err != nil {
+		return fail(err)
+	}
+
+	dumpImpl := config.Dumper.(*mariabackupDumper)
+	if err := readCheckpoints(filepath.Join(dumpImpl.lsnDir, checkpointsFile), info); err != nil {
+		s.Logger.Warnf("Gagal membaca LSN backup fisik: %v", err)
+	}
+
+	fileSize := s.outputFileSize(fullOutputPath)
+	res.successful = append(res.successful, DatabaseBackupInfo{
+		DatabaseName:  physicalBackupName,
+		OutputFile:    fullOutputPath,
+		FileSize:      fileSize,
+		FileSizeHuman: s.formatFileSize(fileSize),
+		Duration:      ui.FormatDuration(time.Since(startTime)),
+		Status:        "success",
+		Verification:  verification,
+		Attempts:      1,
+	})
+
+	s.Logger.Infof("File backup fisik tersimpan di: %s", fullOutputPath)
+	return res
+}
+
+// resolveIncrementalBase menentukan LSN basis incremental dari --incremental-from atau --incremental-lsn
+func (s *Service) resolveIncrementalBase(info *PhysicalBackupInfo) error {
+	opts := s.PhysicalOptions
+	switch {
+	case opts.IncrementalFrom != "" && opts.IncrementalLSN != "":
+		return fmt.Errorf("gunakan salah satu dari --incremental-from atau --incremental-lsn")
+	case opts.IncrementalFrom != "":
+		base, err := s.loadSummaryByID(opts.IncrementalFrom)
+		if err != nil {
+			return err
+		}
+		if base.BackupMode != backupModePhysical || base.Physical == nil {
+			return fmt.Errorf("backup %s bukan backup fisik (mode %s)", base.BackupID, base.BackupMode)
+		}
+		if base.Status != "success" {
+			return fmt.Errorf("backup %s berstatus %s, tidak dapat dijadikan basis incremental", base.BackupID, base.Status)
+		}
+		if base.Physical.ToLSN == "" {
+			return fmt.Errorf("backup %s tidak menyimpan LSN akhir", base.BackupID)
+		}
+		info.BackupType = "incremental"
+		info.FromLSN = base.Physical.ToLSN
+		info.BaseBackupID = base.BackupID
+	case opts.IncrementalLSN != "":
+		if _, err := strconv.ParseUint(opts.IncrementalLSN, 10, 64); err != nil {
+			return fmt.Errorf("--incremental-lsn tidak valid: %s", opts.IncrementalLSN)
+		}
+		info.BackupType = "incremental"
+		info.FromLSN = opts.IncrementalLSN
+	}
+	return nil
+}
+
+// preparePhysicalExtractDir menyiapkan direktori tujuan ekstraksi stream untuk --prepare.
+// Backup full diekstrak langsung ke --prepare-dir yang harus kosong; backup incremental diekstrak
+// ke direktori sementara di sebelahnya karena akan diterapkan ke backup full yang sudah di-prepare.
+func (s *Service) preparePhysicalExtractDir(info *PhysicalBackupInfo) (string, error) {
+	prepareDir := s.PhysicalOptions.PrepareDir
+	if prepareDir == "" {
+		return "", fmt.Errorf("--prepare-dir wajib diisi jika --prepare digunakan")
+	}
+	if _, err := exec.LookPath("mbstream"); err != nil {
+		return "", fmt.Errorf("mbstream tidak ditemukan di PATH: %w", err)
+	}
+
+	if info.BackupType == "full" {
+		entries, err := os.ReadDir(prepareDir)
+		if err != nil && !os.IsNotExist(err) {
+			return "", fmt.Errorf("gagal membaca direktori prepare %s: %w", prepareDir, err)
+		}
+		if len(entries) > 0 {
+			return "", fmt.Errorf("direktori prepare %s tidak kosong", prepareDir)
+		}
+		if err := os.MkdirAll(prepareDir, 0750); err != nil {
+			return "", fmt.Errorf("gagal membuat direktori prepare %s: %w", prepareDir, err)
+		}
+		return prepareDir, nil
+	}
+
+	// Salinan full yang sudah di-prepare harus berakhir tepat di LSN basis incremental
+	prepared := &PhysicalBackupInfo{}
+	if err := readCheckpoints(filepath.Join(prepareDir, checkpointsFile), prepared); err != nil {
+		return "", fmt.Errorf("direktori prepare %s tidak berisi backup fisik yang sudah di-prepare: %w", prepareDir, err)
+	}
+	if prepared.ToLSN != info.FromLSN {
+		return "", fmt.Errorf("LSN akhir di %s (%s) tidak sama dengan LSN basis incremental (%s)", prepareDir, prepared.ToLSN, info.FromLSN)
+	}
+
+	extractDir, err := os.MkdirTemp(filepath.Dir(filepath.Clean(prepareDir)), "sfdbtools_incremental_")
+	if err != nil {
+		return "", fmt.Errorf("gagal membuat direktori sementara incremental: %w", err)
+	}
+	return extractDir, nil
+}
+
+// runMariabackupPrepare menjalankan 'mariabackup --prepare' pada --prepare-dir.
+// Untuk backup incremental, perubahan dari extractDir diterapkan ke salinan full di --prepare-dir.
+func (s *Service) runMariabackupPrepare(ctx context.Context, info *PhysicalBackupInfo, extractDir string) error {
+	ui.PrintSubHeader("Prepare Backup Fisik")
+	prepareDir := s.PhysicalOptions.PrepareDir
+
+	args := []string{"--prepare", "--target-dir=" + prepareDir}
+	if info.BackupType == "incremental" {
+		args = append(args, "--incremental-dir="+extractDir)
+	}
+	args = append(args, s.mariabackupKeyArgs()...)
+	s.Logger.Infof("Menjalankan mariabackup %s", strings.Join(args, " "))
+
+	cmd := exec.CommandContext(ctx, "mariabackup", args...)
+	stderrTail := &tailBuffer{size: mariabackupLogTail}
+	cmd.Stderr = stderrTail
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("mariabackup --prepare gagal: %w: %s", err, strings.TrimSpace(string(stderrTail.buf)))
+	}
+
+	info.Prepared = true
+	info.PrepareDir = prepareDir
+	s.Logger.Infof("Backup fisik siap di-restore (copy-back) dari: %s", prepareDir)
+	return nil
+}
+
+// readCheckpoints membaca from_lsn dan to_lsn dari file xtrabackup_checkpoints
+func readCheckpoints(path string, info *PhysicalBackupInfo) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "from_lsn":
+			info.FromLSN = strings.TrimSpace(value)
+		case "to_lsn":
+			info.ToLSN = strings.TrimSpace(value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if info.ToLSN == "" {
+		return fmt.Errorf("to_lsn tidak ditemukan di %s", path)
+	}
+	return nil
+}
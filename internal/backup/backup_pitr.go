@@ -0,0 +1,354 @@
+// File : internal/backup/backup_pitr.go
+// Deskripsi : Point-in-time restore: pemilihan backup dasar dan replay arsip binlog sampai waktu/GTID tertentu
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sfDBTools/internal/binlog"
+	"sfDBTools/pkg/database"
+	"sfDBTools/pkg/ui"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// pitrTimeFormat adalah format waktu untuk flag --until (waktu lokal server sfDBTools)
+const pitrTimeFormat = "2006-01-02 15:04:05"
+
+// binlogReplayPlan adalah rencana replay binlog setelah backup dasar di-restore
+type binlogReplayPlan struct {
+	ArchiveDir   string
+	StartGTID    string // Posisi GTID backup dasar; replay dimulai setelah posisi ini
+	StopDatetime string // Batas waktu replay (--until)
+	StopGTID     string // Batas posisi GTID replay (--until-gtid)
+	Entries      []binlog.ArchiveEntry
+}
+
+// isPointInTimeRestore mengembalikan true jika restore diminta sampai waktu atau GTID tertentu
+func (s *Service) isPointInTimeRestore() bool {
+	return s.RestoreOptions.Until != "" || s.RestoreOptions.UntilGTID != ""
+}
+
+// parseUntilTime mem-parse flag --until sebagai waktu lokal
+func parseUntilTime(value string) (time.Time, error) {
+	t, err := time.ParseInLocation(pitrTimeFormat, strings.TrimSpace(value), time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("format --until tidak valid, gunakan '%s': %w", pitrTimeFormat, err)
+	}
+	return t, nil
+}
+
+// resolvePITRTargets memilih backup dasar dan menyusun rencana replay binlog untuk point-in-time restore
+func (s *Service) resolvePITRTargets() ([]restoreTarget, *binlogReplayPlan, error) {
+	opts := s.RestoreOptions
+
+	switch {
+	case opts.File != "":
+		return nil, nil, fmt.Errorf("--until/--until-gtid membutuhkan backup dengan posisi GTID, gunakan --backup-id atau biarkan backup dipilih otomatis")
+	case len(opts.Databases) > 0:
+		return nil, nil, fmt.Errorf("--db tidak dapat digunakan bersama --until/--until-gtid karena replay binlog berlaku untuk seluruh server")
+	case opts.Until != "" && opts.UntilGTID != "":
+		return nil, nil, fmt.Errorf("gunakan salah satu dari --until atau --until-gtid, tidak keduanya")
+	}
+
+	var untilTime time.Time
+	if opts.Until != "" {
+		var err error
+		if untilTime, err = parseUntilTime(opts.Until); err != nil {
+			return nil, nil, err
+		}
+	}
+	if opts.UntilGTID != "" {
+		if _, err := binlog.ParseGTIDList(opts.UntilGTID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	summary, err := s.selectPITRBaseSummary(untilTime)
+	if err != nil {
+		return nil, nil, err
+	}
+	s.Logger.Infof("Backup dasar point-in-time restore: %s (GTID %s)", summary.BackupID, summary.GTIDPosition)
+
+	targets, err := s.restoreTargetsFromSummary(summary, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.completeRestoreTargets(targets); err != nil {
+		return nil, nil, err
+	}
+
+	plan, err := s.buildBinlogReplayPlan(summary, untilTime)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return targets, plan, nil
+}
+
+// pitrSummaryUsable mengembalikan nil jika summary dapat menjadi dasar replay sampai untilTime/--until-gtid
+func (s *Service) pitrSummaryUsable(summary *BackupSummary, untilTime time.Time) error {
+	if summary.GTIDPosition == "" {
+		return fmt.Errorf("backup %s tidak menyimpan posisi GTID (aktifkan capture GTID saat backup)", summary.BackupID)
+	}
+	if !untilTime.IsZero() && summary.StartTime.After(untilTime) {
+		return fmt.Errorf("backup %s dimulai %s, setelah waktu --until", summary.BackupID, summary.StartTime.Format(pitrTimeFormat))
+	}
+	if s.RestoreOptions.UntilGTID != "" {
+		ok, err := binlog.GTIDListLessOrEqual(summary.GTIDPosition, s.RestoreOptions.UntilGTID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("posisi GTID backup %s (%s) sudah melewati --until-gtid", summary.BackupID, summary.GTIDPosition)
+		}
+	}
+	return nil
+}
+
+// selectPITRBaseSummary memilih backup dasar: --backup-id jika diberikan, atau backup berhasil terbaru
+// yang memiliki posisi GTID dan dimulai sebelum target restore.
+func (s *Service) selectPITRBaseSummary(untilTime time.Time) (*BackupSummary, error) {
+	if s.RestoreOptions.BackupID != "" {
+		summary, err := s.loadSummaryByID(s.RestoreOptions.BackupID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.pitrSummaryUsable(summary, untilTime); err != nil {
+			return nil, err
+		}
+		return summary, nil
+	}
+
+	entries, err := s.findAllSummaries()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca daftar summary backup: %w", err)
+	}
+
+	var best *BackupSummary
+	for _, entry := range entries {
+		if entry.Status != "success" {
+			continue
+		}
+		summary, err := s.readSummaryFromJSON(entry.FilePath)
+		if err != nil {
+			continue
+		}
+		if err := s.pitrSummaryUsable(summary, untilTime); err != nil {
+			s.Logger.Debugf("Summary %s dilewati: %v", summary.BackupID, err)
+			continue
+		}
+		if best == nil || summary.StartTime.After(best.StartTime) {
+			best = summary
+		}
+	}
+
+	if best == nil {
+		return nil, fmt.Errorf("tidak ditemukan backup berhasil dengan posisi GTID sebelum target restore")
+	}
+	return best, nil
+}
+
+// buildBinlogReplayPlan memilih arsip binlog yang dibutuhkan, dimulai dari binlog yang memuat posisi GTID backup
+func (s *Service) buildBinlogReplayPlan(summary *BackupSummary, untilTime time.Time) (*binlogReplayPlan, error) {
+	archiveDir := binlog.ArchiveDir(s.Config.Backup.Output.BaseDirectory, summary.ServerInfo.Host, summary.ServerInfo.Port)
+	index, err := binlog.LoadIndex(archiveDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(index.Entries) == 0 {
+		return nil, fmt.Errorf("arsip binlog untuk %s:%d tidak ditemukan di %s (jalankan 'binlog archive')", summary.ServerInfo.Host, summary.ServerInfo.Port, archiveDir)
+	}
+
+	// Binlog awal adalah binlog terakhir yang Gtid_list-nya belum melewati posisi GTID backup
+	start := -1
+	for i, entry := range index.Entries {
+		if entry.StartGTID == "" {
+			continue
+		}
+		ok, err := binlog.GTIDListLessOrEqual(entry.StartGTID, summary.GTIDPosition)
+		if err != nil {
+			return nil, fmt.Errorf("gagal membandingkan GTID %s: %w", entry.Name, err)
+		}
+		if ok {
+			start = i
+		}
+	}
+	if start < 0 {
+		return nil, fmt.Errorf("arsip binlog tidak mencakup posisi GTID backup %s", summary.GTIDPosition)
+	}
+
+	plan := &binlogReplayPlan{
+		ArchiveDir: archiveDir,
+		StartGTID:  summary.GTIDPosition,
+		StopGTID:   s.RestoreOptions.UntilGTID,
+	}
+	if !untilTime.IsZero() {
+		plan.StopDatetime = untilTime.Format(pitrTimeFormat)
+	}
+
+	for _, entry := range index.Entries[start:] {
+		// Binlog yang diawali posisi di luar --until-gtid tidak berisi transaksi yang dibutuhkan
+		if plan.StopGTID != "" && entry.StartGTID != "" && len(plan.Entries) > 0 {
+			if beyond, err := binlog.GTIDListLessOrEqual(plan.StopGTID, entry.StartGTID); err == nil && beyond {
+				break
+			}
+		}
+		plan.Entries = append(plan.Entries, entry)
+	}
+
+	last := plan.Entries[len(plan.Entries)-1]
+	if !untilTime.IsZero() && untilTime.After(last.ArchivedAt) {
+		s.Logger.Warnf("Target --until melewati arsip binlog terakhir (%s, diarsipkan %s); transaksi setelahnya belum tersedia",
+			last.Name, last.ArchivedAt.Format(pitrTimeFormat))
+	}
+
+	return plan, nil
+}
+
+// displayReplayPlan menampilkan daftar binlog yang akan di-replay
+func (s *Service) displayReplayPlan(plan *binlogReplayPlan) {
+	ui.PrintSubHeader("Rencana Replay Binlog")
+
+	target := plan.StopDatetime
+	if plan.StopGTID != "" {
+		target = "GTID " + plan.StopGTID
+	}
+	ui.PrintInfo(fmt.Sprintf("Mulai setelah GTID %s sampai %s (%d binlog)", plan.StartGTID, target, len(plan.Entries)))
+
+	headers := []string{"No", "Binlog", "Gtid_list Awal", "Ukuran", "Enkripsi"}
+	var rows [][]string
+	for i, entry := range plan.Entries {
+		rows = append(rows, []string{
+			strconv.Itoa(i + 1),
+			entry.Name,
+			entry.StartGTID,
+			ui.FormatFileSize(entry.OriginalSize),
+			strconv.FormatBool(entry.Encrypted),
+		})
+	}
+	ui.FormatTable(headers, rows)
+}
+
+// replayBinlogs mendekode arsip binlog ke direktori sementara lalu mengalirkan output mysqlbinlog ke client mysql.
+// Posisi GTID pada --start-position/--stop-position membutuhkan mysqlbinlog MariaDB 10.8 atau lebih baru.
+func (s *Service) replayBinlogs(ctx context.Context, plan *binlogReplayPlan, encryptionKey string) error {
+	tmpDir, err := os.MkdirTemp(s.restoreTempDirectory(), "sfdb-pitr-")
+	if err != nil {
+		return fmt.Errorf("gagal membuat direktori sementara: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	var files []string
+	for _, entry := range plan.Entries {
+		path, err := s.decodeArchivedBinlog(plan.ArchiveDir, entry, tmpDir, encryptionKey)
+		if err != nil {
+			return err
+		}
+		files = append(files, path)
+	}
+
+	binlogArgs := []string{"--start-position=" + plan.StartGTID}
+	switch {
+	case plan.StopGTID != "":
+		binlogArgs = append(binlogArgs, "--stop-position="+plan.StopGTID)
+	case plan.StopDatetime != "":
+		binlogArgs = append(binlogArgs, "--stop-datetime="+plan.StopDatetime)
+	}
+	binlogArgs = append(binlogArgs, files...)
+
+	binlogCmd := exec.CommandContext(ctx, "mysqlbinlog", binlogArgs...)
+	dbConn := s.RestoreOptions.DBConfig.ServerDBConnection
+	mysqlCmd, cleanup, err := database.ClientCommand(ctx, dbConn, "mysql", s.buildConnectionArgs(dbConn)...)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	pipeReader, pipeWriter, err := os.Pipe()
+	if err != nil {
+		return fmt.Errorf("gagal membuat pipe replay binlog: %w", err)
+	}
+	var binlogStderr, mysqlStderr strings.Builder
+	binlogCmd.Stdout = pipeWriter
+	binlogCmd.Stderr = &binlogStderr
+	mysqlCmd.Stdin = pipeReader
+	mysqlCmd.Stderr = &mysqlStderr
+
+	if err := mysqlCmd.Start(); err != nil {
+		pipeReader.Close()
+		pipeWriter.Close()
+		return fmt.Errorf("gagal menjalankan mysql: %w", err)
+	}
+	if err := binlogCmd.Start(); err != nil {
+		pipeReader.Close()
+		pipeWriter.Close()
+		mysqlCmd.Wait()
+		return fmt.Errorf("gagal menjalankan mysqlbinlog: %w", err)
+	}
+	// Ujung pipe milik proses ini ditutup agar mysql menerima EOF saat mysqlbinlog selesai
+	pipeReader.Close()
+	pipeWriter.Close()
+
+	binlogErr := binlogCmd.Wait()
+	mysqlErr := mysqlCmd.Wait()
+
+	if binlogErr != nil {
+		return fmt.Errorf("mysqlbinlog gagal: %w: %s", binlogErr, strings.TrimSpace(binlogStderr.String()))
+	}
+	if mysqlErr != nil {
+		return fmt.Errorf("mysql gagal saat replay binlog: %w: %s", mysqlErr, strings.TrimSpace(mysqlStderr.String()))
+	}
+	if warn := strings.TrimSpace(mysqlStderr.String()); warn != "" {
+		s.Logger.Warnf("Replay binlog selesai dengan warning: %s", warn)
+	}
+	return nil
+}
+
+// decodeArchivedBinlog membuka lapisan enkripsi/kompresi satu arsip binlog ke direktori sementara.
+// Arsip binlog selalu berada di disk lokal sehingga tidak dibaca melalui storage backup.
+func (s *Service) decodeArchivedBinlog(archiveDir string, entry binlog.ArchiveEntry, tmpDir, encryptionKey string) (string, error) {
+	archivePath := filepath.Join(archiveDir, entry.ArchiveFile)
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return "", fmt.Errorf("gagal membuka arsip binlog %s: %w", entry.Name, err)
+	}
+	reader, err := s.wrapBackupReader(archivePath, file, file, encryptionKey)
+	if err != nil {
+		return "", fmt.Errorf("gagal membuka arsip binlog %s: %w", entry.Name, err)
+	}
+	defer reader.Close()
+
+	outPath := filepath.Join(tmpDir, entry.Name)
+	out, err := os.Create(outPath)
+	if err != nil {
+		return "", fmt.Errorf("gagal membuat file binlog sementara: %w", err)
+	}
+	if _, err := io.Copy(out, reader); err != nil {
+		out.Close()
+		return "", fmt.Errorf("gagal mendekode arsip binlog %s: %w", entry.Name, err)
+	}
+	if err := out.Close(); err != nil {
+		return "", fmt.Errorf("gagal menulis file binlog sementara: %w", err)
+	}
+	return outPath, nil
+}
+
+// restoreTempDirectory mengembalikan direktori sementara dari konfigurasi, atau default sistem
+func (s *Service) restoreTempDirectory() string {
+	if s.Config != nil && s.Config.Backup.Output.TempDirectory != "" {
+		if err := os.MkdirAll(s.Config.Backup.Output.TempDirectory, 0755); err == nil {
+			return s.Config.Backup.Output.TempDirectory
+		}
+	}
+	return ""
+}
@@ -0,0 +1,86 @@
+// File : internal/backup/backup_reader.go
+// Deskripsi : Helper untuk membuka file backup dan membalik lapisan enkripsi serta kompresi
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package backup
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sfDBTools/pkg/compress"
+	"sfDBTools/pkg/encrypt"
+)
+
+// backupReadCloser menggabungkan reader hasil dekompresi dengan semua closer di bawahnya.
+type backupReadCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+// Close menutup semua layer dari yang paling luar (dekompresi) hingga file.
+func (b *backupReadCloser) Close() error {
+	var firstErr error
+	for i := len(b.closers) - 1; i >= 0; i-- {
+		if err := b.closers[i].Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// openBackupReader membuka file backup dari storage dan mengembalikan stream SQL asli.
+// Urutan layer merupakan kebalikan dari executeDumpWithPipe: File -> Dekripsi -> Dekompresi.
+func (s *Service) openBackupReader(path string, encryptionKey string) (io.ReadCloser, error) {
+	store, err := s.getStorage()
+	if err != nil {
+		return nil, err
+	}
+	file, err := store.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuka file backup: %w", err)
+	}
+
+	return s.wrapBackupReader(path, file, file, encryptionKey)
+}
+
+// wrapBackupReader membungkus reader mentah file backup dengan layer dekripsi dan dekompresi.
+// Jenis enkripsi dideteksi dari header stream dan jenis kompresi dari ekstensi path.
+func (s *Service) wrapBackupReader(path string, raw io.Reader, rawCloser io.Closer, encryptionKey string) (io.ReadCloser, error) {
+	buffered := bufio.NewReader(raw)
+	rc := &backupReadCloser{Reader: buffered, closers: []io.Closer{rawCloser}}
+
+	// Header diintip tanpa membuka ulang file agar juga berlaku untuk object di remote storage
+	header, err := buffered.Peek(encrypt.EncryptedHeaderSize)
+	if err != nil && err != io.EOF {
+		rc.Close()
+		return nil, fmt.Errorf("gagal memeriksa enkripsi file: %w", err)
+	}
+	encrypted := encrypt.IsEncryptedHeader(header)
+
+	if encrypted {
+		if encryptionKey == "" {
+			rc.Close()
+			return nil, fmt.Errorf("file %s terenkripsi namun kunci enkripsi tidak tersedia", path)
+		}
+		decryptingReader, err := encrypt.NewDecryptingReader(rc.Reader, []byte(encryptionKey))
+		if err != nil {
+			rc.Close()
+			return nil, fmt.Errorf("gagal membuat decrypting reader: %w", err)
+		}
+		rc.Reader = decryptingReader
+	}
+
+	compressionType := compress.DetectCompressionTypeFromFile(path)
+	decompressingReader, err := compress.NewDecompressingReader(rc.Reader, compressionType)
+	if err != nil {
+		rc.Close()
+		return nil, fmt.Errorf("gagal membuat decompressing reader (%s): %w", compressionType, err)
+	}
+	rc.closers = append(rc.closers, decompressingReader)
+	rc.Reader = decompressingReader
+
+	return rc, nil
+}
@@ -0,0 +1,321 @@
+// File : internal/backup/backup_restore.go
+// Deskripsi : Fungsi untuk me-restore file backup sfDBTools ke server database
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sfDBTools/pkg/compress"
+	"sfDBTools/pkg/database"
+	"sfDBTools/pkg/dbconfig"
+	"sfDBTools/pkg/encrypt"
+	"sfDBTools/pkg/input"
+	"sfDBTools/pkg/ui"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExecuteRestore adalah entry point untuk me-restore file backup ke server database.
+// Sumber restore dapat berupa file tunggal (--file) atau backup ID dari summary (--backup-id).
+// Dengan --until/--until-gtid, arsip binlog di-replay setelah backup dasar di-restore (point-in-time restore).
+func (s *Service) ExecuteRestore(ctx context.Context) error {
+	ui.Headers("Restore Database")
+
+	// 1. Tentukan file yang akan di-restore (dan binlog yang di-replay untuk point-in-time restore)
+	var targets []restoreTarget
+	var replayPlan *binlogReplayPlan
+	var err error
+	if s.isPointInTimeRestore() {
+		targets, replayPlan, err = s.resolvePITRTargets()
+	} else {
+		targets, err = s.resolveRestoreTargets()
+	}
+	if err != nil {
+		return err
+	}
+
+	// 2. Pilih profil koneksi server tujuan
+	if err := s.CheckAndSelectRestoreConfigFile(); err != nil {
+		return err
+	}
+
+	// 3. Tampilkan rencana restore dan minta konfirmasi
+	s.displayRestorePlan(targets)
+	if replayPlan != nil {
+		s.displayReplayPlan(replayPlan)
+	}
+
+	if !s.RestoreOptions.Force && !input.AssumeYes() {
+		// Default konfirmasi adalah tidak, sehingga mode non-interaktif tanpa --yes/--force gagal dengan jelas
+		if err := input.RequireInteractive("Lanjutkan restore?", "gunakan --force atau --yes untuk melewati konfirmasi"); err != nil {
+			return err
+		}
+		ok, err := input.AskYesNo("Objek database yang sudah ada di server tujuan dapat tertimpa. Lanjutkan restore?", false)
+		if err != nil {
+			return fmt.Errorf("gagal mendapatkan konfirmasi dari user: %w", err)
+		}
+		if !ok {
+			s.Logger.Warn("Proses restore dibatalkan oleh pengguna.")
+			return ErrUserCancelled
+		}
+	}
+
+	// 4. Dapatkan kunci dekripsi jika ada file yang terenkripsi
+	encryptionKey, err := s.resolveRestoreEncryptionKey(targets, replayPlan)
+	if err != nil {
+		return err
+	}
+
+	// 5. Restore setiap file secara berurutan
+	ui.PrintSubHeader("Memulai Proses Restore")
+	for i, target := range targets {
+		startTime := time.Now()
+		s.Logger.Infof("[%d/%d] Restore file: %s", i+1, len(targets), target.FilePath)
+
+		stderrOutput, err := s.restoreSingleFile(ctx, target, encryptionKey)
+		if err != nil {
+			s.Logger.Errorf("Restore file %s gagal: %v", target.FilePath, err)
+			return fmt.Errorf("gagal restore file %s: %w", target.FilePath, err)
+		}
+
+		if stderrOutput != "" {
+			s.Logger.Warnf("Restore file %s selesai dengan warning: %s", target.FilePath, strings.TrimSpace(stderrOutput))
+		}
+
+		ui.PrintSuccess(fmt.Sprintf("[%d/%d] %s berhasil di-restore (%s)", i+1, len(targets), filepath.Base(target.FilePath), ui.FormatDuration(time.Since(startTime))))
+	}
+
+	// 6. Replay binlog sampai target point-in-time
+	if replayPlan != nil {
+		startTime := time.Now()
+		s.Logger.Infof("Replay %d binlog dari GTID %s", len(replayPlan.Entries), replayPlan.StartGTID)
+		if err := s.replayBinlogs(ctx, replayPlan, encryptionKey); err != nil {
+			s.Logger.Errorf("Replay binlog gagal: %v", err)
+			return fmt.Errorf("gagal replay binlog: %w", err)
+		}
+		ui.PrintSuccess(fmt.Sprintf("Replay %d binlog selesai (%s)", len(replayPlan.Entries), ui.FormatDuration(time.Since(startTime))))
+	}
+
+	s.Logger.Infof("Restore selesai: %d file berhasil di-restore.", len(targets))
+	return nil
+}
+
+// CheckAndSelectRestoreConfigFile memilih profil dbconfig untuk server tujuan restore.
+func (s *Service) CheckAndSelectRestoreConfigFile() error {
+	err := dbconfig.CheckAndSelectConfigFile(
+		&s.RestoreOptions.DBConfig,
+		s.RestoreOptions.DBConfig.EncryptionKey,
+		"Pilih file konfigurasi database tujuan:",
+	)
+
+	if err == ErrUserCancelled {
+		s.Logger.Warn("Proses restore dibatalkan oleh pengguna.")
+		return ErrUserCancelled
+	}
+
+	return err
+}
+
+// resolveRestoreTargets menentukan daftar file yang akan di-restore dari flags.
+func (s *Service) resolveRestoreTargets() ([]restoreTarget, error) {
+	opts := s.RestoreOptions
+
+	if opts.File != "" && opts.BackupID != "" {
+		return nil, fmt.Errorf("gunakan salah satu dari --file atau --backup-id, tidak keduanya")
+	}
+
+	var targets []restoreTarget
+	switch {
+	case opts.File != "":
+		if len(opts.Databases) > 0 || opts.Users {
+			return nil, fmt.Errorf("flag --db dan --users hanya dapat digunakan bersama --backup-id")
+		}
+		targets = append(targets, restoreTarget{FilePath: opts.File})
+
+	case opts.BackupID != "":
+		summary, err := s.loadSummaryByID(opts.BackupID)
+		if err != nil {
+			return nil, err
+		}
+		targets, err = s.restoreTargetsFromSummary(summary, opts.Databases)
+		if err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("tentukan file backup dengan --file atau backup ID dengan --backup-id")
+	}
+
+	if err := s.completeRestoreTargets(targets); err != nil {
+		return nil, err
+	}
+
+	return targets, nil
+}
+
+// completeRestoreTargets memastikan setiap file dapat diakses dan melengkapi informasi enkripsi serta kompresinya.
+func (s *Service) completeRestoreTargets(targets []restoreTarget) error {
+	store, err := s.getStorage()
+	if err != nil {
+		return err
+	}
+	for i := range targets {
+		if _, err := store.Stat(targets[i].FilePath); err != nil {
+			return fmt.Errorf("file backup tidak dapat diakses: %w", err)
+		}
+		encrypted, err := s.isEncryptedBackupFile(targets[i].FilePath)
+		if err != nil {
+			return err
+		}
+		targets[i].Encrypted = encrypted
+		targets[i].Compress = string(compress.DetectCompressionTypeFromFile(targets[i].FilePath))
+	}
+	return nil
+}
+
+// restoreTargetsFromSummary mengambil daftar file backup dari summary JSON.
+// Jika databases tidak kosong, hanya file yang berisi database tersebut yang diambil.
+func (s *Service) restoreTargetsFromSummary(summary *BackupSummary, databases []string) ([]restoreTarget, error) {
+	if summary.Status == "failed" {
+		s.Logger.Warnf("Backup %s berstatus failed, hanya file yang berhasil yang dapat di-restore.", summary.BackupID)
+	}
+
+	if summary.BackupMode == backupModePhysical {
+		return nil, fmt.Errorf("backup %s adalah backup fisik (xbstream); ekstrak dengan mbstream lalu gunakan 'mariabackup --prepare' dan '--copy-back'", summary.BackupID)
+	}
+
+	if len(databases) > 0 && summary.BackupMode == "combined" {
+		return nil, fmt.Errorf("backup %s menggunakan mode combined (satu file untuk semua database), filter --db tidak didukung", summary.BackupID)
+	}
+
+	wanted := make(map[string]bool)
+	for _, db := range databases {
+		if db = strings.TrimSpace(db); db != "" {
+			wanted[db] = true
+		}
+	}
+
+	// Kelompokkan database berdasarkan file output (mode combined memakai satu file untuk semua database)
+	var targets []restoreTarget
+	index := make(map[string]int)
+	for _, db := range summary.SuccessfulDatabases {
+		if len(wanted) > 0 && !wanted[db.DatabaseName] {
+			continue
+		}
+		delete(wanted, db.DatabaseName)
+
+		if i, ok := index[db.OutputFile]; ok {
+			targets[i].Databases = append(targets[i].Databases, db.DatabaseName)
+			continue
+		}
+		index[db.OutputFile] = len(targets)
+		targets = append(targets, restoreTarget{
+			FilePath:  db.OutputFile,
+			Databases: []string{db.DatabaseName},
+		})
+	}
+
+	if len(wanted) > 0 {
+		var missing []string
+		for db := range wanted {
+			missing = append(missing, db)
+		}
+		return nil, fmt.Errorf("database %v tidak ditemukan sebagai backup berhasil pada %s", missing, summary.BackupID)
+	}
+
+	// File users di-restore terakhir karena GRANT level tabel membutuhkan tabelnya sudah ada
+	if s.RestoreOptions.Users {
+		if summary.UsersExport == nil {
+			return nil, fmt.Errorf("backup %s tidak memiliki file export user dan grant", summary.BackupID)
+		}
+		targets = append(targets, restoreTarget{FilePath: summary.UsersExport.OutputFile})
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("tidak ada file backup yang dapat di-restore pada %s", summary.BackupID)
+	}
+
+	return targets, nil
+}
+
+// resolveRestoreEncryptionKey mendapatkan kunci dekripsi hanya jika ada file backup atau arsip binlog yang terenkripsi.
+func (s *Service) resolveRestoreEncryptionKey(targets []restoreTarget, plan *binlogReplayPlan) (string, error) {
+	needed := false
+	for _, target := range targets {
+		needed = needed || target.Encrypted
+	}
+	if plan != nil {
+		for _, entry := range plan.Entries {
+			needed = needed || entry.Encrypted
+		}
+	}
+	if !needed {
+		return "", nil
+	}
+
+	key, source, err := encrypt.ResolveEncryptionKey(s.RestoreOptions.EncryptionKey)
+	if err != nil {
+		return "", fmt.Errorf("gagal mendapatkan kunci enkripsi: %w", err)
+	}
+	s.Logger.Infof("Kunci enkripsi diperoleh dari: %s", source)
+	return key, nil
+}
+
+// restoreSingleFile mengalirkan isi satu file backup ke client mysql.
+// Mengembalikan stderr output dari mysql untuk keperluan logging.
+func (s *Service) restoreSingleFile(ctx context.Context, target restoreTarget, encryptionKey string) (string, error) {
+	reader, err := s.openBackupReader(target.FilePath, encryptionKey)
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	dbConn := s.RestoreOptions.DBConfig.ServerDBConnection
+	cmd, cleanup, err := database.ClientCommand(ctx, dbConn, "mysql", s.buildConnectionArgs(dbConn)...)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+	cmd.Stdin = reader
+
+	var stderrBuf strings.Builder
+	cmd.Stderr = &stderrBuf
+
+	if err := cmd.Run(); err != nil {
+		stderrOutput := strings.TrimSpace(stderrBuf.String())
+		if stderrOutput != "" {
+			return stderrOutput, fmt.Errorf("mysql gagal: %w: %s", err, stderrOutput)
+		}
+		return stderrOutput, fmt.Errorf("mysql gagal: %w", err)
+	}
+
+	return stderrBuf.String(), nil
+}
+
+// displayRestorePlan menampilkan daftar file yang akan di-restore dalam bentuk tabel.
+func (s *Service) displayRestorePlan(targets []restoreTarget) {
+	ui.PrintSubHeader("Rencana Restore")
+
+	headers := []string{"No", "File", "Database", "Kompresi", "Enkripsi"}
+	var rows [][]string
+	for i, target := range targets {
+		databases := "-"
+		if len(target.Databases) > 0 {
+			databases = ui.FormatStringSlice(target.Databases)
+		}
+		rows = append(rows, []string{
+			strconv.Itoa(i + 1),
+			target.FilePath,
+			databases,
+			target.Compress,
+			strconv.FormatBool(target.Encrypted),
+		})
+	}
+	ui.FormatTable(headers, rows)
+}
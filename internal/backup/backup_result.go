@@ -0,0 +1,67 @@
+// File : internal/backup/backup_result.go
+// Deskripsi : Ringkasan backup untuk hasil --result-json dan penentuan exit code backup sebagian
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package backup
+
+import (
+	"fmt"
+	"path/filepath"
+	"sfDBTools/pkg/cmdresult"
+	"sfDBTools/pkg/exitcode"
+)
+
+// backupResultDetail adalah ringkasan backup yang ditulis ke hasil --result-json
+type backupResultDetail struct {
+	BackupID        string   `json:"backup_id"`
+	BackupMode      string   `json:"backup_mode"`
+	Status          string   `json:"status"`
+	SummaryFile     string   `json:"summary_file"`
+	ManifestFile    string   `json:"manifest_file,omitempty"`
+	OutputDirectory string   `json:"output_directory"`
+	TotalDatabases  int      `json:"total_databases"`
+	Successful      []string `json:"successful_databases"`
+	Failed          []string `json:"failed_databases"`
+	Files           []string `json:"files"`
+	TotalSizeBytes  int64    `json:"total_size_bytes"`
+	Duration        string   `json:"duration"`
+}
+
+// setResultDetails mencatat ringkasan backup ke hasil --result-json
+func (s *Service) setResultDetails(summary *BackupSummary) {
+	detail := backupResultDetail{
+		BackupID:        summary.BackupID,
+		BackupMode:      summary.BackupMode,
+		Status:          summary.Status,
+		SummaryFile:     filepath.Join(s.getSummaryDir(), fmt.Sprintf("%s.json", summary.BackupID)),
+		ManifestFile:    summary.ManifestFile,
+		OutputDirectory: summary.OutputInfo.OutputDirectory,
+		TotalDatabases:  summary.DatabaseStats.TotalDatabases,
+		Successful:      []string{},
+		Failed:          []string{},
+		Files:           []string{},
+		TotalSizeBytes:  summary.OutputInfo.TotalSize,
+		Duration:        summary.Duration,
+	}
+	for _, db := range summary.SuccessfulDatabases {
+		detail.Successful = append(detail.Successful, db.DatabaseName)
+	}
+	for _, db := range summary.FailedDatabases {
+		detail.Failed = append(detail.Failed, db.DatabaseName)
+	}
+	for _, file := range summary.OutputInfo.Files {
+		detail.Files = append(detail.Files, file.FilePath)
+	}
+	cmdresult.SetDetail("backup", detail)
+}
+
+// backupOutcomeError menandai error backup sebagai keberhasilan sebagian (exit code 2)
+// jika summary tidak berstatus failed, misalnya sebagian database berhasil di-backup.
+func backupOutcomeError(summary *BackupSummary, err error) error {
+	if err == nil || summary.Status == "failed" {
+		return err
+	}
+	return exitcode.AsPartial(err)
+}
@@ -0,0 +1,370 @@
+// File : internal/backup/backup_retention.go
+// Deskripsi : Retensi grandfather-father-son (GFS) berbasis backup set dari summary JSON
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package backup
+
+import (
+	"fmt"
+	"path/filepath"
+	"sfDBTools/pkg/storage"
+	"sfDBTools/pkg/ui"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// retentionPolicyAge menghapus file backup berdasarkan mtime (perilaku lama)
+	retentionPolicyAge = "age"
+	// retentionPolicyGFS menyimpan backup set harian, mingguan, dan bulanan
+	retentionPolicyGFS = "gfs"
+)
+
+// retentionSet adalah satu backup set (satu summary) beserta semua file miliknya dan keputusan retensinya
+type retentionSet struct {
+	Summary *BackupSummary
+	Files   []string // File data, error log, MANIFEST, lalu summary JSON di urutan terakhir
+	Size    int64
+	Reasons []string // Aturan yang menyimpan set ini; kosong berarti set dihapus
+}
+
+func (r *retentionSet) keep(reason string) {
+	r.Reasons = append(r.Reasons, reason)
+}
+
+func (r *retentionSet) kept() bool {
+	return len(r.Reasons) > 0
+}
+
+// retentionPolicy mengembalikan kebijakan retensi yang aktif; konfigurasi lama tanpa policy memakai age
+func (s *Service) retentionPolicy() (string, error) {
+	policy := strings.ToLower(strings.TrimSpace(s.BackupOptions.Cleanup.Policy))
+	switch policy {
+	case "", retentionPolicyAge:
+		return retentionPolicyAge, nil
+	case retentionPolicyGFS:
+		return retentionPolicyGFS, nil
+	default:
+		return "", fmt.Errorf("retention policy tidak dikenal: %s (gunakan %s atau %s)", policy, retentionPolicyGFS, retentionPolicyAge)
+	}
+}
+
+// cleanupGFS menerapkan retensi GFS pada semua backup set yang summary-nya tersimpan.
+// Set yang tidak disimpan aturan manapun dihapus utuh; arsip binlog sebelum posisi GTID set tertua
+// yang disimpan ikut dihapus. Dry-run hanya menampilkan keputusan per set dan per arsip binlog.
+func (s *Service) cleanupGFS(dryRun bool) error {
+	opts := s.BackupOptions.Cleanup
+	s.Logger.Infof("Cleanup policy: gfs (harian=%d, mingguan=%d, bulanan=%d, set gagal disimpan %d hari)",
+		opts.KeepDaily, opts.KeepWeekly, opts.KeepMonthly, opts.RetentionDays)
+
+	summaries, err := s.loadAllSummaries()
+	if storage.IsNotExist(err) {
+		s.Logger.Info("Belum ada summary backup, tidak ada backup set yang perlu dihapus.")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("gagal membaca summary backup: %w", err)
+	}
+
+	sets := s.buildRetentionSets(summaries)
+	applyGFSRules(sets, opts.KeepDaily, opts.KeepWeekly, opts.KeepMonthly, opts.RetentionDays, time.Now())
+	s.warnUntrackedBackupFiles(sets)
+	binlogPlans := s.planBinlogRetention(sets)
+
+	if dryRun {
+		s.displayRetentionPlan(sets)
+		s.displayBinlogRetentionPlan(binlogPlans)
+		return nil
+	}
+	s.deleteRetentionSets(sets)
+	s.pruneBinlogArchives(binlogPlans)
+	return nil
+}
+
+// buildRetentionSets mengubah summary menjadi backup set, diurutkan dari yang terbaru
+func (s *Service) buildRetentionSets(summaries []*BackupSummary) []*retentionSet {
+	sets := make([]*retentionSet, 0, len(summaries))
+	for _, summary := range summaries {
+		set := &retentionSet{Summary: summary, Size: summary.OutputInfo.TotalSize}
+		seen := make(map[string]bool)
+		add := func(path string) {
+			if path == "" || seen[filepath.Clean(path)] {
+				return
+			}
+			seen[filepath.Clean(path)] = true
+			set.Files = append(set.Files, filepath.Clean(path))
+		}
+		for _, file := range summary.OutputInfo.Files {
+			add(file.FilePath)
+		}
+		for _, info := range summary.SuccessfulDatabases {
+			add(info.OutputFile)
+			add(info.ErrorLogFile)
+		}
+		if summary.UsersExport != nil {
+			add(summary.UsersExport.OutputFile)
+		}
+		add(summary.ManifestFile)
+		// Summary dihapus paling akhir agar set yang gagal dihapus sebagian tetap terlihat pada cleanup berikutnya
+		add(filepath.Join(s.getSummaryDir(), summary.BackupID+".json"))
+		sets = append(sets, set)
+	}
+	sort.SliceStable(sets, func(i, j int) bool {
+		return sets[i].Summary.StartTime.After(sets[j].Summary.StartTime)
+	})
+	return sets
+}
+
+// applyGFSRules menandai set yang disimpan. sets harus terurut dari yang terbaru.
+// Bucket harian/mingguan/bulanan dihitung dari periode yang memiliki backup (bukan kalender),
+// sehingga hari tanpa backup tidak menghabiskan jatah keep_daily.
+func applyGFSRules(sets []*retentionSet, keepDaily, keepWeekly, keepMonthly, failedDays int, now time.Time) {
+	buckets := []struct {
+		name  string
+		limit int
+		key   func(t time.Time) string
+	}{
+		{"harian", keepDaily, func(t time.Time) string { return t.Format("2006-01-02") }},
+		{"mingguan", keepWeekly, func(t time.Time) string {
+			year, week := t.ISOWeek()
+			return fmt.Sprintf("%d-W%02d", year, week)
+		}},
+		{"bulanan", keepMonthly, func(t time.Time) string { return t.Format("2006-01") }},
+	}
+	for _, bucket := range buckets {
+		seen := make(map[string]bool)
+		for _, set := range sets {
+			if len(seen) >= bucket.limit {
+				break
+			}
+			if !usableSet(set.Summary) {
+				continue
+			}
+			key := bucket.key(set.Summary.StartTime.Local())
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			set.keep(fmt.Sprintf("%s %s", bucket.name, key))
+		}
+	}
+
+	// Set terakhir yang berhasil untuk setiap database selalu disimpan
+	lastSuccess := make(map[string]bool)
+	for _, set := range sets {
+		var databases []string
+		for _, info := range set.Summary.SuccessfulDatabases {
+			if lastSuccess[info.DatabaseName] {
+				continue
+			}
+			lastSuccess[info.DatabaseName] = true
+			databases = append(databases, info.DatabaseName)
+		}
+		if len(databases) > 0 {
+			set.keep("terakhir berhasil: " + abbreviateList(databases, 3))
+		}
+	}
+
+	// Set gagal disimpan sementara agar penyebabnya masih bisa diperiksa
+	if failedDays > 0 {
+		cutoff := now.AddDate(0, 0, -failedDays)
+		for _, set := range sets {
+			if !usableSet(set.Summary) && set.Summary.StartTime.After(cutoff) {
+				set.keep(fmt.Sprintf("gagal < %d hari", failedDays))
+			}
+		}
+	}
+
+	// Basis backup fisik incremental harus ikut disimpan selama incremental-nya disimpan
+	byID := make(map[string]*retentionSet, len(sets))
+	for _, set := range sets {
+		byID[set.Summary.BackupID] = set
+	}
+	for _, set := range sets {
+		if !set.kept() {
+			continue
+		}
+		for child := set; child.Summary.Physical != nil && child.Summary.Physical.BaseBackupID != ""; {
+			base, ok := byID[child.Summary.Physical.BaseBackupID]
+			if !ok {
+				break
+			}
+			reason := "basis incremental " + child.Summary.BackupID
+			if containsString(base.Reasons, reason) {
+				break
+			}
+			base.keep(reason)
+			child = base
+		}
+	}
+}
+
+// usableSet menentukan apakah set berisi backup yang bisa di-restore
+func usableSet(summary *BackupSummary) bool {
+	return summary.Status != "failed" && len(summary.SuccessfulDatabases) > 0
+}
+
+// deleteRetentionSets menghapus semua file milik set yang tidak disimpan.
+// File yang juga dirujuk oleh set yang disimpan tidak pernah dihapus.
+func (s *Service) deleteRetentionSets(sets []*retentionSet) {
+	store, err := s.getStorage()
+	if err != nil {
+		s.Logger.Errorf("Gagal membuka storage backup: %v", err)
+		return
+	}
+
+	protected := make(map[string]bool)
+	for _, set := range sets {
+		if set.kept() {
+			for _, path := range set.Files {
+				protected[path] = true
+			}
+		}
+	}
+
+	var deletedSets, deletedFiles int
+	var freed int64
+	for _, set := range sets {
+		if set.kept() {
+			continue
+		}
+		failed := false
+		for _, path := range set.Files {
+			if protected[path] {
+				continue
+			}
+			if !s.insideBackupDirs(path) {
+				s.Logger.Warnf("Melewati %s: berada di luar direktori backup", path)
+				continue
+			}
+			// Summary tidak dihapus jika ada file data yang gagal dihapus, agar dicoba lagi pada cleanup berikutnya
+			if failed && strings.HasSuffix(path, ".json") && filepath.Dir(path) == filepath.Clean(s.getSummaryDir()) {
+				continue
+			}
+			if err := store.Remove(path); err != nil && !storage.IsNotExist(err) {
+				s.Logger.Errorf("Gagal menghapus %s: %v", path, err)
+				failed = true
+				continue
+			}
+			deletedFiles++
+		}
+		if failed {
+			s.Logger.Warnf("Backup set %s hanya terhapus sebagian", set.Summary.BackupID)
+			continue
+		}
+		deletedSets++
+		freed += set.Size
+		s.Logger.Infof("Backup set dihapus: %s (%s, %d file, %s)", set.Summary.BackupID,
+			set.Summary.StartTime.Format(timeFormat), len(set.Files), s.formatFileSize(set.Size))
+	}
+
+	if deletedSets == 0 {
+		s.Logger.Info("Tidak ada backup set yang perlu dihapus.")
+		return
+	}
+	s.Logger.Infof("Cleanup selesai: %d backup set (%d file) dihapus, total %s ruang dibebaskan.",
+		deletedSets, deletedFiles, s.formatFileSize(freed))
+}
+
+// displayRetentionPlan menampilkan keputusan retensi setiap set beserta aturan yang menyimpannya
+func (s *Service) displayRetentionPlan(sets []*retentionSet) {
+	ui.PrintSubHeader("DRY-RUN: Rencana Retensi GFS")
+	headers := []string{"Backup ID", "Tanggal", "Status", "Mode", "Ukuran", "Keputusan", "Aturan"}
+	var rows [][]string
+	var deleteCount int
+	var deleteSize int64
+	for _, set := range sets {
+		decision, reasons := retentionDecision(set)
+		if !set.kept() {
+			deleteCount++
+			deleteSize += set.Size
+		}
+		rows = append(rows, []string{
+			set.Summary.BackupID,
+			set.Summary.StartTime.Format("2006-01-02 15:04"),
+			set.Summary.Status,
+			set.Summary.BackupMode,
+			s.formatFileSize(set.Size),
+			decision,
+			reasons,
+		})
+	}
+	ui.FormatTable(headers, rows)
+	s.Logger.Infof("DRY-RUN: %d dari %d backup set AKAN dihapus, total %s akan dibebaskan.",
+		deleteCount, len(sets), s.formatFileSize(deleteSize))
+	s.Logger.Info("DRY-RUN: Untuk menjalankan cleanup sebenarnya, jalankan tanpa flag --dry-run.")
+}
+
+// retentionDecision mengembalikan keputusan dan alasan yang ditampilkan dry-run untuk satu set
+func retentionDecision(set *retentionSet) (string, string) {
+	if !set.kept() {
+		return "HAPUS", "tidak masuk aturan manapun"
+	}
+	return "SIMPAN", strings.Join(set.Reasons, "; ")
+}
+
+// warnUntrackedBackupFiles melaporkan file backup yang tidak tercatat di summary manapun.
+// File tersebut tidak disentuh policy gfs (misalnya backup yang sedang berjalan atau backup sebelum summary ada).
+// Arsip binlog tidak dihitung karena dikelola lewat index.json-nya sendiri.
+func (s *Service) warnUntrackedBackupFiles(sets []*retentionSet) {
+	store, err := s.getStorage()
+	if err != nil {
+		return
+	}
+	files, err := store.List(s.BackupOptions.OutputDirectory)
+	if err != nil {
+		return
+	}
+	tracked := make(map[string]bool)
+	for _, set := range sets {
+		for _, path := range set.Files {
+			tracked[path] = true
+		}
+	}
+	var untracked int
+	for _, file := range files {
+		if rel, err := filepath.Rel(s.BackupOptions.OutputDirectory, file.Path); err == nil && inBinlogArchive(rel) {
+			continue
+		}
+		if s.isBackupFile(file.Path) && !tracked[filepath.Clean(file.Path)] {
+			untracked++
+		}
+	}
+	if untracked > 0 {
+		s.Logger.Warnf("%d file backup tidak tercatat di summary manapun dan tidak dihapus oleh policy gfs; gunakan --pattern untuk membersihkannya", untracked)
+	}
+}
+
+// insideBackupDirs memastikan path berada di direktori output backup atau direktori summary
+func (s *Service) insideBackupDirs(path string) bool {
+	for _, dir := range []string{s.BackupOptions.OutputDirectory, s.getSummaryDir()} {
+		if dir == "" {
+			continue
+		}
+		rel, err := filepath.Rel(filepath.Clean(dir), path)
+		if err == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+			return true
+		}
+	}
+	return false
+}
+
+// abbreviateList menggabungkan daftar dan meringkas sisanya jika melebihi max
+func abbreviateList(items []string, max int) string {
+	if len(items) <= max {
+		return strings.Join(items, ", ")
+	}
+	return fmt.Sprintf("%s, +%d lainnya", strings.Join(items[:max], ", "), len(items)-max)
+}
+
+func containsString(items []string, target string) bool {
+	for _, item := range items {
+		if item == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,199 @@
+// File : internal/backup/backup_retention_binlog.go
+// Deskripsi : Retensi arsip binlog mengikuti backup set yang disimpan policy gfs
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package backup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sfDBTools/internal/binlog"
+	"sfDBTools/pkg/ui"
+	"sort"
+	"strings"
+)
+
+// binlogArchiveSubdir adalah subdirektori arsip binlog di bawah direktori output backup (lihat binlog.ArchiveDir).
+// Isinya dikelola index.json sehingga tidak boleh tersentuh pemindaian file backup biasa.
+const binlogArchiveSubdir = "binlogs"
+
+// inBinlogArchive mengembalikan true jika path relatif terhadap direktori output berada di subtree arsip binlog
+func inBinlogArchive(relPath string) bool {
+	relPath = filepath.ToSlash(filepath.Clean(relPath))
+	return relPath == binlogArchiveSubdir || strings.HasPrefix(relPath, binlogArchiveSubdir+"/")
+}
+
+// binlogPrunePlan adalah arsip binlog satu server yang tidak lagi dibutuhkan backup set yang disimpan
+type binlogPrunePlan struct {
+	Server     string
+	ArchiveDir string
+	OldestGTID string                // Posisi GTID set tertua yang disimpan
+	KeepFrom   string                // Binlog pertama yang tetap disimpan
+	Entries    []binlog.ArchiveEntry // Arsip yang dihapus, dari yang tertua
+}
+
+// binlogPruneCount mengembalikan jumlah entry terdepan pada index (terurut dari binlog tertua) yang
+// tidak dibutuhkan replay dari posisi GTID manapun. Setiap posisi membutuhkan binlog terakhir yang
+// Gtid_list awalnya belum melewati posisi tersebut beserta semua binlog setelahnya, sama seperti
+// rencana replay PITR. Jika ada posisi yang tidak tercakup arsip, tidak ada entry yang dihapus.
+func binlogPruneCount(entries []binlog.ArchiveEntry, positions []string) (int, string, error) {
+	prune, oldest := len(entries), ""
+	for _, position := range positions {
+		start := -1
+		for i, entry := range entries {
+			if entry.StartGTID == "" {
+				continue
+			}
+			ok, err := binlog.GTIDListLessOrEqual(entry.StartGTID, position)
+			if err != nil {
+				return 0, "", fmt.Errorf("gagal membandingkan GTID %s: %w", entry.Name, err)
+			}
+			if ok {
+				start = i
+			}
+		}
+		if start < 0 {
+			return 0, position, nil
+		}
+		if start < prune {
+			prune, oldest = start, position
+		}
+	}
+	if oldest == "" {
+		return 0, "", nil
+	}
+	return prune, oldest, nil
+}
+
+// planBinlogRetention menyusun arsip binlog yang dapat dihapus untuk setiap server yang memiliki
+// backup set tersimpan dengan posisi GTID. Server tanpa set tersebut tidak disentuh.
+// Arsip binlog selalu berada di filesystem lokal, sehingga storage remote dilewati.
+func (s *Service) planBinlogRetention(sets []*retentionSet) []binlogPrunePlan {
+	if s.Config == nil || !s.storageIsLocal() {
+		return nil
+	}
+
+	type server struct {
+		host string
+		port int
+	}
+	positions := make(map[server][]string)
+	for _, set := range sets {
+		summary := set.Summary
+		if !set.kept() || !usableSet(summary) || summary.GTIDPosition == "" || summary.ServerInfo.Host == "" {
+			continue
+		}
+		key := server{summary.ServerInfo.Host, summary.ServerInfo.Port}
+		positions[key] = append(positions[key], summary.GTIDPosition)
+	}
+
+	var plans []binlogPrunePlan
+	for key, gtids := range positions {
+		name := fmt.Sprintf("%s:%d", key.host, key.port)
+		archiveDir := binlog.ArchiveDir(s.Config.Backup.Output.BaseDirectory, key.host, key.port)
+		index, err := binlog.LoadIndex(archiveDir)
+		if err != nil {
+			s.Logger.Warnf("Retensi binlog %s dilewati: %v", name, err)
+			continue
+		}
+		if len(index.Entries) == 0 {
+			continue
+		}
+
+		prune, oldest, err := binlogPruneCount(index.Entries, gtids)
+		if err != nil {
+			s.Logger.Warnf("Retensi binlog %s dilewati: %v", name, err)
+			continue
+		}
+		if prune == 0 {
+			if oldest != "" {
+				s.Logger.Debugf("Retensi binlog %s: tidak ada arsip yang dapat dihapus (posisi GTID set tertua yang disimpan: %s)", name, oldest)
+			}
+			continue
+		}
+
+		plans = append(plans, binlogPrunePlan{
+			Server:     name,
+			ArchiveDir: archiveDir,
+			OldestGTID: oldest,
+			KeepFrom:   index.Entries[prune].Name,
+			Entries:    append([]binlog.ArchiveEntry(nil), index.Entries[:prune]...),
+		})
+	}
+	sort.Slice(plans, func(i, j int) bool { return plans[i].Server < plans[j].Server })
+	return plans
+}
+
+// pruneBinlogArchives menghapus arsip binlog sesuai rencana lalu memperbarui index.json.
+// Penghapusan berhenti pada kegagalan pertama agar entry yang tersisa di index tetap berurutan.
+func (s *Service) pruneBinlogArchives(plans []binlogPrunePlan) {
+	for _, plan := range plans {
+		removed := make(map[string]bool)
+		var freed int64
+		for _, entry := range plan.Entries {
+			path := filepath.Join(plan.ArchiveDir, entry.ArchiveFile)
+			if rel, err := filepath.Rel(plan.ArchiveDir, path); err != nil || strings.HasPrefix(rel, "..") {
+				s.Logger.Warnf("Melewati arsip binlog %s: berada di luar direktori arsip", entry.ArchiveFile)
+				break
+			}
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				s.Logger.Errorf("Gagal menghapus arsip binlog %s: %v", path, err)
+				break
+			}
+			removed[entry.Name] = true
+			freed += entry.ArchivedSize
+			s.Logger.Infof("Arsip binlog dihapus: %s (%s)", path, s.formatFileSize(entry.ArchivedSize))
+		}
+		if len(removed) == 0 {
+			continue
+		}
+
+		// Index dibaca ulang agar entry yang ditambahkan 'binlog archive' selama cleanup tidak hilang
+		index, err := binlog.LoadIndex(plan.ArchiveDir)
+		if err != nil {
+			s.Logger.Errorf("Gagal memperbarui index arsip binlog %s: %v", plan.Server, err)
+			continue
+		}
+		entries := index.Entries[:0]
+		for _, entry := range index.Entries {
+			if !removed[entry.Name] {
+				entries = append(entries, entry)
+			}
+		}
+		index.Entries = entries
+		if err := binlog.SaveIndex(plan.ArchiveDir, index); err != nil {
+			s.Logger.Errorf("Gagal memperbarui index arsip binlog %s: %v", plan.Server, err)
+			continue
+		}
+		s.Logger.Infof("Retensi binlog %s: %d arsip dihapus (%s), arsip mulai %s disimpan.",
+			plan.Server, len(removed), s.formatFileSize(freed), plan.KeepFrom)
+	}
+}
+
+// displayBinlogRetentionPlan menampilkan arsip binlog yang akan dihapus pada dry-run
+func (s *Service) displayBinlogRetentionPlan(plans []binlogPrunePlan) {
+	if len(plans) == 0 {
+		return
+	}
+	ui.PrintSubHeader("DRY-RUN: Retensi Arsip Binlog")
+	headers := []string{"Server", "Binlog", "Gtid_list Awal", "Ukuran", "Keputusan"}
+	var rows [][]string
+	for _, plan := range plans {
+		for _, entry := range plan.Entries {
+			rows = append(rows, []string{plan.Server, entry.Name, entry.StartGTID, s.formatFileSize(entry.ArchivedSize), "HAPUS"})
+		}
+	}
+	ui.FormatTable(headers, rows)
+
+	for _, plan := range plans {
+		var size int64
+		for _, entry := range plan.Entries {
+			size += entry.ArchivedSize
+		}
+		s.Logger.Infof("DRY-RUN: %s: %d arsip binlog sebelum %s AKAN dihapus (%s); posisi GTID set tertua yang disimpan: %s",
+			plan.Server, len(plan.Entries), plan.KeepFrom, s.formatFileSize(size), plan.OldestGTID)
+	}
+}
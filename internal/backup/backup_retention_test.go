@@ -0,0 +1,430 @@
+package backup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"sfDBTools/internal/appconfig"
+	log "sfDBTools/internal/applog"
+	"sfDBTools/internal/binlog"
+	"sfDBTools/internal/structs"
+	"sfDBTools/pkg/storage"
+)
+
+// at membuat waktu lokal karena bucket GFS dihitung dari StartTime.Local()
+func at(value string) time.Time {
+	t, err := time.ParseInLocation("2006-01-02 15:04", value, time.Local)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// testSet membuat backup set dengan status success dan database yang berhasil
+func testSet(id, start string, databases ...string) *retentionSet {
+	summary := &BackupSummary{BackupID: id, Status: "success", StartTime: at(start)}
+	for _, db := range databases {
+		summary.SuccessfulDatabases = append(summary.SuccessfulDatabases, DatabaseBackupInfo{DatabaseName: db})
+	}
+	return &retentionSet{Summary: summary}
+}
+
+func failedSet(id, start string) *retentionSet {
+	return &retentionSet{Summary: &BackupSummary{BackupID: id, Status: "failed", StartTime: at(start)}}
+}
+
+func incrementalSet(id, start, base string) *retentionSet {
+	set := testSet(id, start, "physical")
+	set.Summary.BackupMode = "physical"
+	set.Summary.Physical = &PhysicalBackupInfo{BackupType: "incremental", BaseBackupID: base}
+	return set
+}
+
+func TestApplyGFSRules(t *testing.T) {
+	tests := []struct {
+		name                   string
+		sets                   []*retentionSet // Terurut dari yang terbaru
+		daily, weekly, monthly int
+		failedDays             int
+		now                    string
+		want                   map[string][]string // Backup ID -> alasan; set yang tidak ada di map harus dihapus
+	}{
+		{
+			name: "batas bucket harian",
+			sets: []*retentionSet{
+				testSet("d1", "2026-10-16 23:59", "sales"),
+				testSet("d2", "2026-10-16 00:00", "sales"),
+				testSet("d3", "2026-10-15 23:59", "sales"),
+				testSet("d4", "2026-10-14 12:00", "sales"),
+			},
+			daily: 2,
+			now:   "2026-10-17 01:00",
+			want: map[string][]string{
+				"d1": {"harian 2026-10-16", "terakhir berhasil: sales"},
+				"d3": {"harian 2026-10-15"},
+			},
+		},
+		{
+			name: "hari tanpa backup tidak menghabiskan jatah harian",
+			sets: []*retentionSet{
+				testSet("d1", "2026-10-16 01:00", "sales"),
+				testSet("d2", "2026-10-01 01:00", "sales"),
+				testSet("d3", "2026-09-15 01:00", "sales"),
+			},
+			daily: 2,
+			now:   "2026-10-16 02:00",
+			want: map[string][]string{
+				"d1": {"harian 2026-10-16", "terakhir berhasil: sales"},
+				"d2": {"harian 2026-10-01"},
+			},
+		},
+		{
+			name: "batas minggu ISO Senin dan Minggu",
+			sets: []*retentionSet{
+				testSet("w1", "2026-10-12 00:00", "sales"), // Senin, minggu 42
+				testSet("w2", "2026-10-11 23:59", "sales"), // Minggu, minggu 41
+				testSet("w3", "2026-10-10 12:00", "sales"), // Sabtu, minggu 41
+				testSet("w4", "2026-10-04 12:00", "sales"), // Minggu, minggu 40
+			},
+			weekly: 2,
+			now:    "2026-10-16 02:00",
+			want: map[string][]string{
+				"w1": {"mingguan 2026-W42", "terakhir berhasil: sales"},
+				"w2": {"mingguan 2026-W41"},
+			},
+		},
+		{
+			name: "minggu ISO melewati pergantian tahun",
+			sets: []*retentionSet{
+				testSet("y1", "2027-01-04 01:00", "sales"), // Minggu 2027-W01
+				testSet("y2", "2027-01-01 01:00", "sales"), // Masih 2026-W53
+				testSet("y3", "2026-12-28 01:00", "sales"), // 2026-W53
+				testSet("y4", "2026-12-27 01:00", "sales"), // 2026-W52
+			},
+			weekly: 3,
+			now:    "2027-01-05 02:00",
+			want: map[string][]string{
+				"y1": {"mingguan 2027-W01", "terakhir berhasil: sales"},
+				"y2": {"mingguan 2026-W53"},
+				"y4": {"mingguan 2026-W52"},
+			},
+		},
+		{
+			name: "batas bucket bulanan",
+			sets: []*retentionSet{
+				testSet("m1", "2026-11-01 00:00", "sales"),
+				testSet("m2", "2026-10-31 23:59", "sales"),
+				testSet("m3", "2026-10-01 00:00", "sales"),
+				testSet("m4", "2026-09-30 23:59", "sales"),
+			},
+			monthly: 2,
+			now:     "2026-11-02 00:00",
+			want: map[string][]string{
+				"m1": {"bulanan 2026-11", "terakhir berhasil: sales"},
+				"m2": {"bulanan 2026-10"},
+			},
+		},
+		{
+			name: "bucket bertumpuk pada set yang sama",
+			sets: []*retentionSet{
+				testSet("a1", "2026-10-16 01:00", "sales"),
+				testSet("a2", "2026-10-15 01:00", "sales"),
+				testSet("a3", "2026-09-20 01:00", "sales"),
+			},
+			daily: 1, weekly: 1, monthly: 2,
+			now: "2026-10-16 02:00",
+			want: map[string][]string{
+				"a1": {"harian 2026-10-16", "mingguan 2026-W42", "bulanan 2026-10", "terakhir berhasil: sales"},
+				"a3": {"bulanan 2026-09"},
+			},
+		},
+		{
+			name: "set terakhir berhasil per database di luar semua bucket",
+			sets: []*retentionSet{
+				failedSet("f1", "2026-10-16 01:00"),
+				testSet("s1", "2026-10-15 01:00", "sales", "hr"),
+				testSet("s2", "2026-10-10 01:00", "sales", "finance"),
+				testSet("s3", "2026-10-01 01:00", "hr"),
+				testSet("s4", "2026-09-01 01:00", "legacy", "crm", "erp", "wms"),
+			},
+			now: "2026-10-16 02:00",
+			want: map[string][]string{
+				"s1": {"terakhir berhasil: sales, hr"},
+				"s2": {"terakhir berhasil: finance"},
+				"s4": {"terakhir berhasil: legacy, crm, erp, +1 lainnya"},
+			},
+		},
+		{
+			name: "set gagal tidak mengisi bucket dan disimpan selama failedDays",
+			sets: []*retentionSet{
+				failedSet("f1", "2026-10-16 01:00"),
+				failedSet("f2", "2026-10-13 02:00"),
+				failedSet("f3", "2026-10-13 00:00"),
+				testSet("s1", "2026-10-12 01:00", "sales"),
+			},
+			daily:      1,
+			failedDays: 3,
+			now:        "2026-10-16 01:00",
+			want: map[string][]string{
+				"f1": {"gagal < 3 hari"},
+				"f2": {"gagal < 3 hari"},
+				"s1": {"harian 2026-10-12", "terakhir berhasil: sales"},
+			},
+		},
+		{
+			name: "set gagal langsung dihapus jika failedDays 0",
+			sets: []*retentionSet{
+				failedSet("f1", "2026-10-16 01:00"),
+				testSet("s1", "2026-10-15 01:00", "sales"),
+			},
+			daily: 7,
+			now:   "2026-10-16 02:00",
+			want: map[string][]string{
+				"s1": {"harian 2026-10-15", "terakhir berhasil: sales"},
+			},
+		},
+		{
+			name: "set partial dengan database berhasil tetap dipakai",
+			sets: []*retentionSet{
+				func() *retentionSet {
+					set := testSet("p1", "2026-10-16 01:00", "sales")
+					set.Summary.Status = "partial"
+					return set
+				}(),
+				testSet("s1", "2026-10-15 01:00", "sales", "hr"),
+			},
+			daily: 1,
+			now:   "2026-10-16 02:00",
+			want: map[string][]string{
+				"p1": {"harian 2026-10-16", "terakhir berhasil: sales"},
+				"s1": {"terakhir berhasil: hr"},
+			},
+		},
+		{
+			name: "basis rantai incremental ikut disimpan",
+			sets: []*retentionSet{
+				incrementalSet("i2", "2026-10-15 01:00", "i1"),
+				incrementalSet("i1", "2026-10-08 01:00", "f1"),
+				testSet("f1", "2026-10-01 01:00", "physical"),
+				testSet("f0", "2026-09-01 01:00", "physical"),
+			},
+			daily: 1,
+			now:   "2026-10-16 02:00",
+			want: map[string][]string{
+				"i2": {"harian 2026-10-15", "terakhir berhasil: physical"},
+				"i1": {"basis incremental i2"},
+				"f1": {"basis incremental i1"},
+			},
+		},
+		{
+			name: "rantai incremental yang dihapus tidak menyimpan basisnya",
+			sets: []*retentionSet{
+				testSet("f2", "2026-10-15 01:00", "physical"),
+				incrementalSet("i1", "2026-10-08 01:00", "f1"),
+				testSet("f1", "2026-10-01 01:00", "physical"),
+			},
+			daily: 1,
+			now:   "2026-10-16 02:00",
+			want: map[string][]string{
+				"f2": {"harian 2026-10-15", "terakhir berhasil: physical"},
+			},
+		},
+		{
+			name: "basis yang tidak ada di daftar tidak menyebabkan error",
+			sets: []*retentionSet{
+				incrementalSet("i1", "2026-10-15 01:00", "hilang"),
+			},
+			daily: 1,
+			now:   "2026-10-16 02:00",
+			want: map[string][]string{
+				"i1": {"harian 2026-10-15", "terakhir berhasil: physical"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applyGFSRules(tt.sets, tt.daily, tt.weekly, tt.monthly, tt.failedDays, at(tt.now))
+
+			for _, set := range tt.sets {
+				id := set.Summary.BackupID
+				want, keep := tt.want[id]
+				if !reflect.DeepEqual(set.Reasons, want) {
+					t.Errorf("%s: alasan = %q, ingin %q", id, set.Reasons, want)
+				}
+
+				// Keputusan dan alasan pada tabel dry-run harus sesuai dengan keputusan retensi
+				decision, reasons := retentionDecision(set)
+				if keep {
+					if decision != "SIMPAN" || reasons != strings.Join(want, "; ") {
+						t.Errorf("%s: dry-run = %s (%s), ingin SIMPAN (%s)", id, decision, reasons, strings.Join(want, "; "))
+					}
+				} else if decision != "HAPUS" || reasons != "tidak masuk aturan manapun" {
+					t.Errorf("%s: dry-run = %s (%s), ingin HAPUS", id, decision, reasons)
+				}
+			}
+		})
+	}
+}
+
+func TestBinlogPruneCount(t *testing.T) {
+	entries := []binlog.ArchiveEntry{
+		{Name: "mysql-bin.000001", StartGTID: "0-1-0"},
+		{Name: "mysql-bin.000002", StartGTID: "0-1-100"},
+		{Name: "mysql-bin.000003", StartGTID: ""},
+		{Name: "mysql-bin.000004", StartGTID: "0-1-250"},
+		{Name: "mysql-bin.000005", StartGTID: "0-1-400"},
+	}
+
+	tests := []struct {
+		name       string
+		positions  []string
+		wantPrune  int
+		wantOldest string
+	}{
+		{"tanpa set tersimpan", nil, 0, ""},
+		{"posisi di tengah binlog", []string{"0-1-300"}, 3, "0-1-300"},
+		{"posisi tepat di awal binlog", []string{"0-1-250"}, 3, "0-1-250"},
+		{"set tertua menentukan batas", []string{"0-1-450", "0-1-120", "0-1-300"}, 1, "0-1-120"},
+		{"posisi di binlog pertama", []string{"0-1-50"}, 0, "0-1-50"},
+		{"posisi sebelum arsip pertama", []string{"0-1-450", "1-2-5"}, 0, "1-2-5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prune, oldest, err := binlogPruneCount(entries, tt.positions)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if prune != tt.wantPrune || oldest != tt.wantOldest {
+				t.Errorf("binlogPruneCount = %d, %q; ingin %d, %q", prune, oldest, tt.wantPrune, tt.wantOldest)
+			}
+		})
+	}
+
+	if _, _, err := binlogPruneCount(entries, []string{"3E11FA47-71CA-11E1-9E33-C80AA9429562:1-5"}); err == nil {
+		t.Error("GTID format MySQL diterima, ingin error")
+	}
+}
+
+func TestBinlogArchivesExcludedFromFileScans(t *testing.T) {
+	base := t.TempDir()
+	old := time.Now().AddDate(0, 0, -30)
+	files := []string{
+		"sales_20260901.sql.gz",
+		"db01/hr_20260901.sql.gz.enc",
+		"binlogs/db01_3306/mysql-bin.000001.gz",
+		"binlogs/db01_3306/index.json",
+	}
+	for _, name := range files {
+		path := filepath.Join(base, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	svc := &Service{Logger: log.MockLogger(), Storage: storage.NewLocal(), BackupOptions: &structs.BackupOptions{OutputDirectory: base}}
+	for _, pattern := range []string{"", "**/*.gz"} {
+		found, err := svc.scanFiles(base, time.Now(), pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, file := range found {
+			if strings.Contains(file.Path, "binlogs") {
+				t.Errorf("pattern %q: arsip binlog ikut dipindai: %s", pattern, file.Path)
+			}
+		}
+		if len(found) == 0 {
+			t.Errorf("pattern %q: file backup tidak ditemukan", pattern)
+		}
+	}
+
+	for rel, want := range map[string]bool{
+		"binlogs":                      true,
+		"binlogs/db01_3306/index.json": true,
+		"binlogs2/x.sql":               false,
+		"db01/binlogs/x.sql":           false,
+		"sales.sql.gz":                 false,
+	} {
+		if got := inBinlogArchive(rel); got != want {
+			t.Errorf("inBinlogArchive(%q) = %v, ingin %v", rel, got, want)
+		}
+	}
+}
+
+func TestPruneBinlogArchivesUpdatesIndex(t *testing.T) {
+	base := t.TempDir()
+	archiveDir := binlog.ArchiveDir(base, "db01", 3306)
+	if err := os.MkdirAll(archiveDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	index := &binlog.ArchiveIndex{Server: "db01:3306"}
+	for i, gtid := range []string{"0-1-0", "0-1-100", "0-1-200", "0-1-300"} {
+		entry := binlog.ArchiveEntry{
+			Name:         fmt.Sprintf("mysql-bin.%06d", i+1),
+			ArchiveFile:  fmt.Sprintf("mysql-bin.%06d.gz", i+1),
+			ArchivedSize: 1,
+			StartGTID:    gtid,
+		}
+		if err := os.WriteFile(filepath.Join(archiveDir, entry.ArchiveFile), []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		index.Entries = append(index.Entries, entry)
+	}
+	if err := binlog.SaveIndex(archiveDir, index); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &appconfig.Config{}
+	cfg.Backup.Output.BaseDirectory = base
+	svc := &Service{Logger: log.MockLogger(), Config: cfg, Storage: storage.NewLocal(), BackupOptions: &structs.BackupOptions{OutputDirectory: base}}
+
+	kept := testSet("s1", "2026-10-15 01:00", "sales")
+	kept.Summary.GTIDPosition = "0-1-250"
+	kept.Summary.ServerInfo = ServerConnectionInfo{Host: "db01", Port: 3306}
+	kept.keep("harian 2026-10-15")
+	deleted := testSet("s0", "2026-10-01 01:00", "sales")
+	deleted.Summary.GTIDPosition = "0-1-50"
+	deleted.Summary.ServerInfo = ServerConnectionInfo{Host: "db01", Port: 3306}
+
+	plans := svc.planBinlogRetention([]*retentionSet{kept, deleted})
+	if len(plans) != 1 || len(plans[0].Entries) != 2 || plans[0].KeepFrom != "mysql-bin.000003" {
+		t.Fatalf("rencana retensi binlog = %+v, ingin hapus 2 arsip sebelum mysql-bin.000003", plans)
+	}
+	svc.pruneBinlogArchives(plans)
+
+	for name, want := range map[string]bool{
+		"mysql-bin.000001.gz": false,
+		"mysql-bin.000002.gz": false,
+		"mysql-bin.000003.gz": true,
+		"mysql-bin.000004.gz": true,
+	} {
+		_, err := os.Stat(filepath.Join(archiveDir, name))
+		if exists := err == nil; exists != want {
+			t.Errorf("%s ada = %v, ingin %v", name, exists, want)
+		}
+	}
+
+	updated, err := binlog.LoadIndex(archiveDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range updated.Entries {
+		names = append(names, entry.Name)
+	}
+	if strings.Join(names, ",") != "mysql-bin.000003,mysql-bin.000004" {
+		t.Errorf("entry index setelah retensi = %v", names)
+	}
+}
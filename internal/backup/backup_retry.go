@@ -0,0 +1,294 @@
+// File : internal/backup/backup_retry.go
+// Deskripsi : Backup ulang database yang gagal dari summary sebelumnya dan retry otomatis untuk kegagalan sementara
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sfDBTools/internal/structs"
+	"sfDBTools/pkg/database"
+	"sfDBTools/pkg/ui"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/go-sql-driver/mysql"
+)
+
+// maxRetryBackoff adalah batas atas jeda antar percobaan ulang
+const maxRetryBackoff = 5 * time.Minute
+
+// transientErrorPatterns adalah potongan pesan error mysqldump/MariaDB yang menandakan kegagalan sementara
+var transientErrorPatterns = []string{
+	"lost connection",                 // 2013
+	"server has gone away",            // 2006
+	"can't connect",                   // 2002/2003
+	"too many connections",            // 1040
+	"deadlock found",                  // 1213
+	"lock wait timeout",               // 1205
+	"query execution was interrupted", // max_statement_time / KILL QUERY
+	"connection refused",
+	"connection reset",
+	"invalid connection", // go-sql-driver/mysql (dumper native)
+	"bad connection",
+}
+
+// transientMySQLErrors adalah nomor error server yang bersifat sementara (dipakai oleh dumper native)
+var transientMySQLErrors = map[uint16]bool{
+	1040: true, // ER_CON_COUNT_ERROR
+	1205: true, // ER_LOCK_WAIT_TIMEOUT
+	1213: true, // ER_LOCK_DEADLOCK
+	1317: true, // ER_QUERY_INTERRUPTED
+	2006: true, // CR_SERVER_GONE_ERROR
+	2013: true, // CR_SERVER_LOST
+}
+
+// isTransientBackupError mengembalikan true jika error kemungkinan besar berhasil bila dump diulang
+func isTransientBackupError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && transientMySQLErrors[mysqlErr.Number] {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, pattern := range transientErrorPatterns {
+		if strings.Contains(msg, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryTransient menjalankan fn dan mengulanginya dengan exponential backoff selama error bersifat sementara.
+// Mengembalikan jumlah percobaan yang dilakukan dan error terakhir.
+func (s *Service) retryTransient(ctx context.Context, label string, fn func() error) (int, error) {
+	maxAttempts := s.BackupOptions.Retry.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	backoff := time.Duration(s.BackupOptions.Retry.BackoffSeconds) * time.Second
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			if attempt > 1 {
+				s.Logger.Infof("%s berhasil pada percobaan ke-%d", label, attempt)
+			}
+			return attempt, nil
+		}
+		if attempt >= maxAttempts || !isTransientBackupError(err) {
+			return attempt, err
+		}
+
+		s.Logger.Warnf("%s gagal (percobaan %d/%d): %v. Mencoba ulang dalam %s...", label, attempt, maxAttempts, err, backoff)
+		select {
+		case <-ctx.Done():
+			return attempt, err
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+}
+
+// ExecuteRetry membaca summary backup, mem-backup ulang database yang gagal ke direktori output yang sama,
+// lalu memperbarui summary (status, statistik, file output, manifest) di tempat.
+func (s *Service) ExecuteRetry(ctx context.Context, retryFlags *structs.BackupRetryFlags) error {
+	ui.Headers("Retry Backup Database Gagal")
+
+	if retryFlags.BackupID == "" {
+		return fmt.Errorf("--backup-id wajib diisi")
+	}
+
+	release, err := s.AcquireBackupLock(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	summary, err := s.loadSummaryByID(retryFlags.BackupID)
+	if err != nil {
+		return err
+	}
+	if summary.BackupMode != "separate" {
+		return fmt.Errorf("backup %s menggunakan mode %s; satu file gabungan tidak dapat di-backup ulang sebagian, jalankan ulang backup", summary.BackupID, summary.BackupMode)
+	}
+	if len(summary.FailedDatabases) == 0 {
+		ui.PrintSuccess(fmt.Sprintf("Tidak ada database gagal pada backup %s (status: %s)", summary.BackupID, summary.Status))
+		return nil
+	}
+
+	failedNames := make([]string, 0, len(summary.FailedDatabases))
+	for _, failed := range summary.FailedDatabases {
+		failedNames = append(failedNames, failed.DatabaseName)
+	}
+	ui.PrintInfo(fmt.Sprintf("Backup %s: %d database gagal akan di-backup ulang: %s", summary.BackupID, len(failedNames), strings.Join(failedNames, ", ")))
+
+	// Gunakan konfigurasi backup asli agar file baru konsisten dengan backup set
+	s.applySummaryConfig(summary)
+	store, err := s.getStorage()
+	if err != nil {
+		return err
+	}
+	if err := store.MkdirAll(s.BackupOptions.OutputDirectory); err != nil {
+		return fmt.Errorf("gagal membuat direktori output %s: %w", s.BackupOptions.OutputDirectory, err)
+	}
+
+	if err := s.CheckAndSelectConfigFile(); err != nil {
+		return err
+	}
+	conn := s.DBConfigInfo.ServerDBConnection
+	if summary.ServerInfo.Host != "" && (conn.Host != summary.ServerInfo.Host || conn.Port != summary.ServerInfo.Port) {
+		return fmt.Errorf("server %s:%d berbeda dengan server backup asli %s:%d", conn.Host, conn.Port, summary.ServerInfo.Host, summary.ServerInfo.Port)
+	}
+
+	s.Client, err = database.InitializeDatabase(conn)
+	if err != nil {
+		return err
+	}
+	defer s.Client.Close()
+
+	originalMaxStatementsTime, err := s.AturMaxStatementsTime(ctx, s.Client)
+	if err != nil {
+		return err
+	}
+	defer s.KembalikanMaxStatementsTime(ctx, originalMaxStatementsTime)
+
+	if err := s.CaptureGTIDIfNeeded(ctx, s.Client); err != nil {
+		return err
+	}
+
+	// Database yang sudah tidak ada di server tetap tercatat gagal
+	var retryNames []string
+	var missing []FailedDatabaseInfo
+	for _, dbName := range failedNames {
+		exists, err := s.Client.DatabaseExists(ctx, dbName)
+		if err != nil {
+			return fmt.Errorf("gagal memeriksa database %s: %w", dbName, err)
+		}
+		if !exists {
+			s.Logger.Warnf("Database %s tidak ditemukan di server, dilewati", dbName)
+			missing = append(missing, FailedDatabaseInfo{DatabaseName: dbName, Error: "database tidak ditemukan di server saat retry"})
+			continue
+		}
+		retryNames = append(retryNames, dbName)
+	}
+
+	config, err := s.buildBackupConfig()
+	if err != nil {
+		return fmt.Errorf("gagal menyiapkan konfigurasi backup: %w", err)
+	}
+	if s.hasTableFilters() && len(retryNames) > 0 {
+		s.TableFilters, err = s.resolveTableFilters(ctx, retryNames)
+		if err != nil {
+			return fmt.Errorf("gagal memproses filter tabel: %w", err)
+		}
+	}
+	s.DatabaseDetail = summary.DatabaseDetails
+
+	startTime := time.Now()
+	var result backupResult
+	if len(retryNames) > 0 {
+		ui.PrintSubHeader("Memulai Proses Backup Ulang")
+		result = s.executeBackupSeparate(ctx, config, retryNames, map[string]uint64{})
+	}
+	result.failed = append(result.failed, missing...)
+
+	s.mergeRetryResult(summary, failedNames, result, startTime)
+
+	if len(summary.OutputInfo.Files) > 0 {
+		manifestPath, err := s.SaveManifest(summary)
+		if err != nil {
+			s.Logger.Errorf("Gagal menyimpan manifest backup: %v", err)
+		} else {
+			summary.ManifestFile = manifestPath
+		}
+	}
+	if err := s.SaveSummaryToJSON(summary); err != nil {
+		s.Logger.Errorf("Gagal menyimpan summary ke JSON: %v", err)
+	}
+	s.DisplaySummaryTable(summary)
+	s.recordCatalog(ctx, summary)
+	s.notifyBackupResult(ctx, summary)
+	s.writeBackupMetrics()
+	s.setResultDetails(summary)
+
+	if len(result.failed) > 0 {
+		var stillFailed []string
+		for _, failed := range result.failed {
+			stillFailed = append(stillFailed, failed.DatabaseName)
+		}
+		return backupOutcomeError(summary, fmt.Errorf("beberapa database masih gagal di-backup: %v", stillFailed))
+	}
+
+	ui.PrintSuccess(fmt.Sprintf("Semua database gagal pada backup %s berhasil di-backup ulang", summary.BackupID))
+	return nil
+}
+
+// applySummaryConfig menerapkan konfigurasi backup yang tercatat pada summary ke BackupOptions
+func (s *Service) applySummaryConfig(summary *BackupSummary) {
+	cfg := summary.BackupConfig
+	opts := s.BackupOptions
+
+	opts.OutputDirectory = summary.OutputInfo.OutputDirectory
+	opts.Compression = structs.CompressionOptions{
+		Enabled: cfg.CompressionEnabled,
+		Type:    cfg.CompressionType,
+		Level:   cfg.CompressionLevel,
+	}
+	opts.Encryption.Enabled = cfg.EncryptionEnabled
+	opts.Verification = structs.VerificationOptions{
+		VerifyAfterWrite: cfg.VerifyAfterWrite,
+		CompareChecksums: cfg.CompareChecksums,
+	}
+	opts.IncludeTables = cfg.IncludeTables
+	opts.Exclude.Tables = cfg.ExcludeTables
+	opts.Exclude.DataTables = cfg.ExcludeTableData
+	opts.Exclude.Data = cfg.ExcludeData
+	opts.CaptureGtid = cfg.CaptureGtid
+	opts.Coordinates = cfg.CoordinatesSource
+	if cfg.Dumper != "" {
+		opts.Dumper = cfg.Dumper
+	}
+}
+
+// mergeRetryResult menggabungkan hasil backup ulang ke summary asli dan menghitung ulang status serta statistik
+func (s *Service) mergeRetryResult(summary *BackupSummary, retried []string, result backupResult, startTime time.Time) {
+	summary.SuccessfulDatabases = append(summary.SuccessfulDatabases, result.successful...)
+	summary.FailedDatabases = result.failed
+	summary.Status = determineBackupStatus(len(summary.SuccessfulDatabases), len(summary.FailedDatabases))
+	summary.Errors = append(summary.Errors, result.errors...)
+
+	stats := s.buildDatabaseStats(nil, summary.SuccessfulDatabases, summary.FailedDatabases)
+	summary.DatabaseStats.SuccessfulBackups = stats.SuccessfulBackups
+	summary.DatabaseStats.SuccessWithWarnings = stats.SuccessWithWarnings
+	summary.DatabaseStats.FailedBackups = stats.FailedBackups
+
+	// File lama dipertahankan apa adanya; hanya file hasil backup ulang yang ditambahkan
+	added := s.collectOutputInfo(result.successful)
+	summary.OutputInfo.Files = append(summary.OutputInfo.Files, added.Files...)
+	summary.OutputInfo.TotalFiles = len(summary.OutputInfo.Files)
+	summary.OutputInfo.TotalSize += added.TotalSize
+	summary.OutputInfo.TotalSizeHuman = humanize.Bytes(uint64(summary.OutputInfo.TotalSize))
+
+	attempt := RetryAttempt{
+		Time:      startTime,
+		Databases: retried,
+		Duration:  ui.FormatDuration(time.Since(startTime)),
+	}
+	for _, db := range result.successful {
+		attempt.Succeeded = append(attempt.Succeeded, db.DatabaseName)
+	}
+	for _, failed := range result.failed {
+		attempt.Failed = append(attempt.Failed, failed.DatabaseName)
+	}
+	summary.RetryHistory = append(summary.RetryHistory, attempt)
+}
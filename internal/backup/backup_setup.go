@@ -2,15 +2,20 @@
 // Deskripsi : Fungsi terpadu untuk setup sesi backup dan validasi output directory.
 // Author : Hadiyatna Muflihun
 // Tanggal : 2024-10-15
-// Last Modified : 2024-10-15
+// Last Modified : 16 Oktober 2026
 
 package backup
 
 import (
 	"context"
+	"fmt"
+	"path/filepath"
 	"sfDBTools/pkg/database"
+	"sfDBTools/pkg/encrypt"
 	"sfDBTools/pkg/fs"
+	"sfDBTools/pkg/storage"
 	"sfDBTools/pkg/ui"
+	"time"
 )
 
 // PrepareBackupSession menangani setup awal yang sama untuk semua jenis backup
@@ -59,11 +64,15 @@ func (s *Service) PrepareBackupSession(ctx context.Context, headerTitle string,
 func (s *Service) SetupBackupExecution() (BackupConfig, error) {
 	ui.PrintSubHeader("Persiapan Eksekusi Backup")
 
-	// 1. Jalankan cleanup backup lama terlebih dahulu untuk membebaskan ruang disk
-	s.Logger.Info("Menjalankan cleanup backup lama sebelum backup...")
-	if err := s.CleanupOldBackups(); err != nil {
-		s.Logger.Errorf("Cleanup backup lama gagal: %v", err)
-		// Lanjutkan backup meskipun cleanup gagal
+	// 1. Jalankan cleanup backup lama terlebih dahulu untuk membebaskan ruang disk (mengikuti cleanup_schedule)
+	if due, reason := s.cleanupDue(time.Now()); !due {
+		s.Logger.Infof("Cleanup sebelum backup dilewati: %s", reason)
+	} else {
+		s.Logger.Info("Menjalankan cleanup backup lama sebelum backup...")
+		if err := s.CleanupOldBackups(); err != nil {
+			s.Logger.Errorf("Cleanup backup lama gagal: %v", err)
+			// Lanjutkan backup meskipun cleanup gagal
+		}
 	}
 
 	// 2. Pastikan output directory sudah ada dengan memanggil ValidateOutput
@@ -71,6 +80,11 @@ func (s *Service) SetupBackupExecution() (BackupConfig, error) {
 		return BackupConfig{}, err
 	}
 
+	return s.buildBackupConfig()
+}
+
+// buildBackupConfig menyusun BackupConfig dari BackupOptions dan me-resolve kunci enkripsi
+func (s *Service) buildBackupConfig() (BackupConfig, error) {
 	// 3. Setup konfigurasi backup
 	config := BackupConfig{
 		BaseDumpArgs:        s.Config.Backup.MysqlDumpArgs,
@@ -78,11 +92,32 @@ func (s *Service) SetupBackupExecution() (BackupConfig, error) {
 		CompressionType:     s.BackupOptions.Compression.Type,
 		CompressionRequired: s.BackupOptions.Compression.Enabled,
 		EncryptionEnabled:   s.BackupOptions.Encryption.Enabled,
+		VerifyAfterWrite:    s.BackupOptions.Verification.VerifyAfterWrite,
+		CompareChecksums:    s.BackupOptions.Verification.CompareChecksums,
+	}
+
+	dumpImpl, err := s.newDumper()
+	if err != nil {
+		return BackupConfig{}, err
+	}
+	config.Dumper = dumpImpl
+	if dumpImpl.Name() == dumperNative {
+		s.Logger.Info("Dumper native Go digunakan (mysqldump_args tidak dipakai)")
+	}
+
+	// 4. Resolve kunci enkripsi sekali di awal agar penulisan dan verifikasi memakai kunci yang sama
+	if config.EncryptionEnabled && s.BackupOptions.Encryption.Key == "" {
+		resolvedKey, source, err := encrypt.ResolveEncryptionKey("")
+		if err != nil {
+			return BackupConfig{}, fmt.Errorf("gagal mendapatkan kunci enkripsi: %w", err)
+		}
+		s.BackupOptions.Encryption.Key = resolvedKey
+		s.Logger.Infof("Kunci enkripsi diperoleh dari: %s", source)
 	}
 
 	// Log konfigurasi
 	if config.EncryptionEnabled {
-		s.Logger.Info("Enkripsi AES-256-GCM diaktifkan untuk backup (kompatibel dengan OpenSSL)")
+		s.Logger.Info("Enkripsi AES-256-GCM diaktifkan untuk backup (format stream per chunk)")
 	} else {
 		s.Logger.Info("Enkripsi tidak diaktifkan, melewati langkah kunci enkripsi...")
 	}
@@ -99,11 +134,45 @@ func (s *Service) SetupBackupExecution() (BackupConfig, error) {
 		s.Logger.Info("Data database akan disertakan dalam backup.")
 	}
 
+	limiter, err := s.buildBandwidthLimiter()
+	if err != nil {
+		return BackupConfig{}, err
+	}
+	s.BandwidthLimiter = limiter
+	if limiter != nil {
+		s.Logger.Infof("Kecepatan tulis file backup dibatasi %s/s (total semua worker)", s.BackupOptions.Concurrency.MaxBandwidth)
+	}
+
+	if config.VerifyAfterWrite || config.CompareChecksums {
+		s.Logger.Infof("Verifikasi file setelah ditulis diaktifkan (trailer: %t, bandingkan checksum: %t)", config.VerifyAfterWrite, config.CompareChecksums)
+	}
+
 	return config, nil
 }
 
 // ValidateOutput membuat direktori output jika belum ada
 func (s *Service) ValidateOutput() error {
+	store, err := s.getStorage()
+	if err != nil {
+		return err
+	}
+
+	// Remote storage: path output dibangun tanpa menyentuh disk lokal, direktori dibuat di storage
+	if store.Type() != storage.TypeLocal {
+		outputDir := s.BackupOptions.OutputDirectory
+		if s.Config.Backup.Output.Structure.CreateSubdirs {
+			subdir, err := fs.BuildSubdirPath(s.Config.Backup.Output.Structure.Pattern, s.Config.General.ClientCode)
+			if err != nil {
+				return fmt.Errorf("gagal membangun path subdirektori: %w", err)
+			}
+			outputDir = filepath.Join(outputDir, subdir)
+		}
+		if err := store.MkdirAll(outputDir); err != nil {
+			return fmt.Errorf("gagal membuat direktori output %s pada storage %s: %w", outputDir, store.Type(), err)
+		}
+		s.BackupOptions.OutputDirectory = outputDir
+		return nil
+	}
 
 	// Membuat direktori output jika belum ada
 	OutputDir, err := fs.CreateOutputDirs(s.BackupOptions.OutputDirectory, s.Config.Backup.Output.Structure.CreateSubdirs, s.Config.Backup.Output.Structure.Pattern, s.Config.General.ClientCode)
@@ -0,0 +1,108 @@
+// File : internal/backup/backup_storage.go
+// Deskripsi : Akses storage tujuan backup (local, S3, SFTP) untuk Service
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package backup
+
+import (
+	"fmt"
+	"io"
+	"sfDBTools/pkg/encrypt"
+	"sfDBTools/pkg/storage"
+)
+
+// getStorage mengembalikan storage aktif; dibuka dari backup.storage saat pertama kali dipakai
+func (s *Service) getStorage() (storage.Storage, error) {
+	s.storageMu.Lock()
+	defer s.storageMu.Unlock()
+
+	if s.Storage != nil {
+		return s.Storage, nil
+	}
+	if s.Config == nil {
+		s.Storage = storage.NewLocal()
+		return s.Storage, nil
+	}
+
+	st, err := storage.New(s.Config.Backup.Storage)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuka storage backup: %w", err)
+	}
+	if st.Type() != storage.TypeLocal {
+		s.Logger.Infof("Menggunakan storage %s untuk file backup", st.Type())
+	}
+	s.Storage = st
+	return st, nil
+}
+
+// isRemoteStorage mengembalikan true jika file backup disimpan di luar filesystem lokal
+func (s *Service) isRemoteStorage() bool {
+	st, err := s.getStorage()
+	return err == nil && st.Type() != storage.TypeLocal
+}
+
+// CloseStorage menutup koneksi storage jika sudah dibuka
+func (s *Service) CloseStorage() {
+	s.storageMu.Lock()
+	defer s.storageMu.Unlock()
+
+	if s.Storage == nil {
+		return
+	}
+	if err := s.Storage.Close(); err != nil {
+		s.Logger.Warnf("Gagal menutup storage backup: %v", err)
+	}
+	s.Storage = nil
+}
+
+// outputFileSize mengembalikan ukuran file backup pada storage, 0 jika tidak dapat dibaca
+func (s *Service) outputFileSize(path string) int64 {
+	st, err := s.getStorage()
+	if err != nil {
+		return 0
+	}
+	info, err := st.Stat(path)
+	if err != nil {
+		s.Logger.Debugf("Gagal membaca ukuran file %s: %v", path, err)
+		return 0
+	}
+	return info.Size
+}
+
+// isEncryptedBackupFile memeriksa header file pada storage untuk mengetahui apakah file terenkripsi
+func (s *Service) isEncryptedBackupFile(path string) (bool, error) {
+	st, err := s.getStorage()
+	if err != nil {
+		return false, err
+	}
+	file, err := st.Open(path)
+	if err != nil {
+		return false, fmt.Errorf("gagal membuka file: %w", err)
+	}
+	defer file.Close()
+
+	header := make([]byte, encrypt.EncryptedHeaderSize)
+	n, err := io.ReadFull(file, header)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return false, fmt.Errorf("gagal membaca header file: %w", err)
+	}
+	return encrypt.IsEncryptedHeader(header[:n]), nil
+}
+
+// storageDescription menampilkan jenis storage beserta tujuannya untuk tabel opsi backup
+func (s *Service) storageDescription() string {
+	if s.Config == nil {
+		return storage.TypeLocal
+	}
+	cfg := s.Config.Backup.Storage
+	switch cfg.Type {
+	case storage.TypeS3:
+		return fmt.Sprintf("s3://%s/%s (%s)", cfg.S3.Bucket, cfg.S3.Prefix, cfg.S3.Endpoint)
+	case storage.TypeSFTP:
+		return fmt.Sprintf("sftp://%s@%s", cfg.SFTP.User, cfg.SFTP.Host)
+	default:
+		return storage.TypeLocal
+	}
+}
@@ -3,6 +3,7 @@ package backup
 import (
 	"errors"
 	"sfDBTools/internal/structs"
+	"sfDBTools/pkg/common"
 	"sfDBTools/pkg/database"
 	"time"
 )
@@ -28,8 +29,8 @@ var (
 	// ErrGTIDPermissionDenied dikembalikan bila user tidak punya izin membaca GTID variables
 	ErrGTIDPermissionDenied = errors.New("permission denied reading gtid variables")
 
-	// ErrUserCancelled adalah sentinel error untuk menandai pembatalan oleh pengguna.
-	ErrUserCancelled = errors.New("user_cancelled")
+	// ErrUserCancelled adalah sentinel error untuk menandai pembatalan oleh pengguna (sama dengan common.ErrUserCancelled).
+	ErrUserCancelled = common.ErrUserCancelled
 
 	// ErrNoDatabasesToBackup dikembalikan bila tidak ada database untuk di-backup setelah filtering
 	ErrNoDatabasesToBackup = errors.New("tidak ada database untuk di-backup setelah filtering")
@@ -51,7 +52,6 @@ type BackupEntryConfig struct {
 	HeaderTitle string
 	ShowOptions bool
 	BackupMode  string // "separate" atau "combined"
-	EnableGTID  bool   // apakah perlu capture GTID
 	SuccessMsg  string
 	LogPrefix   string
 }
@@ -65,9 +65,10 @@ type backupResult struct {
 
 // jobResult adalah struct untuk mengirim hasil dari worker melalui channel.
 type jobResult struct {
-	info   DatabaseBackupInfo
-	err    error
-	dbName string // Diperlukan untuk logging error
+	info     DatabaseBackupInfo
+	err      error
+	dbName   string // Diperlukan untuk logging error
+	attempts int    // Jumlah percobaan dump
 }
 
 // BackupSummary adalah struktur untuk menyimpan summary backup
@@ -75,17 +76,29 @@ type BackupSummary struct {
 	// Informasi umum backup
 	BackupID   string    `json:"backup_id"`
 	Timestamp  time.Time `json:"timestamp"`
-	BackupMode string    `json:"backup_mode"` // "separate" atau "combined"
+	BackupMode string    `json:"backup_mode"` // "separate", "combined", atau "physical"
 	Status     string    `json:"status"`      // "success", "partial", "failed"
 	Duration   string    `json:"duration"`
 	StartTime  time.Time `json:"start_time"`
 	EndTime    time.Time `json:"end_time"`
 
+	// Posisi GTID titik awal replay binlog untuk point-in-time restore: koordinat dump pada mode combined,
+	// atau posisi sebelum dump pertama pada mode separate (koordinat per database ada di successful_databases)
+	GTIDPosition string                  `json:"gtid_position,omitempty"`
+	Coordinates  *ReplicationCoordinates `json:"coordinates,omitempty"` // Koordinat replikasi file backup (mode combined)
+
 	// Informasi database
 	DatabaseStats DatabaseSummaryStats `json:"database_stats"`
 
 	// Informasi file output
-	OutputInfo OutputSummaryInfo `json:"output_info"`
+	OutputInfo   OutputSummaryInfo `json:"output_info"`
+	ManifestFile string            `json:"manifest_file,omitempty"` // Path file MANIFEST checksum backup set
+
+	// File export akun user dan grant (tidak ada jika exclude.user aktif)
+	UsersExport *UsersExportInfo `json:"users_export,omitempty"`
+
+	// Informasi backup fisik mariabackup (hanya mode physical)
+	Physical *PhysicalBackupInfo `json:"physical,omitempty"`
 
 	// Konfigurasi backup
 	BackupConfig BackupConfigSummary `json:"backup_config"`
@@ -102,6 +115,41 @@ type BackupSummary struct {
 
 	// Informasi error (jika ada)
 	Errors []string `json:"errors,omitempty"`
+
+	// Riwayat 'backup retry' untuk database yang gagal pada backup ini
+	RetryHistory []RetryAttempt `json:"retry_history,omitempty"`
+}
+
+// RetryAttempt mencatat satu kali eksekusi 'backup retry' terhadap summary
+type RetryAttempt struct {
+	Time      time.Time `json:"time"`
+	Databases []string  `json:"databases"` // Database gagal yang di-backup ulang
+	Succeeded []string  `json:"succeeded,omitempty"`
+	Failed    []string  `json:"failed,omitempty"`
+	Duration  string    `json:"duration"`
+}
+
+// UsersExportInfo berisi informasi file export CREATE USER / GRANT pada backup set
+type UsersExportInfo struct {
+	OutputFile      string                  `json:"output_file"`
+	FileSize        int64                   `json:"file_size_bytes"`
+	FileSizeHuman   string                  `json:"file_size_human"`
+	AccountCount    int                     `json:"account_count"`              // Jumlah akun yang di-export
+	SkippedAccounts []string                `json:"skipped_accounts,omitempty"` // Akun sistem dan system_users.users yang dilewati
+	Warnings        string                  `json:"warnings,omitempty"`         // Akun yang gagal dibaca (SHOW CREATE USER / SHOW GRANTS)
+	Duration        string                  `json:"duration"`
+	Verification    *BackupVerificationInfo `json:"verification,omitempty"`
+}
+
+// PhysicalBackupInfo berisi informasi backup fisik (mariabackup --stream=xbstream)
+type PhysicalBackupInfo struct {
+	BackupType   string `json:"backup_type"`              // "full" atau "incremental"
+	FromLSN      string `json:"from_lsn,omitempty"`       // LSN awal (basis incremental, "0" untuk full)
+	ToLSN        string `json:"to_lsn,omitempty"`         // LSN akhir; basis untuk backup incremental berikutnya
+	BaseBackupID string `json:"base_backup_id,omitempty"` // Backup ID fisik yang menjadi basis incremental
+	DataDir      string `json:"data_dir,omitempty"`       // mariadb.data_dir yang di-backup
+	Prepared     bool   `json:"prepared"`                 // mariabackup --prepare berhasil dijalankan
+	PrepareDir   string `json:"prepare_dir,omitempty"`    // Direktori salinan yang sudah di-prepare
 }
 
 // DatabaseSummaryStats berisi statistik database
@@ -126,13 +174,22 @@ type OutputSummaryInfo struct {
 
 // BackupConfigSummary berisi ringkasan konfigurasi backup
 type BackupConfigSummary struct {
-	CompressionEnabled bool   `json:"compression_enabled"`
-	CompressionType    string `json:"compression_type,omitempty"`
-	CompressionLevel   string `json:"compression_level,omitempty"`
-	EncryptionEnabled  bool   `json:"encryption_enabled"`
-	DBListFile         string `json:"db_list_file,omitempty"`
-	CleanupEnabled     bool   `json:"cleanup_enabled"`
-	RetentionDays      int    `json:"retention_days,omitempty"`
+	CompressionEnabled bool     `json:"compression_enabled"`
+	CompressionType    string   `json:"compression_type,omitempty"`
+	CompressionLevel   string   `json:"compression_level,omitempty"`
+	EncryptionEnabled  bool     `json:"encryption_enabled"`
+	DBListFile         string   `json:"db_list_file,omitempty"`
+	CleanupEnabled     bool     `json:"cleanup_enabled"`
+	RetentionDays      int      `json:"retention_days,omitempty"`
+	VerifyAfterWrite   bool     `json:"verify_after_write"`
+	CompareChecksums   bool     `json:"compare_checksums"`
+	ExcludeTables      []string `json:"exclude_tables,omitempty"`     // Pola tabel yang dikecualikan
+	IncludeTables      []string `json:"include_tables,omitempty"`     // Pola tabel yang disertakan
+	ExcludeTableData   []string `json:"exclude_table_data,omitempty"` // Pola tabel yang hanya di-backup strukturnya
+	ExcludeData        bool     `json:"exclude_data,omitempty"`       // Hanya struktur database yang di-backup
+	CaptureGtid        bool     `json:"capture_gtid"`
+	CoordinatesSource  string   `json:"coordinates_source,omitempty"` // "master" (--master-data) atau "slave" (--dump-slave)
+	Dumper             string   `json:"dumper,omitempty"`             // "mysqldump" atau "native"
 }
 
 // DatabaseBackupInfo berisi informasi database yang berhasil dibackup
@@ -152,12 +209,42 @@ type DatabaseBackupInfo struct {
 	Status              string                       `json:"status"`                   // "success", "success_with_warnings", "failed"
 	Warnings            string                       `json:"warnings,omitempty"`       // Warning/error messages dari mysqldump
 	ErrorLogFile        string                       `json:"error_log_file,omitempty"` // Path ke file log error
+	Verification        *BackupVerificationInfo      `json:"verification,omitempty"`   // Hasil verifikasi file setelah ditulis
+	Coordinates         *ReplicationCoordinates      `json:"coordinates,omitempty"`    // Koordinat replikasi yang konsisten dengan dump
+	Attempts            int                          `json:"attempts,omitempty"`       // Jumlah percobaan dump sampai berhasil
+}
+
+// ReplicationCoordinates berisi posisi binlog dan GTID yang diurai dari header dump (--master-data/--dump-slave)
+type ReplicationCoordinates struct {
+	Source         string `json:"source"`                    // "master" atau "slave"
+	BinlogFile     string `json:"binlog_file,omitempty"`     // MASTER_LOG_FILE
+	BinlogPosition int64  `json:"binlog_position,omitempty"` // MASTER_LOG_POS
+	GTIDPosition   string `json:"gtid_position,omitempty"`   // gtid_slave_pos (MariaDB) atau GTID_PURGED (MySQL)
+}
+
+// BackupVerificationInfo berisi hasil verifikasi file backup setelah ditulis
+type BackupVerificationInfo struct {
+	Verified         bool   `json:"verified"`
+	TrailerFound     bool   `json:"trailer_found"`         // Trailer "-- Dump completed" ditemukan di akhir SQL
+	FileSHA256       string `json:"file_sha256,omitempty"` // SHA-256 byte file yang tersimpan (setelah kompresi/enkripsi)
+	SQLSHA256        string `json:"sql_sha256,omitempty"`  // SHA-256 plaintext SQL hasil dekode
+	SQLSize          int64  `json:"sql_size_bytes"`        // Ukuran plaintext SQL hasil dekode
+	ChecksumCompared bool   `json:"checksum_compared"`     // Checksum dibandingkan dengan checksum saat penulisan
+	Duration         string `json:"duration"`
+	Error            string `json:"error,omitempty"`
+}
+
+// dumpChecksums menyimpan checksum yang dihitung selama file backup ditulis
+type dumpChecksums struct {
+	FileSHA256 string
+	SQLSHA256  string
 }
 
 // FailedDatabaseInfo berisi informasi database yang gagal dibackup
 type FailedDatabaseInfo struct {
 	DatabaseName string `json:"database_name"`
 	Error        string `json:"error"`
+	Attempts     int    `json:"attempts,omitempty"` // Jumlah percobaan dump sebelum dinyatakan gagal
 }
 
 // SummaryFileInfo berisi informasi file backup untuk summary (berbeda dari BackupFileInfo di cleanup)
@@ -168,6 +255,8 @@ type SummaryFileInfo struct {
 	SizeHuman    string    `json:"size_human"`
 	DatabaseName string    `json:"database_name,omitempty"`
 	CreatedAt    time.Time `json:"created_at"`
+	SHA256       string    `json:"sha256,omitempty"`     // SHA-256 file tersimpan (jika verifikasi aktif)
+	SQLSHA256    string    `json:"sql_sha256,omitempty"` // SHA-256 plaintext SQL (jika verifikasi aktif)
 }
 
 // ServerConnectionInfo berisi informasi koneksi server (tanpa password)
@@ -194,4 +283,16 @@ type BackupConfig struct {
 	CompressionType     string
 	CompressionRequired bool
 	EncryptionEnabled   bool
+	VerifyAfterWrite    bool   // Buka ulang file dan periksa trailer dump setelah ditulis
+	CompareChecksums    bool   // Bandingkan checksum saat penulisan dengan hasil baca ulang
+	NoDumpTrailer       bool   // Output bukan dump SQL (misal xbstream) sehingga tidak ada trailer dump
+	Dumper              dumper // Implementasi dump logis (mysqldump atau native)
+}
+
+// restoreTarget menyimpan informasi satu file backup yang akan di-restore.
+type restoreTarget struct {
+	FilePath  string
+	Databases []string // Database yang terdapat dalam file (kosong jika tidak diketahui)
+	Encrypted bool
+	Compress  string
 }
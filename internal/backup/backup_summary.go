@@ -9,8 +9,8 @@ package backup
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"path/filepath" // <-- Menggunakan package UI Anda
+	"sfDBTools/pkg/storage"
 	"sfDBTools/pkg/ui"
 	"time"
 
@@ -28,6 +28,7 @@ const (
 // Fungsi ini menggabungkan pembuatan summary dengan atau tanpa detail database untuk mengurangi duplikasi kode.
 // Jika detail database tidak tersedia, pass `nil` pada parameter `databaseDetails`.
 func (s *Service) CreateBackupSummary(
+	backupID string,
 	backupMode string,
 	dbFiltered []string,
 	successfulDBs []DatabaseBackupInfo,
@@ -37,24 +38,11 @@ func (s *Service) CreateBackupSummary(
 ) *BackupSummary {
 	endTime := time.Now()
 
-	// Tentukan status backup berdasarkan hasil
-	var status string
-	switch {
-	case len(failedDBs) == 0 && len(successfulDBs) > 0:
-		status = "success"
-	case len(failedDBs) > 0 && len(successfulDBs) > 0:
-		status = "partial"
-	case len(failedDBs) > 0 && len(successfulDBs) == 0:
-		status = "failed"
-	default:
-		status = "empty" // Tidak ada database yang diproses
-	}
-
 	summary := &BackupSummary{
-		BackupID:            fmt.Sprintf("backup_%s", startTime.Format(backupIDTimeFormat)),
+		BackupID:            backupID,
 		Timestamp:           startTime,
 		BackupMode:          backupMode,
-		Status:              status,
+		Status:              determineBackupStatus(len(successfulDBs), len(failedDBs)),
 		Duration:            ui.FormatDuration(endTime.Sub(startTime)),
 		StartTime:           startTime,
 		EndTime:             endTime,
@@ -73,10 +61,34 @@ func (s *Service) CreateBackupSummary(
 		},
 		Errors: errors,
 	}
+	if s.BackupInfo != nil {
+		summary.GTIDPosition = s.BackupInfo.GTIDCaptured
+	}
+	// Pada mode combined satu file memuat semua database sehingga koordinatnya berlaku untuk seluruh backup
+	if backupMode == "combined" && len(successfulDBs) > 0 && successfulDBs[0].Coordinates != nil {
+		summary.Coordinates = successfulDBs[0].Coordinates
+		if summary.Coordinates.GTIDPosition != "" {
+			summary.GTIDPosition = summary.Coordinates.GTIDPosition
+		}
+	}
 
 	return summary
 }
 
+// determineBackupStatus menentukan status backup berdasarkan jumlah database yang berhasil dan gagal
+func determineBackupStatus(successCount, failedCount int) string {
+	switch {
+	case failedCount == 0 && successCount > 0:
+		return "success"
+	case failedCount > 0 && successCount > 0:
+		return "partial"
+	case failedCount > 0 && successCount == 0:
+		return "failed"
+	default:
+		return "empty" // Tidak ada database yang diproses
+	}
+}
+
 // buildDatabaseStats membuat statistik database.
 func (s *Service) buildDatabaseStats(dbFiltered []string, successfulDBs []DatabaseBackupInfo, failedDBs []FailedDatabaseInfo) DatabaseSummaryStats {
 	// Hitung berapa yang success clean dan success with warnings
@@ -110,6 +122,14 @@ func (s *Service) buildBackupConfigSummary() BackupConfigSummary {
 		CompressionEnabled: s.BackupOptions.Compression.Enabled,
 		EncryptionEnabled:  s.BackupOptions.Encryption.Enabled,
 		CleanupEnabled:     s.BackupOptions.Cleanup.Enabled,
+		VerifyAfterWrite:   s.BackupOptions.Verification.VerifyAfterWrite,
+		CompareChecksums:   s.BackupOptions.Verification.CompareChecksums,
+		ExcludeTables:      s.BackupOptions.Exclude.Tables,
+		IncludeTables:      s.BackupOptions.IncludeTables,
+		ExcludeTableData:   s.BackupOptions.Exclude.DataTables,
+		ExcludeData:        s.BackupOptions.Exclude.Data,
+		CaptureGtid:        s.BackupOptions.CaptureGtid,
+		Dumper:             s.dumperName(),
 	}
 
 	if cfg.CompressionEnabled {
@@ -122,6 +142,9 @@ func (s *Service) buildBackupConfigSummary() BackupConfigSummary {
 	if cfg.CleanupEnabled {
 		cfg.RetentionDays = s.BackupOptions.Cleanup.RetentionDays
 	}
+	if cfg.CaptureGtid {
+		cfg.CoordinatesSource = s.BackupOptions.Coordinates
+	}
 	return cfg
 }
 
@@ -145,6 +168,10 @@ func (s *Service) collectOutputInfo(successfulDBs []DatabaseBackupInfo) OutputSu
 				DatabaseName: db.DatabaseName,
 				CreatedAt:    time.Now(),
 			}
+			if db.Verification != nil {
+				fileInfo.SHA256 = db.Verification.FileSHA256
+				fileInfo.SQLSHA256 = db.Verification.SQLSHA256
+			}
 			uniqueFiles[db.OutputFile] = fileInfo
 			totalSize += db.FileSize
 		}
@@ -164,11 +191,32 @@ func (s *Service) collectOutputInfo(successfulDBs []DatabaseBackupInfo) OutputSu
 	}
 }
 
+// uniqueBackupID membuat Backup ID dari waktu mulai backup. Karena resolusinya per detik, dua backup
+// yang dimulai pada detik yang sama diberi akhiran _2, _3, ... agar summary tidak saling menimpa.
+func (s *Service) uniqueBackupID(startTime time.Time) string {
+	base := fmt.Sprintf("backup_%s", startTime.Format(backupIDTimeFormat))
+	store, err := s.getStorage()
+	if err != nil {
+		return base
+	}
+	summaryDir := s.getSummaryDir()
+	backupID := base
+	for i := 2; ; i++ {
+		if _, err := store.Stat(filepath.Join(summaryDir, backupID+".json")); err != nil {
+			return backupID
+		}
+		backupID = fmt.Sprintf("%s_%d", base, i)
+	}
+}
+
 // SaveSummaryToJSON menyimpan summary ke file JSON.
 func (s *Service) SaveSummaryToJSON(summary *BackupSummary) error {
-	baseDirectory := s.Config.Backup.Output.BaseDirectory
-	summaryDir := filepath.Join(baseDirectory, "summaries")
-	if err := os.MkdirAll(summaryDir, 0755); err != nil {
+	store, err := s.getStorage()
+	if err != nil {
+		return err
+	}
+	summaryDir := s.getSummaryDir()
+	if err := store.MkdirAll(summaryDir); err != nil {
 		return fmt.Errorf("gagal membuat direktori summary: %w", err)
 	}
 
@@ -180,7 +228,7 @@ func (s *Service) SaveSummaryToJSON(summary *BackupSummary) error {
 		return fmt.Errorf("gagal marshal summary ke JSON: %w", err)
 	}
 
-	if err := os.WriteFile(summaryPath, jsonData, 0644); err != nil {
+	if err := storage.WriteFile(store, summaryPath, jsonData); err != nil {
 		return fmt.Errorf("gagal menulis file summary: %w", err)
 	}
 
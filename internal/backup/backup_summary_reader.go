@@ -9,8 +9,8 @@ package backup
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"path/filepath"
+	"sfDBTools/pkg/storage"
 	"sfDBTools/pkg/ui"
 	"sort"
 	"strings"
@@ -21,7 +21,7 @@ func (s *Service) ListAvailableSummaries() error {
 	summaryFiles, err := s.findAllSummaries()
 	if err != nil {
 		// Jika error karena direktori tidak ada, berikan pesan yang lebih ramah.
-		if os.IsNotExist(err) {
+		if storage.IsNotExist(err) {
 			ui.PrintError("Tidak ada summary backup yang tersedia.")
 			ui.PrintInfo(fmt.Sprintf("Direktori summary tidak ditemukan di: %s", s.getSummaryDir()))
 			return nil
@@ -45,27 +45,42 @@ func (s *Service) ListAvailableSummaries() error {
 
 // ShowSummaryByID menampilkan summary berdasarkan backup ID.
 func (s *Service) ShowSummaryByID(backupID string) error {
+	summary, err := s.loadSummaryByID(backupID)
+	if err != nil {
+		return err
+	}
+
+	s.DisplaySummaryTable(summary)
+	return nil
+}
+
+// loadSummaryByID membaca file summary berdasarkan backup ID.
+func (s *Service) loadSummaryByID(backupID string) (*BackupSummary, error) {
 	summaryFile := filepath.Join(s.getSummaryDir(), backupID+".json")
 
+	store, err := s.getStorage()
+	if err != nil {
+		return nil, err
+	}
+
 	// Periksa apakah file ada sebelum membaca.
-	if _, err := os.Stat(summaryFile); os.IsNotExist(err) {
-		return fmt.Errorf("summary dengan ID '%s' tidak ditemukan di %s", backupID, summaryFile)
+	if _, err := store.Stat(summaryFile); storage.IsNotExist(err) {
+		return nil, fmt.Errorf("summary dengan ID '%s' tidak ditemukan di %s", backupID, summaryFile)
 	}
 
 	summary, err := s.readSummaryFromJSON(summaryFile)
 	if err != nil {
-		return fmt.Errorf("gagal membaca summary: %w", err)
+		return nil, fmt.Errorf("gagal membaca summary: %w", err)
 	}
 
-	s.DisplaySummaryTable(summary)
-	return nil
+	return summary, nil
 }
 
 // ShowLatestSummary menampilkan summary backup terbaru.
 func (s *Service) ShowLatestSummary() error {
 	summaries, err := s.findAllSummaries()
 	if err != nil {
-		if os.IsNotExist(err) {
+		if storage.IsNotExist(err) {
 			return fmt.Errorf("tidak ada summary backup yang tersedia: direktori %s tidak ditemukan", s.getSummaryDir())
 		}
 		return fmt.Errorf("gagal mencari summary: %w", err)
@@ -99,31 +114,29 @@ func (s *Service) findAllSummaries() ([]SummaryFileEntry, error) {
 	summaryDir := s.getSummaryDir()
 	s.Logger.Debugf("Mencari summary di direktori: %s", summaryDir)
 
-	entries, err := os.ReadDir(summaryDir)
+	store, err := s.getStorage()
 	if err != nil {
-		return nil, err // Kembalikan error asli (misal: os.IsNotExist)
+		return nil, err
+	}
+	entries, err := store.List(summaryDir)
+	if err != nil {
+		return nil, err // Kembalikan error asli (misal: fs.ErrNotExist)
 	}
 
 	var summaryFiles []SummaryFileEntry
 	for _, entry := range entries {
-		// Lewati direktori atau file yang bukan .json
-		if entry.IsDir() || !strings.HasSuffix(strings.ToLower(entry.Name()), ".json") {
+		// Lewati file di subdirektori atau file yang bukan .json
+		filePath := filepath.Clean(entry.Path)
+		if filepath.Dir(filePath) != filepath.Clean(summaryDir) || !strings.HasSuffix(strings.ToLower(entry.Name()), ".json") {
 			continue
 		}
 
-		filePath := filepath.Join(summaryDir, entry.Name())
 		summary, readErr := s.readSummaryFromJSON(filePath)
 		if readErr != nil {
 			s.Logger.Warnf("Gagal memproses file summary %s: %v", entry.Name(), readErr)
 			continue // Lanjut ke file berikutnya jika ada yang korup
 		}
 
-		fileInfo, statErr := entry.Info()
-		if statErr != nil {
-			s.Logger.Warnf("Gagal mendapatkan info file untuk %s: %v", entry.Name(), statErr)
-			continue
-		}
-
 		summaryFiles = append(summaryFiles, SummaryFileEntry{
 			FileName:      entry.Name(),
 			FilePath:      filePath,
@@ -135,7 +148,7 @@ func (s *Service) findAllSummaries() ([]SummaryFileEntry, error) {
 			DatabaseCount: summary.DatabaseStats.SuccessfulBackups,
 			FailedCount:   summary.DatabaseStats.FailedBackups,
 			TotalSize:     summary.OutputInfo.TotalSizeHuman,
-			CreatedAt:     fileInfo.ModTime(),
+			CreatedAt:     entry.ModTime,
 		})
 	}
 	return summaryFiles, nil
@@ -170,7 +183,11 @@ func (s *Service) displaySummaryList(summaries []SummaryFileEntry) {
 // readSummaryFromJSON membaca dan mengurai file summary JSON.
 // Nama diubah menjadi huruf kecil karena ini adalah helper internal.
 func (s *Service) readSummaryFromJSON(jsonPath string) (*BackupSummary, error) {
-	data, err := os.ReadFile(jsonPath)
+	store, err := s.getStorage()
+	if err != nil {
+		return nil, err
+	}
+	data, err := storage.ReadFile(store, jsonPath)
 	if err != nil {
 		return nil, fmt.Errorf("gagal membaca file %s: %w", jsonPath, err)
 	}
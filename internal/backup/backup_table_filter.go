@@ -0,0 +1,215 @@
+// File : internal/backup/backup_table_filter.go
+// Deskripsi : Resolusi filter tabel (include/exclude/exclude-data) menjadi argumen mysqldump
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// tablePattern adalah pola db.table yang sudah dipisah; kedua bagian mendukung glob (* ? [])
+type tablePattern struct {
+	Raw      string
+	Database string
+	Table    string
+}
+
+// dbTableFilter adalah hasil resolusi filter tabel untuk satu database
+type dbTableFilter struct {
+	IgnoreTables []string // Tabel yang tidak di-dump sama sekali pada pass utama
+	NoDataTables []string // Tabel yang hanya di-dump strukturnya melalui pass kedua
+}
+
+// dumpPass adalah satu eksekusi mysqldump yang output-nya ditulis ke stream file backup yang sama
+type dumpPass struct {
+	Preamble string                  // SQL yang ditulis sebelum output mysqldump (misal: USE `db`;)
+	Args     []string                // Argumen mysqldump
+	Capture  *ReplicationCoordinates // Jika tidak nil, koordinat replikasi dari header output pass ini diurai ke sini
+}
+
+// hasTableFilters mengembalikan true jika ada filter tabel yang dikonfigurasi
+func (s *Service) hasTableFilters() bool {
+	return len(s.BackupOptions.Exclude.Tables) > 0 ||
+		len(s.BackupOptions.Exclude.DataTables) > 0 ||
+		len(s.BackupOptions.IncludeTables) > 0
+}
+
+// parseTablePatterns mem-parse daftar pola "db.table" dan memvalidasi sintaks glob-nya
+func parseTablePatterns(patterns []string) ([]tablePattern, error) {
+	var parsed []tablePattern
+	for _, raw := range patterns {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		dbPart, tablePart, found := strings.Cut(raw, ".")
+		if !found || dbPart == "" || tablePart == "" {
+			return nil, fmt.Errorf("pola tabel '%s' tidak valid, gunakan format db.table", raw)
+		}
+		if _, err := path.Match(dbPart, ""); err != nil {
+			return nil, fmt.Errorf("pola database pada '%s' tidak valid: %w", raw, err)
+		}
+		if _, err := path.Match(tablePart, ""); err != nil {
+			return nil, fmt.Errorf("pola tabel pada '%s' tidak valid: %w", raw, err)
+		}
+
+		parsed = append(parsed, tablePattern{Raw: raw, Database: dbPart, Table: tablePart})
+	}
+	return parsed, nil
+}
+
+// matchesDatabase mengembalikan true jika bagian database dari pola cocok dengan dbName
+func (p tablePattern) matchesDatabase(dbName string) bool {
+	ok, _ := path.Match(p.Database, dbName)
+	return ok
+}
+
+// matches mengembalikan true jika pola cocok dengan dbName.tableName
+func (p tablePattern) matches(dbName, tableName string) bool {
+	if !p.matchesDatabase(dbName) {
+		return false
+	}
+	ok, _ := path.Match(p.Table, tableName)
+	return ok
+}
+
+// matchesAnyTable mengembalikan true jika salah satu pola cocok dengan dbName.tableName
+func matchesAnyTable(patterns []tablePattern, dbName, tableName string) bool {
+	for _, p := range patterns {
+		if p.matches(dbName, tableName) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveTableFilters mencocokkan pola include/exclude tabel dengan daftar tabel aktual di server.
+// Pola --include-table hanya membatasi database yang cocok dengan bagian database pola tersebut;
+// database lain tetap di-backup utuh. Exclude penuh selalu mengalahkan exclude-data.
+func (s *Service) resolveTableFilters(ctx context.Context, dbFiltered []string) (map[string]dbTableFilter, error) {
+	excludePatterns, err := parseTablePatterns(s.BackupOptions.Exclude.Tables)
+	if err != nil {
+		return nil, err
+	}
+	includePatterns, err := parseTablePatterns(s.BackupOptions.IncludeTables)
+	if err != nil {
+		return nil, err
+	}
+	dataPatterns, err := parseTablePatterns(s.BackupOptions.Exclude.DataTables)
+	if err != nil {
+		return nil, err
+	}
+
+	// Jika seluruh backup sudah --no-data, exclude-data per tabel tidak diperlukan
+	if s.BackupOptions.Exclude.Data {
+		dataPatterns = nil
+	}
+
+	filters := make(map[string]dbTableFilter)
+	for _, dbName := range dbFiltered {
+		tables, err := s.Client.GetTableList(ctx, dbName)
+		if err != nil {
+			return nil, fmt.Errorf("gagal mendapatkan daftar tabel database %s: %w", dbName, err)
+		}
+
+		// Kumpulkan pola include yang berlaku untuk database ini
+		var dbIncludes []tablePattern
+		for _, p := range includePatterns {
+			if p.matchesDatabase(dbName) {
+				dbIncludes = append(dbIncludes, p)
+			}
+		}
+
+		var filter dbTableFilter
+		included := 0
+		for _, table := range tables {
+			switch {
+			case matchesAnyTable(excludePatterns, dbName, table):
+				filter.IgnoreTables = append(filter.IgnoreTables, table)
+			case len(dbIncludes) > 0 && !matchesAnyTable(dbIncludes, dbName, table):
+				filter.IgnoreTables = append(filter.IgnoreTables, table)
+			case matchesAnyTable(dataPatterns, dbName, table):
+				filter.NoDataTables = append(filter.NoDataTables, table)
+				included++
+			default:
+				included++
+			}
+		}
+
+		if len(dbIncludes) > 0 && included == 0 {
+			s.Logger.Warnf("Tidak ada tabel di database %s yang cocok dengan pola --include-table", dbName)
+		}
+
+		if len(filter.IgnoreTables) > 0 || len(filter.NoDataTables) > 0 {
+			sort.Strings(filter.IgnoreTables)
+			sort.Strings(filter.NoDataTables)
+			filters[dbName] = filter
+			s.Logger.Infof("Filter tabel %s: %d dikecualikan, %d hanya struktur", dbName, len(filter.IgnoreTables), len(filter.NoDataTables))
+		}
+	}
+
+	return filters, nil
+}
+
+// buildTableFilterArgs membuat argumen --ignore-table untuk database yang di-dump pada pass utama.
+// Tabel exclude-data juga di-ignore di sini karena strukturnya ditulis oleh pass kedua.
+func (s *Service) buildTableFilterArgs(dbNames []string) []string {
+	var args []string
+	for _, dbName := range dbNames {
+		filter, ok := s.TableFilters[dbName]
+		if !ok {
+			continue
+		}
+		for _, table := range filter.IgnoreTables {
+			args = append(args, fmt.Sprintf("--ignore-table=%s.%s", dbName, table))
+		}
+		for _, table := range filter.NoDataTables {
+			args = append(args, fmt.Sprintf("--ignore-table=%s.%s", dbName, table))
+		}
+	}
+	return args
+}
+
+// buildNoDataPasses membuat pass mysqldump kedua (struktur saja) untuk tabel exclude-data
+func (s *Service) buildNoDataPasses(baseDumpArgs string, dbNames []string) []dumpPass {
+	var passes []dumpPass
+	for _, dbName := range dbNames {
+		filter, ok := s.TableFilters[dbName]
+		if !ok || len(filter.NoDataTables) == 0 {
+			continue
+		}
+
+		args := s.buildConnectionArgs(s.BackupOptions.DBConfig.ServerDBConnection)
+		if baseDumpArgs != "" {
+			args = append(args, strings.Fields(baseDumpArgs)...)
+		}
+		// Routine dan event sudah ditulis oleh pass utama
+		args = append(args, "--no-data", "--skip-routines", "--skip-events", dbName)
+		args = append(args, filter.NoDataTables...)
+
+		quoted := strings.ReplaceAll(dbName, "`", "``")
+		passes = append(passes, dumpPass{
+			Preamble: fmt.Sprintf("\n-- sfDBTools: struktur tanpa data untuk database `%s`\nUSE `%s`;\n", quoted, quoted),
+			Args:     args,
+		})
+	}
+	return passes
+}
+
+// buildDumpPasses menyusun seluruh pass mysqldump untuk satu file backup.
+// Koordinat replikasi diurai dari header pass pertama ke job.Coordinates.
+func (s *Service) buildDumpPasses(job *dumpJob) []dumpPass {
+	passes := []dumpPass{{
+		Args:    s.buildMysqldumpArgs(job.BaseDumpArgs, job.Databases, job.SingleDB),
+		Capture: job.Coordinates,
+	}}
+	return append(passes, s.buildNoDataPasses(job.BaseDumpArgs, job.databases())...)
+}
@@ -0,0 +1,231 @@
+// File : internal/backup/backup_users.go
+// Deskripsi : Export akun user dan grant (SHOW CREATE USER / SHOW GRANTS) ke file users terpisah di setiap backup set
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sfDBTools/pkg/ui"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+)
+
+// dumperUsers adalah nama "dumper" untuk file export user dan grant
+const dumperUsers = "users"
+
+// builtinSystemAccounts adalah akun internal server yang selalu dibuat ulang oleh instalasi MariaDB/MySQL
+var builtinSystemAccounts = map[string]bool{
+	"mariadb.sys":      true,
+	"mysql.sys":        true,
+	"mysql.session":    true,
+	"mysql.infoschema": true,
+	"PUBLIC":           true,
+}
+
+// dbAccount adalah satu akun (atau role MariaDB, Host kosong) dari mysql.user
+type dbAccount struct {
+	User string
+	Host string
+}
+
+// isRole mengembalikan true untuk role MariaDB yang tercatat di mysql.user dengan host kosong
+func (a dbAccount) isRole() bool {
+	return a.Host == ""
+}
+
+// String mengembalikan nama akun dalam format 'user'@'host' (role: 'name')
+func (a dbAccount) String() string {
+	if a.isRole() {
+		return "'" + escapeSQLString(a.User) + "'"
+	}
+	return fmt.Sprintf("'%s'@'%s'", escapeSQLString(a.User), escapeSQLString(a.Host))
+}
+
+// usersDumper menulis CREATE USER / CREATE ROLE dan GRANT untuk daftar akun melalui client aktif
+type usersDumper struct {
+	s        *Service
+	accounts []dbAccount
+}
+
+func (d *usersDumper) Name() string { return dumperUsers }
+
+func (d *usersDumper) Dump(ctx context.Context, job *dumpJob, w io.Writer) (string, error) {
+	db := d.s.Client.DB()
+	var warnings []string
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "-- sfDBTools users and grants export\n--\n-- Host: %s    Accounts: %d\n", d.s.DBConfigInfo.ServerDBConnection.Host, len(d.accounts))
+	b.WriteString("-- ------------------------------------------------------\n\n")
+	b.WriteString("/*!40101 SET NAMES utf8mb4 */;\n")
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return "", fmt.Errorf("gagal menulis header export user: %w", err)
+	}
+
+	for _, account := range d.accounts {
+		b.Reset()
+		fmt.Fprintf(&b, "\n--\n-- Account: %s\n--\n\n", account)
+
+		if account.isRole() {
+			fmt.Fprintf(&b, "CREATE ROLE IF NOT EXISTS %s;\n", account)
+		} else {
+			var createUser string
+			if err := db.QueryRowContext(ctx, "SHOW CREATE USER "+account.String()).Scan(&createUser); err != nil {
+				warnings = append(warnings, fmt.Sprintf("SHOW CREATE USER %s: %v", account, err))
+				continue
+			}
+			if !strings.Contains(createUser, "IF NOT EXISTS") {
+				createUser = strings.Replace(createUser, "CREATE USER ", "CREATE USER IF NOT EXISTS ", 1)
+			}
+			b.WriteString(createUser + ";\n")
+		}
+
+		grants, err := d.showGrants(ctx, account)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("SHOW GRANTS FOR %s: %v", account, err))
+			continue
+		}
+		for _, grant := range grants {
+			b.WriteString(grant + ";\n")
+		}
+
+		if _, err := io.WriteString(w, b.String()); err != nil {
+			return strings.Join(warnings, "\n"), fmt.Errorf("gagal menulis grant %s: %w", account, err)
+		}
+	}
+
+	footer := "\nFLUSH PRIVILEGES;\n\n" + dumpCompletedTrailer + " on " + time.Now().Format("2006-01-02 15:04:05") + "\n"
+	if _, err := io.WriteString(w, footer); err != nil {
+		return strings.Join(warnings, "\n"), fmt.Errorf("gagal menulis penutup export user: %w", err)
+	}
+	return strings.Join(warnings, "\n"), nil
+}
+
+// showGrants mengembalikan semua baris SHOW GRANTS untuk satu akun
+func (d *usersDumper) showGrants(ctx context.Context, account dbAccount) ([]string, error) {
+	rows, err := d.s.Client.DB().QueryContext(ctx, "SHOW GRANTS FOR "+account.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var grants []string
+	for rows.Next() {
+		var grant string
+		if err := rows.Scan(&grant); err != nil {
+			return nil, err
+		}
+		grants = append(grants, grant)
+	}
+	return grants, rows.Err()
+}
+
+// listExportableAccounts membaca akun dari mysql.user dan melewati akun internal server serta system_users.users.
+// Mengembalikan akun yang di-export dan nama akun yang dilewati.
+func (s *Service) listExportableAccounts(ctx context.Context) ([]dbAccount, []string, error) {
+	rows, err := s.Client.DB().QueryContext(ctx, "SELECT User, Host FROM mysql.user ORDER BY User, Host")
+	if err != nil {
+		return nil, nil, fmt.Errorf("gagal membaca daftar user dari mysql.user: %w", err)
+	}
+	defer rows.Close()
+
+	var systemUsers map[string]bool
+	if s.Config != nil {
+		systemUsers = stringSet(s.Config.SystemUsers.Users)
+	}
+
+	var accounts []dbAccount
+	var skipped []string
+	for rows.Next() {
+		var account dbAccount
+		if err := rows.Scan(&account.User, &account.Host); err != nil {
+			return nil, nil, fmt.Errorf("gagal membaca baris mysql.user: %w", err)
+		}
+		if account.User == "" || builtinSystemAccounts[account.User] || systemUsers[account.User] ||
+			(account.User == "root" && account.Host == "localhost") {
+			skipped = append(skipped, account.String())
+			continue
+		}
+		accounts = append(accounts, account)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("gagal membaca daftar user dari mysql.user: %w", err)
+	}
+	return accounts, skipped, nil
+}
+
+// exportUsersAndGrants menulis CREATE USER dan GRANT semua akun non-sistem ke file users_<timestamp>.sql
+// di direktori output backup set, melalui rantai kompresi, enkripsi, dan verifikasi yang sama dengan file dump.
+func (s *Service) exportUsersAndGrants(ctx context.Context, config BackupConfig, startTime time.Time) (*UsersExportInfo, error) {
+	exportStart := time.Now()
+	accounts, skipped, err := s.listExportableAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.Logger.Infof("Export %d akun user dan grant (%d akun sistem dilewati)", len(accounts), len(skipped))
+	if !config.EncryptionEnabled {
+		s.Logger.Warn("Enkripsi tidak diaktifkan: file users berisi hash password dan disimpan tanpa enkripsi")
+	}
+
+	fileName := s.addFileExtensions(fmt.Sprintf("users_%s.sql", startTime.Format(backupIDTimeFormat)), config)
+	fullOutputPath := filepath.Join(config.OutputDir, fileName)
+
+	var written *dumpChecksums
+	if config.shouldVerifyBackup() {
+		written = &dumpChecksums{}
+	}
+
+	dumpImpl := &usersDumper{s: s, accounts: accounts}
+	warnings, err := s.executeDumpWithPipe(ctx, dumpImpl, nil, fullOutputPath, config.CompressionRequired, config.CompressionType, written)
+	if err != nil {
+		return nil, fmt.Errorf("gagal export user dan grant: %w", err)
+	}
+
+	verification, err := s.runPostWriteVerification(config, fullOutputPath, written)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &UsersExportInfo{
+		OutputFile:      fullOutputPath,
+		AccountCount:    len(accounts),
+		SkippedAccounts: skipped,
+		Warnings:        warnings,
+		Duration:        ui.FormatDuration(time.Since(exportStart)),
+		Verification:    verification,
+	}
+	info.FileSize = s.outputFileSize(fullOutputPath)
+	info.FileSizeHuman = s.formatFileSize(info.FileSize)
+	if warnings != "" {
+		s.Logger.Warnf("Export user selesai dengan warning: %s", warnings)
+	}
+	s.Logger.Infof("User dan grant tersimpan di: %s", fullOutputPath)
+	return info, nil
+}
+
+// addUsersFileToSummary mencatat file users pada summary dan daftar file output agar ikut masuk MANIFEST
+func (s *Service) addUsersFileToSummary(summary *BackupSummary, info *UsersExportInfo) {
+	summary.UsersExport = info
+	fileInfo := SummaryFileInfo{
+		FileName:  filepath.Base(info.OutputFile),
+		FilePath:  info.OutputFile,
+		Size:      info.FileSize,
+		SizeHuman: info.FileSizeHuman,
+		CreatedAt: time.Now(),
+	}
+	if info.Verification != nil {
+		fileInfo.SHA256 = info.Verification.FileSHA256
+		fileInfo.SQLSHA256 = info.Verification.SQLSHA256
+	}
+	summary.OutputInfo.Files = append(summary.OutputInfo.Files, fileInfo)
+	summary.OutputInfo.TotalFiles = len(summary.OutputInfo.Files)
+	summary.OutputInfo.TotalSize += info.FileSize
+	summary.OutputInfo.TotalSizeHuman = humanize.Bytes(uint64(summary.OutputInfo.TotalSize))
+}
@@ -9,13 +9,13 @@ package backup
 import (
 	"context"
 	"fmt"
-	"os"
 	"os/exec"
 	"path/filepath"
 	"sfDBTools/internal/dbscan"
 	"sfDBTools/internal/structs"
 	"sfDBTools/pkg/compress"
 	"sfDBTools/pkg/database"
+	"sfDBTools/pkg/storage"
 	"sfDBTools/pkg/ui"
 	"strconv"
 	"strings"
@@ -58,15 +58,31 @@ func (s *Service) DisplayBackupAllOptions() {
 		{"Cleanup Schedule", s.BackupOptions.Cleanup.Scheduled},
 		{"Retention Days", strconv.Itoa(s.BackupOptions.Cleanup.RetentionDays)},
 		{"Exclude Databases", ui.FormatStringSlice(s.BackupOptions.Exclude.Databases)},
+		{"Exclude Tables", ui.FormatStringSlice(s.BackupOptions.Exclude.Tables)},
+		{"Include Tables", ui.FormatStringSlice(s.BackupOptions.IncludeTables)},
+		{"Exclude Table Data", ui.FormatStringSlice(s.BackupOptions.Exclude.DataTables)},
 		{"Exclude Users", strconv.FormatBool(s.BackupOptions.Exclude.Users)},
 		{"Exclude System Databases", strconv.FormatBool(s.BackupOptions.Exclude.SystemsDB)},
 		{"Exclude Data", strconv.FormatBool(s.BackupOptions.Exclude.Data)},
 		{"Use DBList File", strconv.FormatBool(s.BackupOptions.UseDBList)},
 		{"Database List File", s.BackupOptions.DBList},
 		{"Verification Disk Check", strconv.FormatBool(s.BackupOptions.DiskCheck)},
-		{"Capture GTID", strconv.FormatBool(s.BackupAll != nil && s.BackupAll.CaptureGtid)},
+		{"Capture GTID", strconv.FormatBool(s.BackupOptions.CaptureGtid)},
+		{"Coordinates Source", s.BackupOptions.Coordinates},
+		{"Dumper", s.dumperName()},
+		{"Parallel Workers", strconv.Itoa(s.BackupOptions.Concurrency.Parallel)},
+		{"Max Bandwidth", s.BackupOptions.Concurrency.MaxBandwidth},
+		{"Adaptive Concurrency", strconv.FormatBool(s.BackupOptions.Concurrency.Adaptive)},
+		{"Retry Attempts", strconv.Itoa(s.BackupOptions.Retry.MaxAttempts)},
+		{"Storage", s.storageDescription()},
 		{"Create Backup Info File", strconv.FormatBool(s.BackupInfo.Enabled)},
 	}
+	if s.BackupDB != nil {
+		data = append(data,
+			[]string{"Databases", ui.FormatStringSlice(s.BackupDB.DBName)},
+			[]string{"Mode", s.BackupDB.Mode},
+		)
+	}
 	ui.FormatTable(headers, data)
 }
 
@@ -148,8 +164,11 @@ func (s *Service) saveErrorLog(outputDir, dbName, errorOutput string) string {
 	logFileName := dbName + "_errors.log"
 	logFilePath := filepath.Join(outputDir, logFileName)
 
-	// Simpan ke file
-	err := os.WriteFile(logFilePath, []byte(errorOutput), 0644)
+	// Simpan ke storage yang sama dengan file backup
+	store, err := s.getStorage()
+	if err == nil {
+		err = storage.WriteFile(store, logFilePath, []byte(errorOutput))
+	}
 	if err != nil {
 		s.Logger.Errorf("Gagal menyimpan error log ke %s: %v", logFilePath, err)
 		return ""
@@ -175,6 +194,7 @@ func (s *Service) runDatabaseScanForBackup(ctx context.Context, dbNames []string
 		Background:     false,
 		Mode:           "database",
 		DisplayResults: true,
+		Parallel:       s.BackupOptions.Concurrency.Parallel,
 	}
 
 	dbscanSvc.SetScanOptions(scanOptions)
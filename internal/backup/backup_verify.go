@@ -0,0 +1,142 @@
+// File : internal/backup/backup_verify.go
+// Deskripsi : Verifikasi file backup setelah ditulis (trailer dump dan checksum SHA-256)
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package backup
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sfDBTools/pkg/ui"
+	"strings"
+	"time"
+)
+
+// dumpCompletedTrailer adalah komentar penutup yang ditulis mysqldump saat dump selesai
+const dumpCompletedTrailer = "-- Dump completed"
+
+// verifyTailSize adalah ukuran buffer ekor SQL yang disimpan untuk mencari trailer
+const verifyTailSize = 4096
+
+// tailBuffer menyimpan n byte terakhir dari data yang ditulis ke dalamnya
+type tailBuffer struct {
+	buf  []byte
+	size int
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if len(p) >= t.size {
+		t.buf = append(t.buf[:0], p[len(p)-t.size:]...)
+		return n, nil
+	}
+	t.buf = append(t.buf, p...)
+	if len(t.buf) > t.size {
+		t.buf = append(t.buf[:0], t.buf[len(t.buf)-t.size:]...)
+	}
+	return n, nil
+}
+
+// shouldVerifyBackup mengembalikan true jika file backup perlu dibaca ulang setelah ditulis
+func (config BackupConfig) shouldVerifyBackup() bool {
+	return config.VerifyAfterWrite || config.CompareChecksums
+}
+
+// expectsDumpTrailer mengembalikan false jika output bukan dump SQL atau argumen mysqldump
+// menonaktifkan komentar sehingga trailer "-- Dump completed" memang tidak ditulis.
+func (config BackupConfig) expectsDumpTrailer() bool {
+	if config.NoDumpTrailer {
+		return false
+	}
+	for _, arg := range strings.Fields(config.BaseDumpArgs) {
+		if arg == "--skip-comments" || arg == "--compact" || arg == "--comments=0" || arg == "--comments=false" {
+			return false
+		}
+	}
+	return true
+}
+
+// verifyBackupFile membuka ulang file backup, membuka lapisan enkripsi dan kompresi secara streaming,
+// lalu memeriksa trailer dump dan menghitung SHA-256 dari byte file serta plaintext SQL.
+// Jika written tidak nil dan compare_checksums aktif, hasilnya dibandingkan dengan checksum saat penulisan.
+func (s *Service) verifyBackupFile(config BackupConfig, path string, written *dumpChecksums) (*BackupVerificationInfo, error) {
+	startTime := time.Now()
+	info := &BackupVerificationInfo{}
+
+	store, err := s.getStorage()
+	if err != nil {
+		return info, err
+	}
+	file, err := store.Open(path)
+	if err != nil {
+		return info, fmt.Errorf("gagal membuka file backup untuk verifikasi: %w", err)
+	}
+
+	// Byte file di-hash melalui TeeReader sehingga tidak perlu membaca file dua kali
+	fileHasher := sha256.New()
+	rawReader := io.TeeReader(file, fileHasher)
+
+	reader, err := s.wrapBackupReader(path, rawReader, file, s.BackupOptions.Encryption.Key)
+	if err != nil {
+		return info, fmt.Errorf("gagal membuka stream backup untuk verifikasi: %w", err)
+	}
+	defer reader.Close()
+
+	sqlHasher := sha256.New()
+	tail := &tailBuffer{size: verifyTailSize}
+	sqlSize, err := io.Copy(io.MultiWriter(sqlHasher, tail), reader)
+	if err != nil {
+		return info, fmt.Errorf("gagal membaca ulang isi backup (file terpotong atau rusak): %w", err)
+	}
+
+	// Habiskan sisa byte mentah yang tidak dikonsumsi decompressor agar hash file lengkap
+	if _, err := io.Copy(io.Discard, rawReader); err != nil {
+		return info, fmt.Errorf("gagal membaca sisa file backup: %w", err)
+	}
+
+	info.SQLSize = sqlSize
+	info.FileSHA256 = hex.EncodeToString(fileHasher.Sum(nil))
+	info.SQLSHA256 = hex.EncodeToString(sqlHasher.Sum(nil))
+	info.TrailerFound = bytes.Contains(tail.buf, []byte(dumpCompletedTrailer))
+	info.Duration = ui.FormatDuration(time.Since(startTime))
+
+	if config.VerifyAfterWrite && config.expectsDumpTrailer() && !info.TrailerFound {
+		return info, fmt.Errorf("trailer '%s' tidak ditemukan, dump kemungkinan tidak lengkap", dumpCompletedTrailer)
+	}
+
+	if config.CompareChecksums && written != nil {
+		info.ChecksumCompared = true
+		if written.FileSHA256 != info.FileSHA256 {
+			return info, fmt.Errorf("checksum file tidak cocok (ditulis: %s, dibaca: %s)", written.FileSHA256, info.FileSHA256)
+		}
+		if written.SQLSHA256 != info.SQLSHA256 {
+			return info, fmt.Errorf("checksum SQL tidak cocok (ditulis: %s, dibaca: %s)", written.SQLSHA256, info.SQLSHA256)
+		}
+	}
+
+	info.Verified = true
+	return info, nil
+}
+
+// runPostWriteVerification menjalankan verifikasi setelah penulisan dan mencatat hasilnya ke log.
+// Mengembalikan nil info jika verifikasi tidak diaktifkan.
+func (s *Service) runPostWriteVerification(config BackupConfig, path string, written *dumpChecksums) (*BackupVerificationInfo, error) {
+	if !config.shouldVerifyBackup() {
+		return nil, nil
+	}
+
+	s.Logger.Debugf("Memverifikasi file backup: %s", path)
+	info, err := s.verifyBackupFile(config, path, written)
+	if err != nil {
+		info.Error = err.Error()
+		return info, fmt.Errorf("verifikasi file backup gagal: %w", err)
+	}
+
+	s.Logger.Infof("Verifikasi %s berhasil (SQL: %d bytes, sha256: %s)", path, info.SQLSize, info.FileSHA256)
+	return info, nil
+}
@@ -2,28 +2,74 @@ package backup
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"hash"
 	"io"
-	"os"
-	"os/exec"
 	"sfDBTools/pkg/compress"
 	"sfDBTools/pkg/encrypt"
-	"strings"
+	"sfDBTools/pkg/fs"
 )
 
-// executeMysqldumpWithPipe menjalankan mysqldump dengan pipe untuk kompresi dan enkripsi.
-// Mengembalikan error untuk fatal errors dan stderr output untuk warnings/non-fatal errors
-func (s *Service) executeMysqldumpWithPipe(ctx context.Context, mysqldumpArgs []string, outputPath string, compressionRequired bool, compressionType string) (string, error) {
-	outputFile, err := os.Create(outputPath)
+// executeDumpWithPipe menjalankan dumper dengan pipe untuk kompresi dan enkripsi ke file di storage.
+// Mengembalikan error untuk fatal errors dan stderr output untuk warnings/non-fatal errors.
+// Jika written tidak nil, SHA-256 dari byte file dan plaintext SQL dihitung selama penulisan.
+func (s *Service) executeDumpWithPipe(ctx context.Context, d dumper, job *dumpJob, outputPath string, compressionRequired bool, compressionType string, written *dumpChecksums) (string, error) {
+	store, err := s.getStorage()
+	if err != nil {
+		return "", err
+	}
+	outputFile, err := store.Create(outputPath)
 	if err != nil {
 		return "", fmt.Errorf("gagal membuat file output: %w", err)
 	}
-	defer outputFile.Close()
-
-	var writer io.Writer = outputFile
 	var closers []io.Closer
 
-	// Urutan layer: mysqldump -> Compression -> Encryption -> File
+	// closeWriters menutup layer dari yang paling luar; error di sini berarti file tidak lengkap
+	closed := false
+	closeWriters := func(logErrors bool) error {
+		if closed {
+			return nil
+		}
+		closed = true
+		var firstErr error
+		for i := len(closers) - 1; i >= 0; i-- {
+			if err := closers[i].Close(); err != nil {
+				if logErrors {
+					s.Logger.Errorf("Error closing writer: %v", err)
+				}
+				if firstErr == nil {
+					firstErr = err
+				}
+			}
+		}
+		return firstErr
+	}
+
+	// File (atau upload multipart) yang belum selesai dibuang jika terjadi error di tengah jalan.
+	// Abort dilakukan sebelum layer ditutup agar chunk final enkripsi atau footer kompresi
+	// tidak pernah membuat file yang terpotong terlihat lengkap.
+	completed := false
+	defer func() {
+		if !completed {
+			outputFile.Abort()
+			closeWriters(false)
+		}
+	}()
+
+	// Pembatas bandwidth dipasang paling dekat ke file agar yang dibatasi adalah byte yang benar-benar ditulis.
+	// Close dari storage Writer disembunyikan sehingga hanya outputFile.Close() yang menyelesaikan file.
+	fileWriter := io.Writer(writeOnly{fs.NewThrottledWriter(outputFile, s.BandwidthLimiter)})
+	writer := fileWriter
+
+	var fileHasher, sqlHasher hash.Hash
+	if written != nil {
+		fileHasher = sha256.New()
+		writer = io.MultiWriter(fileWriter, fileHasher)
+	}
+
+	// Urutan layer: dumper -> Compression -> Encryption -> File
 	if s.BackupOptions.Encryption.Enabled {
 		encryptionKey := s.BackupOptions.Encryption.Key
 		if encryptionKey == "" {
@@ -56,33 +102,34 @@ func (s *Service) executeMysqldumpWithPipe(ctx context.Context, mysqldumpArgs []
 		writer = compressingWriter
 	}
 
-	defer func() {
-		for i := len(closers) - 1; i >= 0; i-- {
-			if err := closers[i].Close(); err != nil {
-				s.Logger.Errorf("Error closing writer: %v", err)
-			}
-		}
-	}()
-
-	cmd := exec.CommandContext(ctx, "mysqldump", mysqldumpArgs...)
-	cmd.Stdout = writer
+	if written != nil {
+		sqlHasher = sha256.New()
+		writer = io.MultiWriter(writer, sqlHasher)
+	}
 
-	// Capture stderr untuk menangkap warnings dan errors
-	var stderrBuf strings.Builder
-	cmd.Stderr = &stderrBuf
+	stderrOutput, err := d.Dump(ctx, job, writer)
+	if err != nil {
+		return stderrOutput, err
+	}
 
-	// logArgs := s.sanitizeArgsForLogging(mysqldumpArgs)
-	// s.Logger.Infof("Command: mysqldump %s", strings.Join(logArgs, " "))
+	if err := closeWriters(true); err != nil {
+		return stderrOutput, fmt.Errorf("gagal menyelesaikan penulisan file backup: %w", err)
+	}
+	if err := outputFile.Close(); err != nil {
+		return stderrOutput, fmt.Errorf("gagal menyelesaikan penulisan file backup: %w", err)
+	}
+	completed = true
 
-	if err := cmd.Run(); err != nil {
-		stderrOutput := stderrBuf.String()
-		// Cek apakah ini error fatal atau hanya warning
-		if s.isFatalMysqldumpError(err, stderrOutput) {
-			return stderrOutput, fmt.Errorf("mysqldump gagal: %w", err)
-		}
-		// Jika bukan fatal error, kembalikan stderr sebagai warning
-		return stderrOutput, nil
+	if written != nil {
+		written.FileSHA256 = hex.EncodeToString(fileHasher.Sum(nil))
+		written.SQLSHA256 = hex.EncodeToString(sqlHasher.Sum(nil))
 	}
 
-	return stderrBuf.String(), nil
+	return stderrOutput, nil
+}
+
+// writeOnly hanya meneruskan Write sehingga layer kompresi dan enkripsi tidak dapat menutup
+// (dan dengan itu meng-commit) storage Writer di bawahnya
+type writeOnly struct {
+	io.Writer
 }
@@ -0,0 +1,132 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	log "sfDBTools/internal/applog"
+	"sfDBTools/internal/structs"
+	"sfDBTools/pkg/storage"
+)
+
+var errDumpFailed = errors.New("dump gagal di tengah jalan")
+
+// failingDumper menulis sebagian data lalu gagal, seperti mysqldump yang terputus
+type failingDumper struct{}
+
+func (failingDumper) Name() string { return "failing" }
+
+func (failingDumper) Dump(ctx context.Context, job *dumpJob, w io.Writer) (string, error) {
+	if _, err := w.Write(bytes.Repeat([]byte("INSERT INTO t VALUES (1);\n"), 10000)); err != nil {
+		return "", err
+	}
+	return "mysqldump: Lost connection", errDumpFailed
+}
+
+// recordingStorage mencatat apakah file di-commit (Close) atau dibatalkan (Abort)
+type recordingStorage struct {
+	writer *recordingWriter
+}
+
+type recordingWriter struct {
+	bytes.Buffer
+	committed bool
+	aborted   bool
+}
+
+func (w *recordingWriter) Write(p []byte) (int, error) {
+	if w.aborted || w.committed {
+		return 0, errors.New("writer sudah selesai")
+	}
+	return w.Buffer.Write(p)
+}
+
+func (w *recordingWriter) Close() error {
+	if !w.aborted {
+		w.committed = true
+	}
+	return nil
+}
+
+func (w *recordingWriter) Abort() error {
+	if !w.committed {
+		w.aborted = true
+	}
+	return nil
+}
+
+func (r *recordingStorage) Type() string { return storage.TypeS3 }
+func (r *recordingStorage) Create(path string) (storage.Writer, error) {
+	r.writer = &recordingWriter{}
+	return r.writer, nil
+}
+func (r *recordingStorage) Open(path string) (io.ReadCloser, error) { return nil, os.ErrNotExist }
+func (r *recordingStorage) Stat(path string) (storage.FileInfo, error) {
+	return storage.FileInfo{}, os.ErrNotExist
+}
+func (r *recordingStorage) List(dir string) ([]storage.FileInfo, error) {
+	return nil, nil
+}
+func (r *recordingStorage) Remove(path string) error  { return nil }
+func (r *recordingStorage) MkdirAll(dir string) error { return nil }
+func (r *recordingStorage) Close() error              { return nil }
+
+func newWriterTestService(st storage.Storage, compression bool) *Service {
+	return &Service{
+		Logger:  log.MockLogger(),
+		Storage: st,
+		BackupOptions: &structs.BackupOptions{
+			Encryption:  structs.EncryptionOptions{Enabled: true, Key: "kunci-uji"},
+			Compression: structs.CompressionOptions{Enabled: compression, Type: "gzip", Level: "fast"},
+		},
+	}
+}
+
+func TestExecuteDumpWithPipeFailureRemovesLocalFile(t *testing.T) {
+	for _, compression := range []bool{false, true} {
+		dir := t.TempDir()
+		outputPath := filepath.Join(dir, "db.sql.enc")
+		svc := newWriterTestService(storage.NewLocal(), compression)
+
+		_, err := svc.executeDumpWithPipe(context.Background(), failingDumper{}, &dumpJob{SingleDB: "db"}, outputPath, compression, "gzip", nil)
+		if !errors.Is(err, errDumpFailed) {
+			t.Fatalf("compression=%v: error = %v, ingin %v", compression, err, errDumpFailed)
+		}
+		if _, err := os.Stat(outputPath); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("compression=%v: file backup terpotong masih ada (stat error: %v)", compression, err)
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 0 {
+			t.Fatalf("compression=%v: direktori output tidak kosong: %v", compression, entries)
+		}
+	}
+}
+
+func TestExecuteDumpWithPipeFailureAbortsUpload(t *testing.T) {
+	for _, compression := range []bool{false, true} {
+		st := &recordingStorage{}
+		svc := newWriterTestService(st, compression)
+
+		_, err := svc.executeDumpWithPipe(context.Background(), failingDumper{}, &dumpJob{SingleDB: "db"}, "backup/db.sql.enc", compression, "gzip", nil)
+		if !errors.Is(err, errDumpFailed) {
+			t.Fatalf("compression=%v: error = %v, ingin %v", compression, err, errDumpFailed)
+		}
+		if st.writer == nil {
+			t.Fatalf("compression=%v: file output tidak pernah dibuat", compression)
+		}
+		if st.writer.committed {
+			t.Fatalf("compression=%v: upload terpotong di-commit", compression)
+		}
+		if !st.writer.aborted {
+			t.Fatalf("compression=%v: upload tidak dibatalkan", compression)
+		}
+	}
+}
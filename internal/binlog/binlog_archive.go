@@ -0,0 +1,292 @@
+// File : internal/binlog/binlog_archive.go
+// Deskripsi : Alur pengarsipan binlog yang sudah ditutup ke direktori backup (sekali jalan atau periodik)
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package binlog
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sfDBTools/pkg/compress"
+	"sfDBTools/pkg/database"
+	"sfDBTools/pkg/dbconfig"
+	"sfDBTools/pkg/encrypt"
+	"sfDBTools/pkg/ui"
+	"time"
+)
+
+// ExecuteArchive adalah entry point command 'binlog archive'.
+// Tanpa --watch, binlog yang sudah ditutup diarsipkan sekali lalu selesai.
+// Dengan --watch, pemeriksaan diulang setiap interval sampai context dibatalkan (SIGINT/SIGTERM).
+func (s *Service) ExecuteArchive(ctx context.Context) error {
+	ui.Headers("Arsip Binary Log")
+	opts := s.ArchiveOptions
+
+	if err := s.validateArchiveOptions(); err != nil {
+		return err
+	}
+
+	// Pilih profil koneksi server sumber binlog
+	if err := dbconfig.CheckAndSelectConfigFile(&opts.DBConfig, opts.DBConfig.EncryptionKey, "Pilih file konfigurasi database sumber binlog:"); err != nil {
+		return err
+	}
+
+	// Resolve kunci enkripsi sekali di awal
+	if opts.Encryption.Enabled && opts.Encryption.Key == "" {
+		key, source, err := encrypt.ResolveEncryptionKey("")
+		if err != nil {
+			return fmt.Errorf("gagal mendapatkan kunci enkripsi: %w", err)
+		}
+		opts.Encryption.Key = key
+		s.Logger.Infof("Kunci enkripsi diperoleh dari: %s", source)
+	}
+
+	var err error
+	s.Client, err = database.InitializeDatabase(opts.DBConfig.ServerDBConnection)
+	if err != nil {
+		return err
+	}
+	defer s.Client.Close()
+
+	archiveDir := ArchiveDir(opts.OutputDirectory, opts.DBConfig.ServerDBConnection.Host, opts.DBConfig.ServerDBConnection.Port)
+	if err := os.MkdirAll(archiveDir, 0755); err != nil {
+		return fmt.Errorf("gagal membuat direktori arsip binlog: %w", err)
+	}
+	s.Logger.Infof("Direktori arsip binlog: %s", archiveDir)
+
+	if !opts.Watch {
+		archived, err := s.archiveClosedBinlogs(ctx, archiveDir)
+		if err != nil {
+			return err
+		}
+		ui.PrintSuccess(fmt.Sprintf("Pengarsipan selesai: %d binlog baru diarsipkan.", archived))
+		return nil
+	}
+
+	interval := time.Duration(opts.Interval) * time.Second
+	s.Logger.Infof("Mode watch aktif: memeriksa binlog baru setiap %s", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// Pada mode watch, error satu siklus tidak menghentikan proses; siklus berikutnya mencoba lagi
+		if archived, err := s.archiveClosedBinlogs(ctx, archiveDir); err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			s.Logger.Errorf("Siklus pengarsipan binlog gagal: %v", err)
+		} else if archived > 0 {
+			s.Logger.Infof("%d binlog baru diarsipkan", archived)
+		}
+
+		select {
+		case <-ctx.Done():
+			s.Logger.Info("Pengarsipan binlog dihentikan.")
+			return nil
+		case <-ticker.C:
+		}
+	}
+
+	s.Logger.Info("Pengarsipan binlog dihentikan.")
+	return nil
+}
+
+// validateArchiveOptions memvalidasi flags command 'binlog archive'
+func (s *Service) validateArchiveOptions() error {
+	opts := s.ArchiveOptions
+	switch opts.Source {
+	case "auto", "local", "remote":
+	default:
+		return fmt.Errorf("sumber binlog tidak valid: %s. Gunakan 'auto', 'local', atau 'remote'", opts.Source)
+	}
+	if opts.Source == "local" && opts.BinlogDir == "" {
+		return fmt.Errorf("sumber 'local' membutuhkan --binlog-dir")
+	}
+	if opts.OutputDirectory == "" {
+		return fmt.Errorf("direktori output arsip binlog belum ditentukan (--output)")
+	}
+	if opts.Watch && opts.Interval <= 0 {
+		return fmt.Errorf("interval harus lebih dari 0 detik")
+	}
+	if opts.Compression.Enabled {
+		if _, err := compress.ValidateCompressionType(opts.Compression.Type); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// archiveClosedBinlogs mengarsipkan semua binlog yang sudah ditutup dan belum ada di index.
+// Binlog terakhir pada SHOW BINARY LOGS adalah binlog aktif dan tidak diarsipkan.
+func (s *Service) archiveClosedBinlogs(ctx context.Context, archiveDir string) (int, error) {
+	logs, err := s.listBinaryLogs(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(logs) <= 1 {
+		s.Logger.Info("Belum ada binlog yang ditutup untuk diarsipkan.")
+		return 0, nil
+	}
+	closed := logs[:len(logs)-1]
+
+	index, err := LoadIndex(archiveDir)
+	if err != nil {
+		return 0, err
+	}
+	conn := s.ArchiveOptions.DBConfig.ServerDBConnection
+	index.Server = fmt.Sprintf("%s:%d", conn.Host, conn.Port)
+
+	archived := 0
+	for _, log := range closed {
+		if index.Has(log.Name) {
+			continue
+		}
+		if ctx.Err() != nil {
+			return archived, ctx.Err()
+		}
+
+		entry, err := s.archiveBinlog(ctx, archiveDir, log)
+		if err != nil {
+			return archived, fmt.Errorf("gagal mengarsipkan %s: %w", log.Name, err)
+		}
+
+		// Simpan index setelah setiap binlog agar progres tidak hilang bila proses terhenti
+		index.Entries = append(index.Entries, entry)
+		if err := SaveIndex(archiveDir, index); err != nil {
+			return archived, err
+		}
+		archived++
+		s.Logger.Infof("Binlog %s diarsipkan ke %s (%s)", log.Name, entry.ArchiveFile, ui.FormatFileSize(entry.ArchivedSize))
+	}
+
+	return archived, nil
+}
+
+// archiveBinlog menyalin satu binlog ke direktori arsip melalui layer kompresi dan enkripsi.
+// File ditulis ke nama sementara dan di-rename setelah lengkap.
+func (s *Service) archiveBinlog(ctx context.Context, archiveDir string, log binaryLog) (ArchiveEntry, error) {
+	opts := s.ArchiveOptions
+
+	startGTID, err := s.readStartGTIDList(ctx, log.Name)
+	if err != nil {
+		s.Logger.Warnf("Gagal membaca Gtid_list %s: %v", log.Name, err)
+	}
+
+	source, sourceName, err := s.openBinlogSource(ctx, log.Name)
+	if err != nil {
+		return ArchiveEntry{}, err
+	}
+	defer source.Close()
+
+	archiveFile := log.Name
+	compressionType := string(compress.CompressionNone)
+	if opts.Compression.Enabled {
+		compressionType = opts.Compression.Type
+		archiveFile += compress.GetFileExtension(compress.CompressionType(compressionType))
+	}
+	if opts.Encryption.Enabled {
+		archiveFile += ".enc"
+	}
+
+	finalPath := filepath.Join(archiveDir, archiveFile)
+	tmpPath := finalPath + ".partial"
+
+	originalSize, archivedSize, sum, err := s.writeArchiveFile(tmpPath, source)
+	if err != nil {
+		os.Remove(tmpPath)
+		return ArchiveEntry{}, err
+	}
+	if err := os.Rename(tmpPath, finalPath); err != nil {
+		os.Remove(tmpPath)
+		return ArchiveEntry{}, fmt.Errorf("gagal memindahkan file arsip: %w", err)
+	}
+
+	return ArchiveEntry{
+		Name:         log.Name,
+		ArchiveFile:  archiveFile,
+		OriginalSize: originalSize,
+		ArchivedSize: archivedSize,
+		SHA256:       sum,
+		StartGTID:    startGTID,
+		Compression:  compressionType,
+		Encrypted:    opts.Encryption.Enabled,
+		Source:       sourceName,
+		ArchivedAt:   time.Now(),
+	}, nil
+}
+
+// writeArchiveFile menulis isi binlog ke path dengan urutan layer: Kompresi -> Enkripsi -> File.
+// Mengembalikan ukuran asli, ukuran file arsip, dan SHA-256 file arsip.
+func (s *Service) writeArchiveFile(path string, source io.Reader) (int64, int64, string, error) {
+	opts := s.ArchiveOptions
+
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("gagal membuat file arsip: %w", err)
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	counter := &countingWriter{}
+	var writer io.Writer = io.MultiWriter(file, hasher, counter)
+	var closers []io.Closer
+
+	if opts.Encryption.Enabled {
+		encryptingWriter, err := encrypt.NewEncryptingWriter(writer, []byte(opts.Encryption.Key))
+		if err != nil {
+			return 0, 0, "", fmt.Errorf("gagal membuat encrypting writer: %w", err)
+		}
+		closers = append(closers, encryptingWriter)
+		writer = encryptingWriter
+	}
+
+	if opts.Compression.Enabled {
+		compressingWriter, err := compress.NewCompressingWriter(writer, compress.CompressionConfig{
+			Type:  compress.CompressionType(opts.Compression.Type),
+			Level: compress.CompressionLevel(opts.Compression.Level),
+		})
+		if err != nil {
+			return 0, 0, "", fmt.Errorf("gagal membuat compressing writer: %w", err)
+		}
+		closers = append(closers, compressingWriter)
+		writer = compressingWriter
+	}
+
+	originalSize, copyErr := io.Copy(writer, source)
+
+	// Tutup layer dari yang paling luar; error di sini berarti arsip tidak lengkap
+	var closeErr error
+	for i := len(closers) - 1; i >= 0; i-- {
+		if err := closers[i].Close(); err != nil && closeErr == nil {
+			closeErr = err
+		}
+	}
+	if copyErr != nil {
+		return 0, 0, "", fmt.Errorf("gagal menyalin binlog: %w", copyErr)
+	}
+	if closeErr != nil {
+		return 0, 0, "", fmt.Errorf("gagal menyelesaikan file arsip: %w", closeErr)
+	}
+	if err := file.Sync(); err != nil {
+		return 0, 0, "", fmt.Errorf("gagal sinkronisasi file arsip: %w", err)
+	}
+
+	return originalSize, counter.n, hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// countingWriter menghitung jumlah byte yang ditulis
+type countingWriter struct {
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
+}
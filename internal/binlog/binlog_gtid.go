@@ -0,0 +1,67 @@
+// File : internal/binlog/binlog_gtid.go
+// Deskripsi : Helper parsing dan perbandingan posisi GTID MariaDB (domain-server-seq)
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package binlog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseGTIDList mengurai daftar GTID MariaDB ("0-1-100,1-2-50") menjadi map domain -> sequence.
+// Server ID diabaikan karena urutan transaksi dalam satu domain ditentukan oleh sequence.
+func ParseGTIDList(gtidList string) (map[uint32]uint64, error) {
+	positions := make(map[uint32]uint64)
+	gtidList = strings.Trim(strings.TrimSpace(gtidList), "[]")
+	if gtidList == "" {
+		return positions, nil
+	}
+
+	for _, part := range strings.Split(gtidList, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		fields := strings.Split(part, "-")
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("format GTID '%s' tidak valid, gunakan domain-server-sequence", part)
+		}
+		domain, err := strconv.ParseUint(fields[0], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("domain GTID '%s' tidak valid: %w", part, err)
+		}
+		if _, err := strconv.ParseUint(fields[1], 10, 32); err != nil {
+			return nil, fmt.Errorf("server ID GTID '%s' tidak valid: %w", part, err)
+		}
+		seq, err := strconv.ParseUint(fields[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("sequence GTID '%s' tidak valid: %w", part, err)
+		}
+		positions[uint32(domain)] = seq
+	}
+	return positions, nil
+}
+
+// GTIDListLessOrEqual mengembalikan true jika posisi a tidak melewati posisi b
+// pada setiap domain (domain yang tidak ada pada b dianggap sequence 0).
+func GTIDListLessOrEqual(a, b string) (bool, error) {
+	posA, err := ParseGTIDList(a)
+	if err != nil {
+		return false, err
+	}
+	posB, err := ParseGTIDList(b)
+	if err != nil {
+		return false, err
+	}
+
+	for domain, seq := range posA {
+		if seq > posB[domain] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
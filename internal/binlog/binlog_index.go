@@ -0,0 +1,84 @@
+// File : internal/binlog/binlog_index.go
+// Deskripsi : Lokasi direktori arsip binlog serta baca/tulis file index arsip
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package binlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// indexFileName adalah nama file index pada setiap direktori arsip binlog
+const indexFileName = "index.json"
+
+// ArchiveDir mengembalikan direktori arsip binlog untuk satu server: <baseDir>/binlogs/<host>_<port>
+func ArchiveDir(baseDir, host string, port int) string {
+	safeHost := strings.NewReplacer("/", "_", "\\", "_", ":", "_").Replace(host)
+	return filepath.Join(baseDir, "binlogs", fmt.Sprintf("%s_%d", safeHost, port))
+}
+
+// LoadIndex membaca index arsip dari direktori arsip.
+// Jika index belum ada, dikembalikan index kosong tanpa error.
+func LoadIndex(dir string) (*ArchiveIndex, error) {
+	data, err := os.ReadFile(filepath.Join(dir, indexFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &ArchiveIndex{}, nil
+		}
+		return nil, fmt.Errorf("gagal membaca index arsip binlog: %w", err)
+	}
+
+	var index ArchiveIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("gagal parse index arsip binlog: %w", err)
+	}
+	index.sortEntries()
+	return &index, nil
+}
+
+// SaveIndex menulis index arsip secara atomik (tulis ke file sementara lalu rename)
+func SaveIndex(dir string, index *ArchiveIndex) error {
+	index.sortEntries()
+	index.UpdatedAt = time.Now()
+
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("gagal marshal index arsip binlog: %w", err)
+	}
+
+	indexPath := filepath.Join(dir, indexFileName)
+	tmpPath := indexPath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("gagal menulis index arsip binlog: %w", err)
+	}
+	if err := os.Rename(tmpPath, indexPath); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("gagal menyimpan index arsip binlog: %w", err)
+	}
+	return nil
+}
+
+// Has mengembalikan true jika binlog dengan nama tersebut sudah diarsipkan
+func (idx *ArchiveIndex) Has(name string) bool {
+	for _, entry := range idx.Entries {
+		if entry.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// sortEntries mengurutkan entry berdasarkan nama binlog (nomor urut binlog selalu zero-padded)
+func (idx *ArchiveIndex) sortEntries() {
+	sort.Slice(idx.Entries, func(i, j int) bool {
+		return idx.Entries[i].Name < idx.Entries[j].Name
+	})
+}
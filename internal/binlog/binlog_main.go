@@ -0,0 +1,34 @@
+// File : internal/binlog/binlog_main.go
+// Deskripsi : Service utama untuk pengarsipan binary log MariaDB/MySQL
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package binlog
+
+import (
+	"sfDBTools/internal/appconfig"
+	"sfDBTools/internal/applog"
+	"sfDBTools/internal/structs"
+	"sfDBTools/pkg/database"
+)
+
+// Service adalah service untuk pengarsipan binlog
+type Service struct {
+	Logger         applog.Logger
+	Config         *appconfig.Config
+	ArchiveOptions *structs.BinlogArchiveFlags
+	Client         *database.Client // Client database aktif selama pengarsipan
+}
+
+// NewService membuat instance baru dari Service
+func NewService(logger applog.Logger, config *appconfig.Config, opts *structs.BinlogArchiveFlags) *Service {
+	if opts == nil {
+		opts = &structs.BinlogArchiveFlags{}
+	}
+	return &Service{
+		Logger:         logger,
+		Config:         config,
+		ArchiveOptions: opts,
+	}
+}
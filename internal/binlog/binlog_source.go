@@ -0,0 +1,193 @@
+// File : internal/binlog/binlog_source.go
+// Deskripsi : Daftar binlog di server, pembacaan Gtid_list, dan pembukaan sumber binlog (lokal atau remote)
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package binlog
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sfDBTools/pkg/database"
+	"strconv"
+	"strings"
+)
+
+// binlogNamePattern membatasi nama binlog yang boleh dipakai pada query dan path file
+var binlogNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// listBinaryLogs menjalankan SHOW BINARY LOGS dan mengembalikan daftar binlog sesuai urutan server.
+// Jumlah kolom berbeda antar versi (Log_name, File_size[, Encrypted]) sehingga hasil dibaca secara generik.
+func (s *Service) listBinaryLogs(ctx context.Context) ([]binaryLog, error) {
+	rows, err := s.Client.DB().QueryContext(ctx, "SHOW BINARY LOGS")
+	if err != nil {
+		return nil, fmt.Errorf("gagal menjalankan SHOW BINARY LOGS (pastikan log_bin aktif): %w", err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca kolom SHOW BINARY LOGS: %w", err)
+	}
+
+	var logs []binaryLog
+	for rows.Next() {
+		values := make([]sql.RawBytes, len(columns))
+		dest := make([]interface{}, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("gagal membaca hasil SHOW BINARY LOGS: %w", err)
+		}
+
+		log := binaryLog{Name: string(values[0])}
+		if len(values) > 1 {
+			log.Size, _ = strconv.ParseInt(string(values[1]), 10, 64)
+		}
+		logs = append(logs, log)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("gagal membaca hasil SHOW BINARY LOGS: %w", err)
+	}
+
+	return logs, nil
+}
+
+// readStartGTIDList membaca event Gtid_list di awal binlog, yaitu posisi GTID sebelum event pertama binlog tersebut
+func (s *Service) readStartGTIDList(ctx context.Context, name string) (string, error) {
+	if !binlogNamePattern.MatchString(name) {
+		return "", fmt.Errorf("nama binlog tidak valid: %s", name)
+	}
+
+	rows, err := s.Client.DB().QueryContext(ctx, fmt.Sprintf("SHOW BINLOG EVENTS IN '%s' LIMIT 5", name))
+	if err != nil {
+		return "", fmt.Errorf("gagal menjalankan SHOW BINLOG EVENTS: %w", err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+	eventTypeIdx, infoIdx := -1, -1
+	for i, col := range columns {
+		switch strings.ToLower(col) {
+		case "event_type":
+			eventTypeIdx = i
+		case "info":
+			infoIdx = i
+		}
+	}
+	if eventTypeIdx < 0 || infoIdx < 0 {
+		return "", fmt.Errorf("format SHOW BINLOG EVENTS tidak dikenali")
+	}
+
+	for rows.Next() {
+		values := make([]sql.RawBytes, len(columns))
+		dest := make([]interface{}, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return "", err
+		}
+		if string(values[eventTypeIdx]) == "Gtid_list" {
+			return strings.Trim(strings.TrimSpace(string(values[infoIdx])), "[]"), nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+
+	return "", fmt.Errorf("event Gtid_list tidak ditemukan (server tidak menggunakan GTID MariaDB)")
+}
+
+// openBinlogSource membuka isi binlog sesuai --source.
+// "local" membaca dari --binlog-dir, "remote" mengunduh melalui mysqlbinlog --read-from-remote-server,
+// "auto" mencoba lokal terlebih dahulu lalu remote.
+func (s *Service) openBinlogSource(ctx context.Context, name string) (io.ReadCloser, string, error) {
+	if !binlogNamePattern.MatchString(name) {
+		return nil, "", fmt.Errorf("nama binlog tidak valid: %s", name)
+	}
+
+	opts := s.ArchiveOptions
+	if opts.Source != "remote" && opts.BinlogDir != "" {
+		file, err := os.Open(filepath.Join(opts.BinlogDir, name))
+		if err == nil {
+			return file, "local", nil
+		}
+		if opts.Source == "local" {
+			return nil, "", fmt.Errorf("gagal membuka binlog lokal: %w", err)
+		}
+		s.Logger.Debugf("Binlog %s tidak tersedia secara lokal (%v), menggunakan mysqlbinlog remote", name, err)
+	}
+
+	reader, err := s.fetchRemoteBinlog(ctx, name)
+	if err != nil {
+		return nil, "", err
+	}
+	return reader, "remote", nil
+}
+
+// fetchRemoteBinlog mengunduh satu binlog mentah dari server ke direktori sementara menggunakan mysqlbinlog.
+// File sementara dihapus saat reader ditutup.
+func (s *Service) fetchRemoteBinlog(ctx context.Context, name string) (io.ReadCloser, error) {
+	tmpDir, err := os.MkdirTemp(s.tempDirectory(), "sfdb-binlog-")
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat direktori sementara: %w", err)
+	}
+
+	args := database.BuildClientArgs(s.ArchiveOptions.DBConfig.ServerDBConnection)
+	args = append(args, "--read-from-remote-server", "--raw", "--result-file="+tmpDir+string(os.PathSeparator), name)
+
+	var stderr bytes.Buffer
+	cmd, cleanup, err := database.ClientCommand(ctx, s.ArchiveOptions.DBConfig.ServerDBConnection, "mysqlbinlog", args...)
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		return nil, err
+	}
+	cmd.Stderr = &stderr
+	err = cmd.Run()
+	cleanup()
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		return nil, fmt.Errorf("mysqlbinlog gagal mengunduh %s: %w (%s)", name, err, strings.TrimSpace(stderr.String()))
+	}
+
+	file, err := os.Open(filepath.Join(tmpDir, name))
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		return nil, fmt.Errorf("gagal membuka binlog hasil unduhan: %w", err)
+	}
+	return &tempFileReader{File: file, dir: tmpDir}, nil
+}
+
+// tempDirectory mengembalikan direktori sementara dari konfigurasi, atau default sistem
+func (s *Service) tempDirectory() string {
+	if s.Config != nil && s.Config.Backup.Output.TempDirectory != "" {
+		if err := os.MkdirAll(s.Config.Backup.Output.TempDirectory, 0755); err == nil {
+			return s.Config.Backup.Output.TempDirectory
+		}
+	}
+	return ""
+}
+
+// tempFileReader adalah file sementara yang direktorinya dihapus saat ditutup
+type tempFileReader struct {
+	*os.File
+	dir string
+}
+
+func (t *tempFileReader) Close() error {
+	err := t.File.Close()
+	os.RemoveAll(t.dir)
+	return err
+}
@@ -0,0 +1,36 @@
+// File : internal/binlog/binlog_structs.go
+// Deskripsi : Struktur data untuk arsip binlog dan index-nya
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package binlog
+
+import "time"
+
+// ArchiveIndex adalah isi file index.json pada direktori arsip binlog satu server
+type ArchiveIndex struct {
+	Server    string         `json:"server"` // host:port sumber binlog
+	UpdatedAt time.Time      `json:"updated_at"`
+	Entries   []ArchiveEntry `json:"entries"`
+}
+
+// ArchiveEntry adalah informasi satu file binlog yang sudah diarsipkan
+type ArchiveEntry struct {
+	Name         string    `json:"name"`          // Nama binlog di server (misal: mysql-bin.000123)
+	ArchiveFile  string    `json:"archive_file"`  // Nama file arsip (relatif terhadap direktori arsip)
+	OriginalSize int64     `json:"original_size"` // Ukuran binlog asli
+	ArchivedSize int64     `json:"archived_size"` // Ukuran file arsip setelah kompresi/enkripsi
+	SHA256       string    `json:"sha256"`        // SHA-256 file arsip
+	StartGTID    string    `json:"start_gtid"`    // Gtid_list di awal binlog (posisi GTID sebelum event pertama)
+	Compression  string    `json:"compression"`   // Jenis kompresi arsip
+	Encrypted    bool      `json:"encrypted"`     // Apakah arsip dienkripsi
+	Source       string    `json:"source"`        // Sumber binlog: local atau remote
+	ArchivedAt   time.Time `json:"archived_at"`
+}
+
+// binaryLog adalah satu baris hasil SHOW BINARY LOGS
+type binaryLog struct {
+	Name string
+	Size int64
+}
@@ -0,0 +1,252 @@
+// File : internal/daemon/daemon_jobs.go
+// Deskripsi : Definisi job terjadwal dan eksekusinya sebagai proses sfdbtools terpisah
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sfDBTools/internal/appconfig"
+	"sfDBTools/pkg/cron"
+	"sfDBTools/pkg/exitcode"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Tipe job yang didukung beserta sub-command sfdbtools yang dijalankan
+const (
+	jobTypeBackup  = "backup"
+	jobTypeCleanup = "cleanup"
+	jobTypeDBScan  = "dbscan"
+	jobTypeBinlog  = "binlog"
+)
+
+// implicitCleanupJob adalah nama job cleanup yang dibuat dari backup.retention.cleanup_schedule
+const implicitCleanupJob = "retention-cleanup"
+
+// job adalah satu job terjadwal yang sudah divalidasi
+type job struct {
+	Name     string
+	Type     string
+	Schedule *cron.Schedule
+	Args     []string // Argumen lengkap setelah nama executable
+	Timeout  time.Duration
+	Group    string // Grup antrean; job dalam grup yang sama dijalankan bergantian
+}
+
+// resolveJobs memvalidasi schedule.jobs dan menambahkan job cleanup dari retention.cleanup_schedule
+func (s *Service) resolveJobs() ([]*job, error) {
+	var jobs []*job
+	names := make(map[string]bool)
+	hasCleanup := false
+
+	for i, jc := range s.Config.Schedule.Jobs {
+		if jc.Name == "" {
+			return nil, fmt.Errorf("schedule.jobs[%d]: name wajib diisi", i)
+		}
+		if names[jc.Name] {
+			return nil, fmt.Errorf("schedule.jobs: nama job '%s' duplikat", jc.Name)
+		}
+		names[jc.Name] = true
+		if jc.Type == jobTypeCleanup {
+			// Job cleanup yang dinonaktifkan tetap menggantikan job cleanup otomatis
+			hasCleanup = true
+		}
+		if !jc.Enabled {
+			continue
+		}
+		j, err := s.newJob(jc)
+		if err != nil {
+			return nil, fmt.Errorf("job '%s': %w", jc.Name, err)
+		}
+		jobs = append(jobs, j)
+	}
+
+	retention := s.Config.Backup.Retention
+	if !hasCleanup && retention.CleanupEnabled && scheduledCleanup(retention.CleanupSchedule) && !names[implicitCleanupJob] {
+		j, err := s.newJob(appconfig.ScheduleJobConfig{
+			Name:    implicitCleanupJob,
+			Enabled: true,
+			Type:    jobTypeCleanup,
+			Cron:    retention.CleanupSchedule,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("backup.retention.cleanup_schedule: %w", err)
+		}
+		jobs = append(jobs, j)
+	}
+	return jobs, nil
+}
+
+// scheduledCleanup menentukan apakah cleanup_schedule berupa jadwal (bukan "setiap sebelum backup")
+func scheduledCleanup(schedule string) bool {
+	switch strings.ToLower(strings.TrimSpace(schedule)) {
+	case "", "always", "before_backup":
+		return false
+	}
+	return true
+}
+
+// newJob membuat job dari konfigurasi
+func (s *Service) newJob(jc appconfig.ScheduleJobConfig) (*job, error) {
+	schedule, err := cron.Parse(jc.Cron)
+	if err != nil {
+		return nil, err
+	}
+
+	var args []string
+	switch jc.Type {
+	case jobTypeBackup:
+		if len(jc.Args) == 0 {
+			return nil, fmt.Errorf("job backup membutuhkan args, misalnya [all-databases, ...]")
+		}
+		args = []string{"backup"}
+	case jobTypeCleanup:
+		args = []string{"backup", "cleanup", "--cleanup"}
+		if s.Config.Backup.Output.BaseDirectory != "" {
+			args = append(args, "--output", s.Config.Backup.Output.BaseDirectory)
+		}
+	case jobTypeDBScan:
+		if len(jc.Args) == 0 {
+			return nil, fmt.Errorf("job dbscan membutuhkan args, misalnya [all, ...]")
+		}
+		args = []string{"dbscan"}
+	case jobTypeBinlog:
+		args = []string{"binlog", "archive"}
+	default:
+		return nil, fmt.Errorf("type '%s' tidak dikenal (gunakan %s, %s, %s, atau %s)", jc.Type, jobTypeBackup, jobTypeCleanup, jobTypeDBScan, jobTypeBinlog)
+	}
+
+	return &job{
+		Name:     jc.Name,
+		Type:     jc.Type,
+		Schedule: schedule,
+		Args:     append(args, jc.Args...),
+		Timeout:  time.Duration(jc.Timeout) * time.Second,
+		Group:    lockGroup(jc.Type, jc.Name),
+	}, nil
+}
+
+// lockGroup mengelompokkan job yang memakai lock yang sama: backup dan cleanup berbagi lock backup,
+// semua dbscan berbagi lock scan. Job lain (binlog archive) berjalan di antreannya sendiri sehingga
+// tidak tertunda oleh backup yang berjalan berjam-jam.
+func lockGroup(jobType, name string) string {
+	switch jobType {
+	case jobTypeBackup, jobTypeCleanup:
+		return jobTypeBackup
+	case jobTypeDBScan:
+		return jobTypeDBScan
+	default:
+		return "job:" + name
+	}
+}
+
+// runJob menjalankan job sebagai proses sfdbtools terpisah dan mencatat hasilnya ke state.
+// Saat ctx dibatalkan (SIGTERM), proses job menerima SIGTERM dan diberi waktu shutdown_timeout sebelum di-kill.
+func (s *Service) runJob(ctx context.Context, j *job, scheduled time.Time, state *stateStore) {
+	executable, err := os.Executable()
+	if err != nil {
+		s.recordResult(state, j, time.Now(), -1, fmt.Errorf("gagal menentukan executable: %w", err), "")
+		return
+	}
+
+	logFile := filepath.Join(s.daemonDir(), "jobs", j.Name+".log")
+	out, err := openJobLog(logFile)
+	if err != nil {
+		s.recordResult(state, j, time.Now(), -1, err, "")
+		return
+	}
+	defer out.Close()
+
+	jobCtx := ctx
+	if j.Timeout > 0 {
+		var cancel context.CancelFunc
+		jobCtx, cancel = context.WithTimeout(ctx, j.Timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(jobCtx, executable, j.Args...)
+	cmd.Env = append(os.Environ(), "SFDB_SCHEDULED_JOB="+j.Name)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	cmd.Cancel = func() error { return cmd.Process.Signal(syscall.SIGTERM) }
+	cmd.WaitDelay = s.shutdownTimeout()
+
+	start := time.Now()
+	fmt.Fprintf(out, "\n===== %s job %s (jadwal %s): %s %s =====\n",
+		start.Format(time.RFC3339), j.Name, scheduled.Format(time.RFC3339), filepath.Base(executable), strings.Join(j.Args, " "))
+	if err := state.update(j.Name, func(js *jobState) {
+		js.LastStart = start
+		js.LastStatus = "running"
+		js.LastLogFile = logFile
+	}); err != nil {
+		s.Logger.Warnf("Gagal menyimpan state daemon: %v", err)
+	}
+	s.Logger.Infof("Job %s dimulai (jadwal %s)", j.Name, scheduled.Format(time.RFC3339))
+
+	runErr := cmd.Run()
+	exitCode := 0
+	if runErr != nil {
+		exitCode = -1
+		var exitErr *exec.ExitError
+		if errors.As(runErr, &exitErr) {
+			exitCode = exitErr.ExitCode()
+		}
+		if errors.Is(jobCtx.Err(), context.DeadlineExceeded) {
+			runErr = fmt.Errorf("melebihi timeout %s: %w", j.Timeout, runErr)
+		} else if ctx.Err() != nil {
+			runErr = fmt.Errorf("dihentikan karena daemon berhenti: %w", runErr)
+		}
+	}
+	fmt.Fprintf(out, "===== %s job %s selesai dalam %s, exit code %d =====\n",
+		time.Now().Format(time.RFC3339), j.Name, time.Since(start).Round(time.Second), exitCode)
+	s.recordResult(state, j, start, exitCode, runErr, logFile)
+}
+
+// recordResult mencatat hasil job ke log daemon dan state
+func (s *Service) recordResult(state *stateStore, j *job, start time.Time, exitCode int, runErr error, logFile string) {
+	status := "success"
+	errMsg := ""
+	if runErr != nil {
+		// Exit code subprocess mengikuti skema pkg/exitcode (2 = sebagian, 6 = lock, dll)
+		status = "failed"
+		if exitCode > 0 {
+			status = exitcode.Status(exitCode)
+		}
+		errMsg = runErr.Error()
+		s.Logger.Errorf("Job %s gagal setelah %s: %v (log: %s)", j.Name, time.Since(start).Round(time.Second), runErr, logFile)
+	} else {
+		s.Logger.Infof("Job %s selesai dalam %s", j.Name, time.Since(start).Round(time.Second))
+	}
+	if err := state.update(j.Name, func(js *jobState) {
+		js.LastEnd = time.Now()
+		js.LastStatus = status
+		js.LastExitCode = exitCode
+		js.LastError = errMsg
+		if logFile != "" {
+			js.LastLogFile = logFile
+		}
+	}); err != nil {
+		s.Logger.Warnf("Gagal menyimpan state daemon: %v", err)
+	}
+}
+
+// openJobLog membuka file log job untuk ditambahkan
+func openJobLog(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("gagal membuat direktori log job: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuka log job %s: %w", path, err)
+	}
+	return file, nil
+}
@@ -0,0 +1,53 @@
+// File : internal/daemon/daemon_main.go
+// Deskripsi : Service daemon penjadwal job sfDBTools (backup, cleanup, dbscan, arsip binlog)
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package daemon
+
+import (
+	"sfDBTools/internal/appconfig"
+	"sfDBTools/internal/applog"
+	"time"
+)
+
+const (
+	// defaultMisfireGrace dipakai jika schedule.misfire_grace tidak diisi
+	defaultMisfireGrace = time.Hour
+	// defaultShutdownTimeout dipakai jika schedule.shutdown_timeout tidak diisi
+	defaultShutdownTimeout = 5 * time.Minute
+)
+
+// Service adalah daemon yang menjalankan job dari bagian 'schedule' pada config
+type Service struct {
+	Logger applog.Logger
+	Config *appconfig.Config
+}
+
+// NewService membuat instance baru dari Service
+func NewService(logger applog.Logger, config *appconfig.Config) *Service {
+	return &Service{
+		Logger: logger,
+		Config: config,
+	}
+}
+
+// daemonDir mengembalikan direktori lock, state, dan log job daemon (di bawah direktori log)
+func (s *Service) daemonDir() string {
+	return s.Config.StateDir("daemon")
+}
+
+func (s *Service) misfireGrace() time.Duration {
+	if s.Config.Schedule.MisfireGrace > 0 {
+		return time.Duration(s.Config.Schedule.MisfireGrace) * time.Second
+	}
+	return defaultMisfireGrace
+}
+
+func (s *Service) shutdownTimeout() time.Duration {
+	if s.Config.Schedule.ShutdownTimeout > 0 {
+		return time.Duration(s.Config.Schedule.ShutdownTimeout) * time.Second
+	}
+	return defaultShutdownTimeout
+}
@@ -0,0 +1,290 @@
+// File : internal/daemon/daemon_scheduler.go
+// Deskripsi : Loop penjadwal daemon: perhitungan jadwal, penanganan misfire, pencegahan overlap, dan shutdown
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"sfDBTools/pkg/exitcode"
+	"sfDBTools/pkg/fs"
+	"sfDBTools/pkg/ui"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxMisfireScan membatasi iterasi saat mencari jadwal terakhir yang terlewat
+const maxMisfireScan = 100000
+
+// fire adalah satu jadwal job yang siap dijalankan
+type fire struct {
+	job       *job
+	scheduled time.Time
+}
+
+// scheduler menyimpan antrean job per grup lock. Job dalam grup yang sama (misalnya backup dan
+// cleanup) dijalankan satu per satu, sedangkan grup berbeda berjalan paralel.
+type scheduler struct {
+	svc     *Service
+	state   *stateStore
+	queues  map[string]chan fire // Antrean per job.Group
+	mu      sync.Mutex
+	pending map[string]bool // Job yang sedang antre atau berjalan
+	run     func(ctx context.Context, j *job, scheduled time.Time, state *stateStore)
+}
+
+// newScheduler membuat scheduler dengan satu antrean per grup lock. Buffer antrean sebesar jumlah job
+// dalam grup karena setiap job paling banyak satu kali antre.
+func newScheduler(s *Service, state *stateStore, jobs []*job) *scheduler {
+	sch := &scheduler{
+		svc:     s,
+		state:   state,
+		queues:  make(map[string]chan fire),
+		pending: make(map[string]bool),
+		run:     s.runJob,
+	}
+	groupSize := make(map[string]int)
+	for _, j := range jobs {
+		groupSize[j.Group]++
+	}
+	for group, size := range groupSize {
+		sch.queues[group] = make(chan fire, size)
+	}
+	return sch
+}
+
+// Run menjalankan daemon sampai ctx dibatalkan (SIGINT/SIGTERM).
+// Jika list true, hanya menampilkan daftar job beserta jadwal berikutnya lalu keluar.
+func (s *Service) Run(ctx context.Context, list bool) error {
+	jobs, err := s.resolveJobs()
+	if err != nil {
+		return exitcode.AsConfigError(fmt.Errorf("konfigurasi schedule tidak valid: %w", err))
+	}
+	loc, err := s.location()
+	if err != nil {
+		return err
+	}
+	state, err := loadState(s.daemonDir())
+	if err != nil {
+		return err
+	}
+
+	if list {
+		s.showJobs(jobs, state, time.Now().In(loc))
+		return nil
+	}
+	if len(jobs) == 0 {
+		return fmt.Errorf("tidak ada job terjadwal; isi schedule.jobs atau backup.retention.cleanup_schedule pada config")
+	}
+
+	lock, err := fs.AcquireLock(ctx, s.daemonDir(), "daemon", false, 0)
+	if err != nil {
+		return fmt.Errorf("gagal menjalankan daemon: %w", err)
+	}
+	defer lock.Release()
+	if lock.StalePID > 0 {
+		s.Logger.Warnf("Daemon sebelumnya (PID %d) berhenti tanpa melepas lock", lock.StalePID)
+	}
+
+	sch := newScheduler(s, state, jobs)
+	var wg sync.WaitGroup
+	for _, queue := range sch.queues {
+		wg.Add(1)
+		go func(queue chan fire) {
+			defer wg.Done()
+			sch.work(ctx, queue)
+		}(queue)
+	}
+
+	s.Logger.Infof("Daemon berjalan dengan %d job (zona waktu %s, misfire grace %s)", len(jobs), loc, s.misfireGrace())
+	sch.loop(ctx, jobs, loc)
+
+	s.Logger.Infof("Daemon menerima sinyal berhenti, menunggu job yang sedang berjalan (maksimal %s)...", s.shutdownTimeout())
+	for _, queue := range sch.queues {
+		close(queue)
+	}
+	wg.Wait()
+	s.Logger.Info("Daemon berhenti")
+	return nil
+}
+
+// location mengembalikan zona waktu jadwal dari schedule.timezone (kosong = zona waktu lokal)
+func (s *Service) location() (*time.Location, error) {
+	if s.Config.Schedule.Timezone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(s.Config.Schedule.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("schedule.timezone '%s' tidak valid: %w", s.Config.Schedule.Timezone, err)
+	}
+	return loc, nil
+}
+
+// loop menghitung jadwal berikutnya setiap job dan memasukkannya ke antrean saat waktunya tiba
+func (sch *scheduler) loop(ctx context.Context, jobs []*job, loc *time.Location) {
+	grace := sch.svc.misfireGrace()
+	now := time.Now().In(loc)
+	next := make(map[string]time.Time, len(jobs))
+
+	for _, j := range jobs {
+		sch.handleMisfire(j, now, grace)
+		next[j.Name] = j.Schedule.Next(now)
+		if next[j.Name].IsZero() {
+			sch.svc.Logger.Warnf("Job %s tidak memiliki jadwal berikutnya (cron '%s')", j.Name, j.Schedule.Expr)
+		}
+	}
+
+	for {
+		var earliest time.Time
+		for _, t := range next {
+			if !t.IsZero() && (earliest.IsZero() || t.Before(earliest)) {
+				earliest = t
+			}
+		}
+		if earliest.IsZero() {
+			// Tidak ada jadwal lagi; tetap hidup sampai dihentikan
+			<-ctx.Done()
+			return
+		}
+
+		timer := time.NewTimer(time.Until(earliest))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		now = time.Now().In(loc)
+		for _, j := range jobs {
+			due := next[j.Name]
+			if due.IsZero() || due.After(now) {
+				continue
+			}
+			next[j.Name] = j.Schedule.Next(now)
+			// Jadwal bisa sangat terlambat jika sistem sempat suspend
+			if late := now.Sub(due); late > grace {
+				sch.svc.Logger.Warnf("Jadwal %s job %s terlambat %s (melebihi misfire grace), dilewati",
+					due.Format(time.RFC3339), j.Name, late.Round(time.Second))
+				sch.markSkipped(j, due, next[j.Name], "melebihi misfire grace")
+				continue
+			}
+			sch.enqueue(j, due, next[j.Name])
+		}
+	}
+}
+
+// handleMisfire menjalankan sekali jadwal yang terlewat saat daemon mati jika masih dalam misfire grace
+func (sch *scheduler) handleMisfire(j *job, now time.Time, grace time.Duration) {
+	last := sch.state.get(j.Name).LastScheduled
+	if last.IsZero() {
+		// Job baru: mulai dari jadwal berikutnya
+		return
+	}
+
+	missed := j.Schedule.Next(last.In(now.Location()))
+	if missed.IsZero() || missed.After(now) {
+		return
+	}
+	// Cari jadwal terakhir yang terlewat; jadwal yang lebih lama digabung menjadi satu eksekusi
+	for i := 0; i < maxMisfireScan; i++ {
+		n := j.Schedule.Next(missed)
+		if n.IsZero() || n.After(now) {
+			break
+		}
+		missed = n
+	}
+
+	next := j.Schedule.Next(now)
+	if late := now.Sub(missed); late > grace {
+		sch.svc.Logger.Warnf("Jadwal %s job %s terlewat %s saat daemon tidak berjalan (melebihi misfire grace %s), dilewati",
+			missed.Format(time.RFC3339), j.Name, late.Round(time.Second), grace)
+		sch.markSkipped(j, missed, next, "terlewat saat daemon tidak berjalan")
+		return
+	}
+	sch.svc.Logger.Infof("Jadwal %s job %s terlewat saat daemon tidak berjalan, dijalankan sekarang", missed.Format(time.RFC3339), j.Name)
+	sch.enqueue(j, missed, next)
+}
+
+// enqueue memasukkan job ke antrean kecuali job yang sama masih antre atau berjalan
+func (sch *scheduler) enqueue(j *job, scheduled, next time.Time) {
+	sch.mu.Lock()
+	if sch.pending[j.Name] {
+		sch.mu.Unlock()
+		sch.svc.Logger.Warnf("Job %s masih berjalan atau antre, jadwal %s dilewati", j.Name, scheduled.Format(time.RFC3339))
+		sch.markSkipped(j, scheduled, next, "eksekusi sebelumnya belum selesai")
+		return
+	}
+	sch.pending[j.Name] = true
+	sch.mu.Unlock()
+
+	if err := sch.state.update(j.Name, func(js *jobState) {
+		js.LastScheduled = scheduled
+		js.NextScheduled = next
+	}); err != nil {
+		sch.svc.Logger.Warnf("Gagal menyimpan state daemon: %v", err)
+	}
+	// Setiap job paling banyak satu kali di antrean sehingga buffer sebesar jumlah job dalam grup tidak pernah penuh
+	sch.queues[j.Group] <- fire{job: j, scheduled: scheduled}
+}
+
+// markSkipped mencatat jadwal yang dilewati ke state tanpa menimpa hasil eksekusi sebelumnya
+func (sch *scheduler) markSkipped(j *job, scheduled, next time.Time, reason string) {
+	if err := sch.state.update(j.Name, func(js *jobState) {
+		js.LastScheduled = scheduled
+		js.NextScheduled = next
+		if js.LastStatus != "running" {
+			js.LastStatus = "skipped"
+			js.LastError = reason
+		}
+	}); err != nil {
+		sch.svc.Logger.Warnf("Gagal menyimpan state daemon: %v", err)
+	}
+}
+
+// work menjalankan job dari antrean satu grup satu per satu; job yang masih antre saat shutdown dibuang
+func (sch *scheduler) work(ctx context.Context, queue chan fire) {
+	for f := range queue {
+		if ctx.Err() == nil {
+			sch.run(ctx, f.job, f.scheduled, sch.state)
+		} else {
+			sch.svc.Logger.Infof("Job %s (jadwal %s) batal dijalankan karena daemon berhenti", f.job.Name, f.scheduled.Format(time.RFC3339))
+		}
+		sch.mu.Lock()
+		delete(sch.pending, f.job.Name)
+		sch.mu.Unlock()
+	}
+}
+
+// showJobs menampilkan daftar job, jadwal berikutnya, dan hasil eksekusi terakhir
+func (s *Service) showJobs(jobs []*job, state *stateStore, now time.Time) {
+	if len(jobs) == 0 {
+		ui.PrintWarning("Tidak ada job terjadwal; isi schedule.jobs atau backup.retention.cleanup_schedule pada config.")
+		return
+	}
+
+	ui.PrintHeader("JOB TERJADWAL")
+	headers := []string{"Job", "Tipe", "Cron", "Berikutnya", "Terakhir", "Status", "Perintah"}
+	var rows [][]string
+	for _, j := range jobs {
+		js := state.get(j.Name)
+		nextRun := "-"
+		if next := j.Schedule.Next(now); !next.IsZero() {
+			nextRun = next.Format("2006-01-02 15:04")
+		}
+		lastRun, status := "-", "-"
+		if !js.LastStart.IsZero() {
+			lastRun = js.LastStart.In(now.Location()).Format("2006-01-02 15:04")
+		}
+		if js.LastStatus != "" {
+			status = ui.GetStatusIcon(js.LastStatus) + " " + js.LastStatus
+		}
+		rows = append(rows, []string{j.Name, j.Type, j.Schedule.Expr, nextRun, lastRun, status, strings.Join(j.Args, " ")})
+	}
+	ui.FormatTable(headers, rows)
+	ui.PrintInfo(fmt.Sprintf("State dan log job: %s", s.daemonDir()))
+}
@@ -0,0 +1,292 @@
+package daemon
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"sfDBTools/internal/appconfig"
+	"sfDBTools/internal/applog"
+	"sfDBTools/pkg/cron"
+)
+
+func testJob(t *testing.T, name, jobType, expr string) *job {
+	t.Helper()
+	schedule, err := cron.Parse(expr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &job{Name: name, Type: jobType, Schedule: schedule, Group: lockGroup(jobType, name)}
+}
+
+func newTestScheduler(t *testing.T, jobs ...*job) *scheduler {
+	t.Helper()
+	state, err := loadState(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newScheduler(NewService(applog.MockLogger(), &appconfig.Config{}), state, jobs)
+}
+
+func clock(t *testing.T, value string) time.Time {
+	t.Helper()
+	ts, err := time.Parse("2006-01-02 15:04", value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ts
+}
+
+// queued mengambil semua jadwal yang ada di antrean grup tanpa menunggu
+func queued(sch *scheduler, group string) []time.Time {
+	var times []time.Time
+	for {
+		select {
+		case f := <-sch.queues[group]:
+			times = append(times, f.scheduled)
+		default:
+			return times
+		}
+	}
+}
+
+func TestLockGroup(t *testing.T) {
+	for _, tt := range []struct{ jobType, name, want string }{
+		{jobTypeBackup, "backup-malam", "backup"},
+		{jobTypeCleanup, "cleanup", "backup"},
+		{jobTypeDBScan, "scan", "dbscan"},
+		{jobTypeBinlog, "binlog-db01", "job:binlog-db01"},
+	} {
+		if got := lockGroup(tt.jobType, tt.name); got != tt.want {
+			t.Errorf("lockGroup(%s, %s) = %s, ingin %s", tt.jobType, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHandleMisfire(t *testing.T) {
+	tests := []struct {
+		name       string
+		expr       string
+		prev       jobState // State sebelum daemon start; LastScheduled nol berarti job baru
+		now        string
+		grace      time.Duration
+		wantQueued []string
+		wantState  jobState // Hanya field jadwal, status, dan error yang dibandingkan
+	}{
+		{
+			name:  "job baru tidak dijalankan saat start",
+			expr:  "0 1 * * *",
+			now:   "2026-10-16 01:30",
+			grace: time.Hour,
+		},
+		{
+			name:      "tidak ada jadwal terlewat",
+			expr:      "0 1 * * *",
+			prev:      jobState{LastScheduled: clock(t, "2026-10-16 01:00"), LastStatus: "success"},
+			now:       "2026-10-16 01:30",
+			grace:     time.Hour,
+			wantState: jobState{LastScheduled: clock(t, "2026-10-16 01:00"), LastStatus: "success"},
+		},
+		{
+			name:       "jadwal terlewat dalam grace dijalankan sekali",
+			expr:       "0 1 * * *",
+			prev:       jobState{LastScheduled: clock(t, "2026-10-15 01:00"), LastStatus: "success"},
+			now:        "2026-10-16 01:30",
+			grace:      time.Hour,
+			wantQueued: []string{"2026-10-16 01:00"},
+			wantState: jobState{LastScheduled: clock(t, "2026-10-16 01:00"), NextScheduled: clock(t, "2026-10-17 01:00"),
+				LastStatus: "success"},
+		},
+		{
+			name:       "beberapa jadwal terlewat digabung menjadi jadwal terakhir",
+			expr:       "0 * * * *",
+			prev:       jobState{LastScheduled: clock(t, "2026-10-16 10:00"), LastStatus: "success"},
+			now:        "2026-10-16 13:20",
+			grace:      time.Hour,
+			wantQueued: []string{"2026-10-16 13:00"},
+			wantState: jobState{LastScheduled: clock(t, "2026-10-16 13:00"), NextScheduled: clock(t, "2026-10-16 14:00"),
+				LastStatus: "success"},
+		},
+		{
+			name:  "jadwal terlewat melebihi grace dilewati",
+			expr:  "0 1 * * *",
+			prev:  jobState{LastScheduled: clock(t, "2026-10-14 01:00"), LastStatus: "success"},
+			now:   "2026-10-16 05:00",
+			grace: time.Hour,
+			wantState: jobState{LastScheduled: clock(t, "2026-10-16 01:00"), NextScheduled: clock(t, "2026-10-17 01:00"),
+				LastStatus: "skipped", LastError: "terlewat saat daemon tidak berjalan"},
+		},
+		{
+			name:  "status running tidak ditimpa saat dilewati",
+			expr:  "0 1 * * *",
+			prev:  jobState{LastScheduled: clock(t, "2026-10-15 01:00"), LastStatus: "running"},
+			now:   "2026-10-16 05:00",
+			grace: time.Hour,
+			wantState: jobState{LastScheduled: clock(t, "2026-10-16 01:00"), NextScheduled: clock(t, "2026-10-17 01:00"),
+				LastStatus: "running"},
+		},
+		{
+			name:       "batas grace masih dijalankan",
+			expr:       "0 1 * * *",
+			prev:       jobState{LastScheduled: clock(t, "2026-10-15 01:00")},
+			now:        "2026-10-16 02:00",
+			grace:      time.Hour,
+			wantQueued: []string{"2026-10-16 01:00"},
+			wantState:  jobState{LastScheduled: clock(t, "2026-10-16 01:00"), NextScheduled: clock(t, "2026-10-17 01:00")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := testJob(t, "backup-malam", jobTypeBackup, tt.expr)
+			sch := newTestScheduler(t, j)
+			if !tt.prev.LastScheduled.IsZero() {
+				if err := sch.state.update(j.Name, func(js *jobState) { *js = tt.prev }); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			sch.handleMisfire(j, clock(t, tt.now), tt.grace)
+
+			var got []string
+			for _, ts := range queued(sch, j.Group) {
+				got = append(got, ts.Format("2006-01-02 15:04"))
+			}
+			if len(got) != len(tt.wantQueued) || (len(got) > 0 && got[0] != tt.wantQueued[0]) {
+				t.Errorf("antrean = %v, ingin %v", got, tt.wantQueued)
+			}
+
+			js := sch.state.get(j.Name)
+			if !js.LastScheduled.Equal(tt.wantState.LastScheduled) || !js.NextScheduled.Equal(tt.wantState.NextScheduled) ||
+				js.LastStatus != tt.wantState.LastStatus || js.LastError != tt.wantState.LastError {
+				t.Errorf("state = %+v, ingin %+v", js, tt.wantState)
+			}
+		})
+	}
+}
+
+func TestEnqueueSkipsPendingJob(t *testing.T) {
+	j := testJob(t, "backup-malam", jobTypeBackup, "0 * * * *")
+	sch := newTestScheduler(t, j)
+
+	sch.enqueue(j, clock(t, "2026-10-16 01:00"), clock(t, "2026-10-16 02:00"))
+	sch.enqueue(j, clock(t, "2026-10-16 02:00"), clock(t, "2026-10-16 03:00"))
+
+	if got := queued(sch, j.Group); len(got) != 1 || !got[0].Equal(clock(t, "2026-10-16 01:00")) {
+		t.Fatalf("antrean = %v, ingin hanya jadwal 01:00", got)
+	}
+	js := sch.state.get(j.Name)
+	if js.LastStatus != "skipped" || js.LastError != "eksekusi sebelumnya belum selesai" || !js.LastScheduled.Equal(clock(t, "2026-10-16 02:00")) {
+		t.Errorf("state jadwal yang dilewati = %+v", js)
+	}
+
+	// Setelah eksekusi selesai, jadwal berikutnya boleh antre lagi
+	sch.mu.Lock()
+	delete(sch.pending, j.Name)
+	sch.mu.Unlock()
+	sch.enqueue(j, clock(t, "2026-10-16 03:00"), clock(t, "2026-10-16 04:00"))
+	if got := queued(sch, j.Group); len(got) != 1 {
+		t.Errorf("antrean setelah selesai = %v, ingin 1 jadwal", got)
+	}
+}
+
+// TestWorkSerializesPerGroup memastikan job dalam grup lock yang sama berjalan bergantian,
+// sedangkan grup lain tetap berjalan paralel.
+func TestWorkSerializesPerGroup(t *testing.T) {
+	backupJob := testJob(t, "backup-malam", jobTypeBackup, "0 1 * * *")
+	cleanupJob := testJob(t, "cleanup", jobTypeCleanup, "0 1 * * *")
+	scanJob := testJob(t, "scan", jobTypeDBScan, "0 1 * * *")
+	sch := newTestScheduler(t, backupJob, cleanupJob, scanJob)
+
+	if len(sch.queues) != 2 || cap(sch.queues["backup"]) != 2 || cap(sch.queues["dbscan"]) != 1 {
+		t.Fatalf("antrean per grup tidak sesuai: %d grup", len(sch.queues))
+	}
+
+	var mu sync.Mutex
+	running := make(map[string]int) // Jumlah job yang sedang berjalan per grup
+	maxRunning := make(map[string]int)
+	started := make(chan string, 3)
+	release := make(chan struct{})
+	sch.run = func(ctx context.Context, j *job, scheduled time.Time, state *stateStore) {
+		mu.Lock()
+		running[j.Group]++
+		if running[j.Group] > maxRunning[j.Group] {
+			maxRunning[j.Group] = running[j.Group]
+		}
+		mu.Unlock()
+		started <- j.Name
+		<-release
+		mu.Lock()
+		running[j.Group]--
+		mu.Unlock()
+	}
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for _, queue := range sch.queues {
+		wg.Add(1)
+		go func(queue chan fire) {
+			defer wg.Done()
+			sch.work(ctx, queue)
+		}(queue)
+	}
+
+	due := clock(t, "2026-10-16 01:00")
+	next := clock(t, "2026-10-17 01:00")
+	sch.enqueue(backupJob, due, next)
+	sch.enqueue(cleanupJob, due, next)
+	sch.enqueue(scanJob, due, next)
+
+	// Backup dan dbscan mulai bersamaan; cleanup menunggu backup selesai
+	first := map[string]bool{<-started: true, <-started: true}
+	if !first["backup-malam"] || !first["scan"] {
+		t.Fatalf("job yang mulai lebih dulu = %v, ingin backup-malam dan scan", first)
+	}
+	select {
+	case name := <-started:
+		t.Fatalf("%s mulai sebelum job dalam grup yang sama selesai", name)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Selama berjalan, jadwal baru job yang sama dilewati
+	sch.enqueue(backupJob, next, next.Add(24*time.Hour))
+	if js := sch.state.get(backupJob.Name); js.LastStatus != "skipped" {
+		t.Errorf("jadwal backup saat masih berjalan tidak dilewati: %+v", js)
+	}
+
+	close(release)
+	if name := <-started; name != "cleanup" {
+		t.Fatalf("job berikutnya = %s, ingin cleanup", name)
+	}
+	for _, queue := range sch.queues {
+		close(queue)
+	}
+	wg.Wait()
+
+	if maxRunning["backup"] != 1 || maxRunning["dbscan"] != 1 {
+		t.Errorf("maksimal job paralel per grup = %v, ingin 1", maxRunning)
+	}
+	if len(sch.pending) != 0 {
+		t.Errorf("pending tidak dibersihkan setelah job selesai: %v", sch.pending)
+	}
+}
+
+func TestWorkDropsQueuedJobsOnShutdown(t *testing.T) {
+	j := testJob(t, "backup-malam", jobTypeBackup, "0 1 * * *")
+	sch := newTestScheduler(t, j)
+	sch.run = func(ctx context.Context, j *job, scheduled time.Time, state *stateStore) {
+		t.Errorf("job %s dijalankan setelah daemon berhenti", j.Name)
+	}
+
+	sch.enqueue(j, clock(t, "2026-10-16 01:00"), clock(t, "2026-10-17 01:00"))
+	close(sch.queues[j.Group])
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	sch.work(ctx, sch.queues[j.Group])
+
+	if sch.pending[j.Name] {
+		t.Error("job yang dibuang masih tercatat pending")
+	}
+}
@@ -0,0 +1,92 @@
+// File : internal/daemon/daemon_state.go
+// Deskripsi : Penyimpanan status job daemon agar jadwal yang terlewat dapat dideteksi setelah restart
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// stateFileName adalah nama file status job di direktori daemon
+const stateFileName = "state.json"
+
+// jobState adalah status terakhir satu job
+type jobState struct {
+	LastScheduled time.Time `json:"last_scheduled"`           // Waktu jadwal terakhir yang sudah ditangani (dijalankan atau dilewati)
+	LastStart     time.Time `json:"last_start,omitempty"`     // Waktu proses job terakhir dimulai
+	LastEnd       time.Time `json:"last_end,omitempty"`       // Waktu proses job terakhir selesai
+	LastStatus    string    `json:"last_status,omitempty"`    // success, failed, skipped, atau running
+	LastExitCode  int       `json:"last_exit_code"`           // Exit code proses job terakhir
+	LastError     string    `json:"last_error,omitempty"`     // Pesan error job terakhir
+	LastLogFile   string    `json:"last_log_file,omitempty"`  // File log output job
+	NextScheduled time.Time `json:"next_scheduled,omitempty"` // Jadwal berikutnya saat state ditulis
+}
+
+// stateStore menyimpan status semua job dan menuliskannya ke disk setiap kali berubah
+type stateStore struct {
+	mu   sync.Mutex
+	path string
+	Jobs map[string]*jobState `json:"jobs"`
+}
+
+// loadState membaca file status; file yang belum ada menghasilkan state kosong
+func loadState(dir string) (*stateStore, error) {
+	st := &stateStore{path: filepath.Join(dir, stateFileName), Jobs: make(map[string]*jobState)}
+	data, err := os.ReadFile(st.path)
+	if os.IsNotExist(err) {
+		return st, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca state daemon: %w", err)
+	}
+	if err := json.Unmarshal(data, st); err != nil {
+		return nil, fmt.Errorf("gagal parse state daemon %s: %w", st.path, err)
+	}
+	if st.Jobs == nil {
+		st.Jobs = make(map[string]*jobState)
+	}
+	return st, nil
+}
+
+// get mengembalikan salinan status job
+func (st *stateStore) get(name string) jobState {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if js, ok := st.Jobs[name]; ok {
+		return *js
+	}
+	return jobState{}
+}
+
+// update mengubah status job lalu menyimpan state secara atomik
+func (st *stateStore) update(name string, fn func(js *jobState)) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	js, ok := st.Jobs[name]
+	if !ok {
+		js = &jobState{}
+		st.Jobs[name] = js
+	}
+	fn(js)
+
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(st.path), 0755); err != nil {
+		return err
+	}
+	tmp := st.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, st.path)
+}
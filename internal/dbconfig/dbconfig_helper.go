@@ -2,12 +2,11 @@
 // Deskripsi : Helper functions untuk modul dbconfig
 // Author : Hadiyatna Muflihun
 // Tanggal : 2024-10-03
-// Last Modified : 2024-10-03
+// Last Modified : 16 Oktober 2026
 
 package dbconfig
 
 import (
-	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -17,8 +16,8 @@ import (
 	"strings"
 )
 
-// ErrUserCancelled adalah sentinel error untuk menandai pembatalan oleh pengguna.
-var ErrUserCancelled = errors.New("user_cancelled")
+// ErrUserCancelled adalah sentinel error untuk menandai pembatalan oleh pengguna (sama dengan common.ErrUserCancelled).
+var ErrUserCancelled = common.ErrUserCancelled
 
 // buildFileName menormalkan input (menghapus suffix jika ada) lalu memastikan suffix .cnf.enc
 // Tujuan: menghindari duplikasi suffix saat user sudah mengetikkan nama dengan ekstensi.
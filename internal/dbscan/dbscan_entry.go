@@ -7,8 +7,8 @@ import (
 	"os/exec"
 	"path/filepath"
 	"sfDBTools/pkg/database"
+	"sfDBTools/pkg/fs"
 	"sfDBTools/pkg/ui"
-	"syscall"
 	"time"
 )
 
@@ -32,6 +32,13 @@ func (s *Service) ExecuteScanCommand(config ScanEntryConfig) error {
 		return s.spawnDaemonProcess(config)
 	}
 
+	// Cegah scan foreground berjalan bersamaan dengan scan lain
+	lock, err := s.acquireScanLock(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.releaseScanLock(lock)
+
 	// Setup connections
 	sourceClient, targetClient, dbFiltered, cleanup, err := s.setupScanConnections(ctx, config.HeaderTitle, config.ShowOptions)
 	if err != nil {
@@ -48,6 +55,10 @@ func (s *Service) ExecuteScanCommand(config ScanEntryConfig) error {
 
 	// Tampilkan hasil
 	s.DisplayScanResult(result)
+	s.recordScanMetrics(result)
+	if err := s.scanOutcome(result); err != nil {
+		return err
+	}
 
 	// Print success message jika ada
 	if config.SuccessMsg != "" {
@@ -67,7 +78,7 @@ func (s *Service) spawnDaemonProcess(config ScanEntryConfig) error {
 
 	// Generate scan ID dan log file
 	scanID := fmt.Sprintf("scan_%s", time.Now().Format("20060102_150405"))
-	logDir := filepath.Join("logs", "dbscan")
+	logDir := s.scanLockDir()
 
 	// Create log directory jika belum ada
 	if err := os.MkdirAll(logDir, 0755); err != nil {
@@ -75,26 +86,13 @@ func (s *Service) spawnDaemonProcess(config ScanEntryConfig) error {
 		logDir = ""
 	}
 
-	// PID file path (fixed name to prevent multiple background runs)
-	pidFile := filepath.Join(logDir, "dbscan_background.pid")
-
-	// Check if PID file exists and process is running
-	if pidFile != "" {
-		if data, err := os.ReadFile(pidFile); err == nil {
-			// Parse pid
-			var existingPID int
-			if _, err := fmt.Sscanf(string(data), "%d", &existingPID); err == nil {
-				// Check if process exists
-				if err := syscall.Kill(existingPID, 0); err == nil {
-					return fmt.Errorf("background process sudah berjalan dengan PID %d (pidfile=%s)", existingPID, pidFile)
-				}
-				// If syscall.Kill returned ESRCH or other, we proceed and remove stale pidfile
-				_ = os.Remove(pidFile)
-			} else {
-				// Can't parse, remove stale file
-				_ = os.Remove(pidFile)
-			}
-		}
+	// PID file ditulis oleh proses daemon setelah memperoleh lock
+	pidFile := filepath.Join(s.scanLockDir(), scanLockName+".pid")
+
+	// Tolak lebih awal jika scan lain masih berjalan, kecuali --wait (daemon yang akan menunggu lock)
+	waitLock := s.ScanOptions.Lock.Wait && !s.ScanOptions.Lock.NoWait
+	if existingPID, alive := fs.ReadLockPID(pidFile); alive && !waitLock {
+		return fmt.Errorf("background process sudah berjalan dengan PID %d (pidfile=%s)", existingPID, pidFile)
 	}
 
 	var logFile string
@@ -126,11 +124,6 @@ func (s *Service) spawnDaemonProcess(config ScanEntryConfig) error {
 		return fmt.Errorf("gagal memulai background process: %w", err)
 	}
 
-	// Write PID file so we can prevent duplicate background runs
-	if pidFile != "" {
-		_ = os.WriteFile(pidFile, []byte(fmt.Sprintf("%d", cmd.Process.Pid)), 0644)
-	}
-
 	// Print informasi
 	ui.PrintHeader("DATABASE SCANNING - BACKGROUND MODE")
 	ui.PrintSuccess(fmt.Sprintf("Background process dimulai dengan PID: %d", cmd.Process.Pid))
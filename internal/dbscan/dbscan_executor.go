@@ -2,7 +2,7 @@
 // Deskripsi : Eksekutor utama untuk database scanning dan menyimpan hasil ke database
 // Author : Hadiyatna Muflihun
 // Tanggal : 15 Oktober 2025
-// Last Modified : 16 Oktober 2025
+// Last Modified : 16 Oktober 2026
 
 package dbscan
 
@@ -11,7 +11,6 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
-	"path/filepath"
 	"syscall"
 	"time"
 
@@ -39,36 +38,13 @@ func (s *Service) ExecuteScanInBackground(ctx context.Context, config ScanEntryC
 	}
 	defer cleanup()
 
-	// Ensure logs dir, create lockfile and pid file, and acquire exclusive lock (flock)
-	logDir := filepath.Join("logs", "dbscan")
-	if err := os.MkdirAll(logDir, 0755); err != nil {
-		s.Logger.Warnf("[%s] Gagal membuat log dir: %v", scanID, err)
-	}
-
-	lockFile := filepath.Join(logDir, "dbscan_background.lock")
-	pidFile := filepath.Join(logDir, "dbscan_background.pid")
-
-	// Open (or create) lock file
-	lf, err := os.OpenFile(lockFile, os.O_CREATE|os.O_RDWR, 0644)
+	// Pastikan hanya satu scan berjalan (lock + PID file di <log dir>/dbscan)
+	lock, err := s.acquireScanLock(ctx)
 	if err != nil {
-		s.Logger.Warnf("[%s] Gagal membuka lock file %s: %v", scanID, lockFile, err)
-	} else {
-		// Try to acquire exclusive non-blocking lock
-		if err := syscall.Flock(int(lf.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
-			// Couldn't acquire lock -> another process likely running
-			lf.Close()
-			s.Logger.Warnf("[%s] Tidak dapat memperoleh lock, proses background lain mungkin sedang berjalan (lockfile=%s)", scanID, lockFile)
-			return fmt.Errorf("background process sudah berjalan (lockfile=%s)", lockFile)
-		}
-		s.Logger.Infof("[%s] Berhasil memperoleh lock: %s", scanID, lockFile)
-	}
-
-	// Write own PID to pid file
-	if err := os.WriteFile(pidFile, []byte(fmt.Sprintf("%d", os.Getpid())), 0644); err != nil {
-		s.Logger.Warnf("[%s] Gagal menulis pid file: %v", scanID, err)
-	} else {
-		s.Logger.Infof("[%s] Menulis PID file: %s", scanID, pidFile)
+		s.Logger.Warnf("[%s] %v", scanID, err)
+		return err
 	}
+	s.Logger.Infof("[%s] Berhasil memperoleh lock: %s (PID file: %s)", scanID, lock.Path, lock.PIDPath)
 
 	// Create cancellable context to support graceful shutdown
 	runCtx, cancel := context.WithCancel(ctx)
@@ -82,24 +58,9 @@ func (s *Service) ExecuteScanInBackground(ctx context.Context, config ScanEntryC
 		cancel()
 	}()
 
-	// Cleanup: remove pidfile, release flock, close lock file, stop signal notifications
+	// Cleanup: lepas lock (hapus PID file), stop signal notifications
 	defer func() {
-		// Remove pid file
-		if err := os.Remove(pidFile); err != nil {
-			s.Logger.Warnf("[%s] Gagal menghapus pid file %s: %v", scanID, pidFile, err)
-		} else {
-			s.Logger.Infof("[%s] PID file %s dihapus", scanID, pidFile)
-		}
-
-		// Release flock and close file
-		if lf != nil {
-			if err := syscall.Flock(int(lf.Fd()), syscall.LOCK_UN); err != nil {
-				s.Logger.Warnf("[%s] Gagal melepaskan lock %s: %v", scanID, lockFile, err)
-			} else {
-				s.Logger.Infof("[%s] Lock dilepas: %s", scanID, lockFile)
-			}
-			lf.Close()
-		}
+		s.releaseScanLock(lock)
 
 		signal.Stop(sigs)
 		cancel()
@@ -112,6 +73,7 @@ func (s *Service) ExecuteScanInBackground(ctx context.Context, config ScanEntryC
 		s.Logger.Errorf("[%s] Scanning gagal: %v", scanID, err)
 		return err
 	}
+	s.recordScanMetrics(result)
 
 	// Log hasil
 	s.Logger.Infof("[%s] ========================================", scanID)
@@ -129,6 +91,11 @@ func (s *Service) ExecuteScanInBackground(ctx context.Context, config ScanEntryC
 		}
 	}
 
+	if err := s.scanOutcome(result); err != nil {
+		s.Logger.Warnf("[%s] Background scanning selesai: %v", scanID, err)
+		return err
+	}
+
 	s.Logger.Infof("[%s] Background scanning selesai dengan sukses.", scanID)
 	s.Logger.Infof("[%s] ========================================", scanID)
 
@@ -177,7 +144,7 @@ func (s *Service) ExecuteScan(ctx context.Context, sourceClient *database.Client
 	s.Logger.Info("Memulai pengumpulan detail database...")
 
 	// Collect database details
-	detailsMap := sourceClient.CollectDatabaseDetails(ctx, dbNames, s.Logger)
+	detailsMap := sourceClient.CollectDatabaseDetails(ctx, dbNames, s.Logger, s.ScanOptions.Parallel)
 
 	// Kembalikan max_statement_time ke nilai awal
 	if originalMaxStatementTime > 0 {
@@ -256,5 +223,8 @@ func (s *Service) ExecuteScan(ctx context.Context, sourceClient *database.Client
 		FailedCount:    failedCount,
 		Duration:       duration.String(),
 		Errors:         errors,
+		StartTime:      startTime,
+		EndTime:        time.Now(),
+		Details:        detailsMap,
 	}, nil
 }
@@ -0,0 +1,42 @@
+// File : internal/dbscan/dbscan_lock.go
+// Deskripsi : Lock antar proses agar hanya satu database scan (foreground maupun background) berjalan
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package dbscan
+
+import (
+	"context"
+	"sfDBTools/pkg/fs"
+	"time"
+)
+
+// scanLockName adalah nama lock dan PID file scan di direktori log dbscan
+const scanLockName = "dbscan"
+
+// scanLockDir mengembalikan direktori absolut lock, PID file, dan log dbscan (di bawah direktori log)
+// sehingga cron, daemon, dan sesi interaktif memakai lock yang sama
+func (s *Service) scanLockDir() string {
+	return s.Config.StateDir("dbscan")
+}
+
+// acquireScanLock mengambil lock scan sesuai opsi --wait/--no-wait/--wait-timeout
+func (s *Service) acquireScanLock(ctx context.Context) (*fs.ProcessLock, error) {
+	opts := s.ScanOptions.Lock
+	policy := fs.LockPolicy{
+		Wait:    opts.Wait,
+		NoWait:  opts.NoWait,
+		Timeout: time.Duration(opts.WaitTimeout) * time.Second,
+	}
+	return fs.AcquireLockWithPolicy(ctx, s.scanLockDir(), scanLockName, "database scan", policy, s.Logger)
+}
+
+// releaseScanLock melepas lock scan dan mencatat kegagalan sebagai warning
+func (s *Service) releaseScanLock(lock *fs.ProcessLock) {
+	if err := lock.Release(); err != nil {
+		s.Logger.Warnf("Gagal melepas lock scan: %v", err)
+		return
+	}
+	s.Logger.Debugf("Lock scan dilepas: %s", lock.Path)
+}
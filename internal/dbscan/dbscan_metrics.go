@@ -0,0 +1,136 @@
+// File : internal/dbscan/dbscan_metrics.go
+// Deskripsi : Snapshot hasil scan terakhir dan metrik Prometheus ukuran serta isi database
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package dbscan
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sfDBTools/pkg/database"
+	"sfDBTools/pkg/metrics"
+	"sort"
+	"time"
+)
+
+const (
+	// scanMetricsFile adalah nama file textfile collector untuk metrik dbscan
+	scanMetricsFile = "sfdbtools_dbscan.prom"
+	// scanSnapshotFile menyimpan hasil scan terakhir agar 'serve --metrics' dapat menyajikannya tanpa koneksi database
+	scanSnapshotFile = "last_scan.json"
+)
+
+// scanSnapshot adalah hasil scan terakhir yang disimpan di direktori log dbscan
+type scanSnapshot struct {
+	Mode           string                                 `json:"mode"`
+	Host           string                                 `json:"host"`
+	Port           int                                    `json:"port"`
+	StartTime      time.Time                              `json:"start_time"`
+	EndTime        time.Time                              `json:"end_time"`
+	TotalDatabases int                                    `json:"total_databases"`
+	SuccessCount   int                                    `json:"success_count"`
+	FailedCount    int                                    `json:"failed_count"`
+	Details        map[string]database.DatabaseDetailInfo `json:"details"`
+}
+
+// recordScanMetrics menyimpan snapshot hasil scan dan menulis textfile metrik jika metrics.textfile_dir diisi.
+// Kegagalan hanya dicatat sebagai warning karena scan sendiri sudah selesai.
+func (s *Service) recordScanMetrics(result *ScanResult) {
+	snapshot := scanSnapshot{
+		Mode:           s.ScanOptions.Mode,
+		Host:           s.ScanOptions.DBConfig.ServerDBConnection.Host,
+		Port:           s.ScanOptions.DBConfig.ServerDBConnection.Port,
+		StartTime:      result.StartTime,
+		EndTime:        result.EndTime,
+		TotalDatabases: result.TotalDatabases,
+		SuccessCount:   result.SuccessCount,
+		FailedCount:    result.FailedCount,
+		Details:        result.Details,
+	}
+	if err := writeScanSnapshot(s.scanLockDir(), snapshot); err != nil {
+		s.Logger.Warnf("Gagal menyimpan snapshot scan: %v", err)
+	}
+
+	if s.Config == nil || s.Config.Metrics.TextfileDir == "" {
+		return
+	}
+	c := metrics.NewCollector()
+	collectScanMetrics(c, snapshot)
+	path, err := c.WriteTextfile(s.Config.Metrics.TextfileDir, scanMetricsFile)
+	if err != nil {
+		s.Logger.Warnf("Gagal menulis metrik dbscan: %v", err)
+		return
+	}
+	s.Logger.Debugf("Metrik dbscan ditulis ke %s", path)
+}
+
+// GatherScanMetrics mengisi collector dari snapshot scan terakhir; tidak ada snapshot berarti tidak ada metrik
+func (s *Service) GatherScanMetrics(c *metrics.Collector) error {
+	data, err := os.ReadFile(filepath.Join(s.scanLockDir(), scanSnapshotFile))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("gagal membaca snapshot scan: %w", err)
+	}
+	var snapshot scanSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return fmt.Errorf("gagal parse snapshot scan: %w", err)
+	}
+	collectScanMetrics(c, snapshot)
+	return nil
+}
+
+// collectScanMetrics mengubah snapshot scan menjadi metrik
+func collectScanMetrics(c *metrics.Collector, snapshot scanSnapshot) {
+	modeLabel := metrics.L("mode", snapshot.Mode)
+	c.Gauge("sfdbtools_dbscan_last_run_timestamp_seconds", "Waktu selesai scan terakhir (unix)",
+		float64(snapshot.EndTime.UnixNano())/float64(time.Second), modeLabel)
+	c.Gauge("sfdbtools_dbscan_last_run_duration_seconds", "Durasi scan terakhir", snapshot.EndTime.Sub(snapshot.StartTime).Seconds(), modeLabel)
+	c.Gauge("sfdbtools_dbscan_last_run_databases", "Jumlah database pada scan terakhir per hasil", float64(snapshot.SuccessCount), modeLabel, metrics.L("result", "success"))
+	c.Gauge("sfdbtools_dbscan_last_run_databases", "Jumlah database pada scan terakhir per hasil", float64(snapshot.FailedCount), modeLabel, metrics.L("result", "failed"))
+
+	for _, dbName := range sortedDetailNames(snapshot.Details) {
+		detail := snapshot.Details[dbName]
+		if detail.Error != "" {
+			continue
+		}
+		dbLabel := metrics.L("database", dbName)
+		c.Gauge("sfdbtools_dbscan_database_size_bytes", "Ukuran database hasil scan terakhir", float64(detail.SizeBytes), dbLabel)
+		c.Gauge("sfdbtools_dbscan_database_tables", "Jumlah tabel database hasil scan terakhir", float64(detail.TableCount), dbLabel)
+		c.Gauge("sfdbtools_dbscan_database_views", "Jumlah view database hasil scan terakhir", float64(detail.ViewCount), dbLabel)
+		c.Gauge("sfdbtools_dbscan_database_routines", "Jumlah procedure dan function database hasil scan terakhir",
+			float64(detail.ProcedureCount+detail.FunctionCount), dbLabel)
+	}
+}
+
+// sortedDetailNames mengembalikan nama database terurut agar output metrik stabil
+func sortedDetailNames(details map[string]database.DatabaseDetailInfo) []string {
+	names := make([]string, 0, len(details))
+	for name := range details {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// writeScanSnapshot menulis snapshot ke direktori log dbscan secara atomik
+func writeScanSnapshot(dir string, snapshot scanSnapshot) error {
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	path := filepath.Join(dir, scanSnapshotFile)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
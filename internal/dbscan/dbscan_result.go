@@ -0,0 +1,45 @@
+// File : internal/dbscan/dbscan_result.go
+// Deskripsi : Ringkasan hasil scan untuk --result-json dan penentuan exit code scan sebagian
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package dbscan
+
+import (
+	"fmt"
+	"sfDBTools/pkg/cmdresult"
+	"sfDBTools/pkg/exitcode"
+)
+
+// scanResultDetail adalah ringkasan scan yang ditulis ke hasil --result-json
+type scanResultDetail struct {
+	Mode           string   `json:"mode"`
+	TotalDatabases int      `json:"total_databases"`
+	SuccessCount   int      `json:"success_count"`
+	FailedCount    int      `json:"failed_count"`
+	Duration       string   `json:"duration"`
+	Errors         []string `json:"errors,omitempty"`
+}
+
+// scanOutcome mencatat hasil scan ke --result-json dan mengembalikan error jika ada database yang gagal.
+// Jika sebagian database berhasil, error ditandai sebagai keberhasilan sebagian (exit code 2).
+func (s *Service) scanOutcome(result *ScanResult) error {
+	cmdresult.SetDetail("dbscan", scanResultDetail{
+		Mode:           s.ScanOptions.Mode,
+		TotalDatabases: result.TotalDatabases,
+		SuccessCount:   result.SuccessCount,
+		FailedCount:    result.FailedCount,
+		Duration:       result.Duration,
+		Errors:         result.Errors,
+	})
+
+	if result.FailedCount == 0 {
+		return nil
+	}
+	err := fmt.Errorf("%d dari %d database gagal di-scan", result.FailedCount, result.TotalDatabases)
+	if result.SuccessCount > 0 {
+		return exitcode.AsPartial(err)
+	}
+	return err
+}
@@ -6,6 +6,11 @@
 
 package dbscan
 
+import (
+	"sfDBTools/pkg/database"
+	"time"
+)
+
 // ScanEntryConfig untuk konfigurasi scan entry point
 type ScanEntryConfig struct {
 	HeaderTitle string
@@ -22,6 +27,9 @@ type ScanResult struct {
 	FailedCount    int
 	Duration       string
 	Errors         []string
+	StartTime      time.Time
+	EndTime        time.Time
+	Details        map[string]database.DatabaseDetailInfo // Detail hasil scan per database (untuk metrik)
 }
 
 // DatabaseFilterStats menyimpan statistik hasil filtering database.
@@ -33,16 +33,34 @@ func GetDefaultBackupFlags() (*structs.BackupDBFlags, error) {
 				Enabled:       cfg.Backup.Retention.CleanupEnabled,
 				Scheduled:     cfg.Backup.Retention.CleanupSchedule,
 				RetentionDays: cfg.Backup.Retention.Days,
+				Policy:        cfg.Backup.Retention.Policy,
+				KeepDaily:     cfg.Backup.Retention.KeepDaily,
+				KeepWeekly:    cfg.Backup.Retention.KeepWeekly,
+				KeepMonthly:   cfg.Backup.Retention.KeepMonthly,
 			},
 			Exclude: structs.ExcludeOptions{
-				Databases: cfg.Backup.Exclude.Databases,
-				Users:     cfg.Backup.Exclude.User,
-				SystemsDB: cfg.Backup.Exclude.SystemDatabases,
-				Data:      cfg.Backup.Exclude.Data,
+				Databases:  cfg.Backup.Exclude.Databases,
+				Tables:     cfg.Backup.Exclude.Tables,
+				DataTables: cfg.Backup.Exclude.TableData,
+				Users:      cfg.Backup.Exclude.User,
+				SystemsDB:  cfg.Backup.Exclude.SystemDatabases,
+				Data:       cfg.Backup.Exclude.Data,
 			},
 			OutputDirectory: cfg.Backup.Output.BaseDirectory,
 			DiskCheck:       cfg.Backup.Verification.DiskSpaceCheck,
 			DBList:          cfg.Backup.DBList.File,
+			Verification: structs.VerificationOptions{
+				VerifyAfterWrite: cfg.Backup.Verification.VerifyAfterWrite,
+				CompareChecksums: cfg.Backup.Verification.CompareChecksums,
+			},
+			CaptureGtid: cfg.Backup.Output.CaptureGtid,
+			Coordinates: cfg.Backup.Output.Coordinates,
+			Dumper:      cfg.Backup.Dumper,
+			Retry: structs.RetryOptions{
+				MaxAttempts:    cfg.Backup.Retry.MaxAttempts,
+				BackoffSeconds: cfg.Backup.Retry.BackoffSeconds,
+			},
+			Concurrency: concurrencyFromConfig(cfg),
 		},
 		BackupInfo: structs.BackupInfo{
 			Enabled: cfg.Backup.Output.CreateBackupInfo,
@@ -71,21 +89,102 @@ func GetDefaultBackupAllFlags() (*structs.BackupAllFlags, error) {
 			Cleanup: structs.CleanupOptions{
 				Enabled:       cfg.Backup.Retention.CleanupEnabled,
 				RetentionDays: cfg.Backup.Retention.Days,
+				Policy:        cfg.Backup.Retention.Policy,
+				KeepDaily:     cfg.Backup.Retention.KeepDaily,
+				KeepWeekly:    cfg.Backup.Retention.KeepWeekly,
+				KeepMonthly:   cfg.Backup.Retention.KeepMonthly,
 			},
 
 			Exclude: structs.ExcludeOptions{
-				Databases: cfg.Backup.Exclude.Databases,
-				Users:     cfg.Backup.Exclude.User,
-				SystemsDB: cfg.Backup.Exclude.SystemDatabases,
-				Data:      cfg.Backup.Exclude.Data,
+				Databases:  cfg.Backup.Exclude.Databases,
+				Tables:     cfg.Backup.Exclude.Tables,
+				DataTables: cfg.Backup.Exclude.TableData,
+				Users:      cfg.Backup.Exclude.User,
+				SystemsDB:  cfg.Backup.Exclude.SystemDatabases,
+				Data:       cfg.Backup.Exclude.Data,
 			},
 			OutputDirectory: cfg.Backup.Output.BaseDirectory,
 			DiskCheck:       cfg.Backup.Verification.DiskSpaceCheck,
 			DBList:          cfg.Backup.DBList.File,
+			Verification: structs.VerificationOptions{
+				VerifyAfterWrite: cfg.Backup.Verification.VerifyAfterWrite,
+				CompareChecksums: cfg.Backup.Verification.CompareChecksums,
+			},
+			CaptureGtid: cfg.Backup.Output.CaptureGtid,
+			Coordinates: cfg.Backup.Output.Coordinates,
+			Dumper:      cfg.Backup.Dumper,
+			Retry: structs.RetryOptions{
+				MaxAttempts:    cfg.Backup.Retry.MaxAttempts,
+				BackoffSeconds: cfg.Backup.Retry.BackoffSeconds,
+			},
+			Concurrency: concurrencyFromConfig(cfg),
 		},
 		BackupInfo: structs.BackupInfo{
 			Enabled: cfg.Backup.Output.CreateBackupInfo,
 		},
-		CaptureGtid: cfg.Backup.Output.CaptureGtid,
 	}, nil
 }
+
+// GetDefaultBackupRetryFlags returns default values for BackupRetryFlags
+func GetDefaultBackupRetryFlags() (*structs.BackupRetryFlags, error) {
+	cfg, err := appconfig.LoadConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return &structs.BackupRetryFlags{
+		EncryptionKey: cfg.Backup.Encryption.Key,
+		Retry: structs.RetryOptions{
+			MaxAttempts:    cfg.Backup.Retry.MaxAttempts,
+			BackoffSeconds: cfg.Backup.Retry.BackoffSeconds,
+		},
+		Concurrency: concurrencyFromConfig(cfg),
+	}, nil
+}
+
+// GetDefaultBackupPhysicalFlags returns default values for BackupPhysicalFlags
+func GetDefaultBackupPhysicalFlags() (*structs.BackupPhysicalFlags, error) {
+	cfg, err := appconfig.LoadConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return &structs.BackupPhysicalFlags{
+		OutputDirectory: cfg.Backup.Output.BaseDirectory,
+		Encryption: structs.EncryptionOptions{
+			Enabled: cfg.Backup.Encryption.Enabled,
+			Key:     cfg.Backup.Encryption.Key,
+		},
+		Compression: structs.CompressionOptions{
+			Type:    cfg.Backup.Compression.Type,
+			Level:   cfg.Backup.Compression.Level,
+			Enabled: cfg.Backup.Compression.Required,
+		},
+		Verification: structs.VerificationOptions{
+			VerifyAfterWrite: cfg.Backup.Verification.VerifyAfterWrite,
+			CompareChecksums: cfg.Backup.Verification.CompareChecksums,
+		},
+		Cleanup: structs.CleanupOptions{
+			Enabled:       cfg.Backup.Retention.CleanupEnabled,
+			Scheduled:     cfg.Backup.Retention.CleanupSchedule,
+			RetentionDays: cfg.Backup.Retention.Days,
+			Policy:        cfg.Backup.Retention.Policy,
+			KeepDaily:     cfg.Backup.Retention.KeepDaily,
+			KeepWeekly:    cfg.Backup.Retention.KeepWeekly,
+			KeepMonthly:   cfg.Backup.Retention.KeepMonthly,
+		},
+		Parallel:     cfg.Backup.Performance.Parallel,
+		MaxBandwidth: cfg.Backup.Performance.MaxBandwidth,
+	}, nil
+}
+
+// concurrencyFromConfig mengambil opsi paralelisme dan pembatasan I/O dari bagian backup.performance
+func concurrencyFromConfig(cfg *appconfig.Config) structs.ConcurrencyOptions {
+	perf := cfg.Backup.Performance
+	return structs.ConcurrencyOptions{
+		Parallel:          perf.Parallel,
+		MaxBandwidth:      perf.MaxBandwidth,
+		Adaptive:          perf.Adaptive.Enabled,
+		MaxThreadsRunning: perf.Adaptive.MaxThreadsRunning,
+		MaxReplicationLag: perf.Adaptive.MaxReplicationLag,
+		CheckInterval:     perf.Adaptive.CheckInterval,
+	}
+}
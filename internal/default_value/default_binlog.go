@@ -0,0 +1,36 @@
+// File : internal/default_value/default_binlog.go
+// Deskripsi : Nilai default untuk flags pada modul binlog
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package defaultvalue
+
+import (
+	"sfDBTools/internal/appconfig"
+	"sfDBTools/internal/structs"
+)
+
+// GetDefaultBinlogArchiveFlags returns default values for BinlogArchiveFlags
+func GetDefaultBinlogArchiveFlags() (*structs.BinlogArchiveFlags, error) {
+	// Muat konfigurasi aplikasi; kompresi, enkripsi, dan direktori output mengikuti konfigurasi backup
+	cfg, err := appconfig.LoadConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return &structs.BinlogArchiveFlags{
+		Encryption: structs.EncryptionOptions{
+			Enabled: cfg.Backup.Encryption.Enabled,
+			Key:     cfg.Backup.Encryption.Key,
+		},
+		Compression: structs.CompressionOptions{
+			Type:    cfg.Backup.Compression.Type,
+			Level:   cfg.Backup.Compression.Level,
+			Enabled: cfg.Backup.Compression.Required,
+		},
+		OutputDirectory: cfg.Backup.Output.BaseDirectory,
+		BinlogDir:       cfg.Mariadb.BinlogDir,
+		Interval:        300,
+		Source:          "auto",
+	}, nil
+}
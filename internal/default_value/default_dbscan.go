@@ -2,7 +2,7 @@
 // Deskripsi : Default values untuk database scan options
 // Author : Hadiyatna Muflihun
 // Tanggal : 15 Oktober 2025
-// Last Modified : 15 Oktober 2025
+// Last Modified : 16 Oktober 2026
 
 package defaultvalue
 
@@ -15,8 +15,10 @@ import (
 
 // GetDefaultScanOptions mengembalikan default options untuk database scan
 func GetDefaultScanOptions(mode string) structs.ScanOptions {
-	// Muat konfigurasi aplikasi untuk mendapatkan direktori konfigurasi
-	cfg, _ := appconfig.LoadConfigFromEnv()
+	// Muat konfigurasi aplikasi untuk mendapatkan direktori konfigurasi.
+	// Fungsi ini dipanggil saat init command, sehingga konfigurasi yang gagal dimuat tidak boleh
+	// menyebabkan panic; kesalahannya dilaporkan oleh main.
+	cfg, err := appconfig.LoadConfigFromEnv()
 
 	opts := structs.ScanOptions{}
 
@@ -31,7 +33,9 @@ func GetDefaultScanOptions(mode string) structs.ScanOptions {
 		opts.DatabaseList.UseFile = true
 	}
 
-	opts.DatabaseList.File = cfg.Backup.DBList.File
+	if err == nil {
+		opts.DatabaseList.File = cfg.Backup.DBList.File
+	}
 
 	// Filter Options
 	opts.ExcludeSystem = true
@@ -64,6 +68,11 @@ func GetDefaultScanOptions(mode string) structs.ScanOptions {
 		opts.TargetDB.Database = "sfdbtools"
 	}
 
+	// Batas worker mengikuti backup.performance.parallel agar beban scan sama dengan backup
+	if err == nil {
+		opts.Parallel = cfg.Backup.Performance.Parallel
+	}
+
 	// Output Options
 	opts.DisplayResults = true
 	opts.SaveToDB = true
@@ -22,9 +22,46 @@ type BackupOptions struct {
 	DBConfig        DBConfigInfo
 	DiskCheck       bool `flag:"disk-check" env:"SFDB_VERIFICATION_DISK_CHECK" default:"true"` // Apakah cek disk diaktifkan
 	Exclude         ExcludeOptions
-	UseDBList       bool   `flag:"use-db-list" env:"SFDB_BACKUP_USE_DB_LIST" default:"false"` // Apakah menggunakan file db list
-	DBList          string `flag:"db-list" env:"SFDB_BACKUP_DB_LIST_FILE" default:""`
+	UseDBList       bool     `flag:"use-db-list" env:"SFDB_BACKUP_USE_DB_LIST" default:"false"` // Apakah menggunakan file db list
+	DBList          string   `flag:"db-list" env:"SFDB_BACKUP_DB_LIST_FILE" default:""`
+	IncludeTables   []string `flag:"include-table" env:"SFDB_BACKUP_INCLUDE_TABLES" default:""` // Hanya backup tabel ini pada database terkait (format db.table, mendukung glob)
 	Cleanup         CleanupOptions
+	Verification    VerificationOptions
+	Retry           RetryOptions
+	Concurrency     ConcurrencyOptions
+	CaptureGtid     bool   `flag:"capture-gtid" env:"SFDB_CAPTURE_GTID"`                       // Catat posisi GTID dan koordinat binlog yang konsisten dengan dump
+	Coordinates     string `flag:"coordinates" env:"SFDB_BACKUP_COORDINATES" default:"master"` // Sumber koordinat: master (--master-data=2) atau slave (--dump-slave=2, menghentikan SQL thread replika selama dump)
+	Dumper          string `flag:"dumper" env:"SFDB_BACKUP_DUMPER" default:"mysqldump"`        // Implementasi dump logis: mysqldump (binary eksternal) atau native (Go, tanpa mysqldump)
+	Lock            LockOptions
+}
+
+// VerificationOptions - Opsi verifikasi file backup setelah ditulis
+type VerificationOptions struct {
+	VerifyAfterWrite bool `flag:"verify-after-write" env:"SFDB_VERIFICATION_VERIFY_AFTER_WRITE" default:"true"` // Buka ulang file backup dan periksa trailer dump setelah ditulis
+	CompareChecksums bool `flag:"compare-checksums" env:"SFDB_VERIFICATION_COMPARE_CHECKSUMS" default:"true"`   // Bandingkan SHA-256 saat penulisan dengan hasil baca ulang
+}
+
+// RetryOptions - Kebijakan percobaan ulang otomatis untuk kegagalan sementara (koneksi terputus, deadlock, dll)
+type RetryOptions struct {
+	MaxAttempts    int `flag:"retry-attempts" env:"SFDB_BACKUP_RETRY_ATTEMPTS" default:"3"` // Jumlah percobaan maksimal per dump (1 = tanpa retry)
+	BackoffSeconds int `flag:"retry-backoff" env:"SFDB_BACKUP_RETRY_BACKOFF" default:"10"`  // Jeda awal sebelum retry dalam detik, dilipatgandakan setiap percobaan
+}
+
+// ConcurrencyOptions - Opsi paralelisme dan pembatasan I/O saat backup
+type ConcurrencyOptions struct {
+	Parallel          int    `flag:"parallel" env:"SFDB_BACKUP_PARALLEL" default:"0"`                          // Jumlah worker mysqldump paralel pada mode separate (0 = jumlah CPU)
+	MaxBandwidth      string `flag:"max-bandwidth" env:"SFDB_BACKUP_MAX_BANDWIDTH" default:""`                 // Batas total kecepatan tulis file backup per detik, contoh: 50MB, 200MiB (kosong = tanpa batas)
+	Adaptive          bool   `flag:"adaptive" env:"SFDB_BACKUP_ADAPTIVE" default:"false"`                      // Turunkan paralelisme saat beban server atau lag replikasi melewati ambang batas
+	MaxThreadsRunning int    `flag:"adaptive-max-threads" env:"SFDB_BACKUP_ADAPTIVE_MAX_THREADS" default:"64"` // Ambang batas Threads_running untuk mode adaptive (0 = tidak diperiksa)
+	MaxReplicationLag int    `flag:"adaptive-max-lag" env:"SFDB_BACKUP_ADAPTIVE_MAX_LAG" default:"300"`        // Ambang batas lag replikasi dalam detik untuk mode adaptive (0 = tidak diperiksa)
+	CheckInterval     int    // Interval pemeriksaan beban server dalam detik untuk mode adaptive
+}
+
+// LockOptions - Perilaku saat proses backup/cleanup/dbscan lain sedang memegang lock
+type LockOptions struct {
+	Wait        bool `flag:"wait" env:"SFDB_LOCK_WAIT" default:"false"`             // Tunggu proses lain selesai alih-alih langsung gagal
+	NoWait      bool `flag:"no-wait" env:"SFDB_LOCK_NO_WAIT" default:"false"`       // Langsung gagal jika proses lain sedang berjalan (mengalahkan --wait)
+	WaitTimeout int  `flag:"wait-timeout" env:"SFDB_LOCK_WAIT_TIMEOUT" default:"0"` // Batas waktu menunggu lock dalam detik (0 = tanpa batas)
 }
 
 // EncryptionOptions - Opsi enkripsi untuk backup
@@ -38,6 +75,10 @@ type CleanupOptions struct {
 	Enabled       bool   `flag:"cleanup" env:"SFDB_CLEANUP_ENABLED" default:"false"` // Aktifkan pembersihan otomatis backup lama sesuai kebijakan retensi
 	Scheduled     string // Jadwal pembersihan: daily, weekly, monthly
 	RetentionDays int    `flag:"cleanup-days" env:"SFDB_CLEANUP_DAYS" default:"30"` // Jumlah hari untuk menyimpan backup sebelum dihapus (retensi)
+	Policy        string // Kebijakan retensi: gfs (berbasis backup set) atau age (mtime file)
+	KeepDaily     int    // Policy gfs: jumlah backup set harian yang disimpan
+	KeepWeekly    int    // Policy gfs: jumlah backup set mingguan yang disimpan
+	KeepMonthly   int    // Policy gfs: jumlah backup set bulanan yang disimpan
 }
 
 // CleanupFlags - Flags khusus untuk command cleanup (lebih sederhana)
@@ -47,13 +88,17 @@ type CleanupFlags struct {
 	Enabled         bool   `flag:"cleanup" env:"SFDB_CLEANUP_ENABLED" default:"true"`             // Aktifkan pembersihan (harus true untuk menjalankan cleanup)
 	DryRun          bool   `flag:"dry-run" env:"SFDB_CLEANUP_DRY_RUN" default:"false"`            // Mode dry-run: tampilkan file yang akan dihapus tanpa menghapus
 	Pattern         string `flag:"pattern" env:"SFDB_CLEANUP_PATTERN" default:""`                 // Pattern file yang akan dibersihkan (opsional)
+	Policy          string `flag:"policy" env:"SFDB_CLEANUP_POLICY" default:""`                   // Kebijakan retensi: gfs atau age (kosong = backup.retention.policy)
+	KeepDaily       int    `flag:"keep-daily" env:"SFDB_CLEANUP_KEEP_DAILY" default:"0"`          // Policy gfs: backup set harian yang disimpan (0 = dari konfigurasi)
+	KeepWeekly      int    `flag:"keep-weekly" env:"SFDB_CLEANUP_KEEP_WEEKLY" default:"0"`        // Policy gfs: backup set mingguan yang disimpan (0 = dari konfigurasi)
+	KeepMonthly     int    `flag:"keep-monthly" env:"SFDB_CLEANUP_KEEP_MONTHLY" default:"0"`      // Policy gfs: backup set bulanan yang disimpan (0 = dari konfigurasi)
+	Lock            LockOptions
 }
 
 // BackupAllFlags - Struct untuk menyimpan flags pada perintah backup
 type BackupAllFlags struct {
 	BackupOptions BackupOptions
 	BackupInfo    BackupInfo
-	CaptureGtid   bool   `flag:"capture-gtid" env:"SFDB_CAPTURE_GTID"`         // Apakah GTID capture diaktifkan
 	Mode          string `flag:"mode" env:"SFDB_BACKUP_MODE" default:"single"` // Mode backup: single atau multi
 	// Cache internal untuk optimasi performa
 	DbListCache map[string]bool // Cache untuk database whitelist dari file
@@ -63,7 +108,8 @@ type BackupAllFlags struct {
 type BackupDBFlags struct {
 	BackupOptions BackupOptions
 	BackupInfo    BackupInfo
-	DBName        []string `flag:"db" env:"SFDB_BACKUP_DB_NAME" default:""` // Nama database yang akan dibackup
+	DBName        []string `flag:"db" env:"SFDB_BACKUP_DB_NAME" default:""`     // Nama database yang akan dibackup
+	Mode          string   `flag:"mode" env:"SFDB_BACKUP_MODE" default:"multi"` // Mode backup: single (satu file) atau multi (file per database)
 }
 
 // DBListOptions - Struct untuk menyimpan flags pada perintah backup db-list
@@ -73,11 +119,12 @@ type DBListOptions struct {
 
 // ExcludeOptions - Struct untuk menyimpan flags pada perintah backup exclude
 type ExcludeOptions struct {
-	Databases []string `flag:"exclude-db" env:"SFDB_BACKUP_EXCLUDE_DATABASES" default:""` // Daftar database yang dikecualikan, dipisah koma
-	// Tables    []string `flag:"exclude-tables" env:"SFDB_BACKUP_EXCLUDE_TABLES" default:""`       // Daftar tabel yang dikecualikan, dipisah koma
-	SystemsDB bool `flag:"exclude-system" env:"SFDB_BACKUP_EXCLUDE_SYSTEMS" default:"false"` // Apakah sistem dikecualikan
-	Users     bool `flag:"exclude-user" env:"SFDB_BACKUP_EXCLUDE_USERS" default:"false"`     // Apakah user dikecualikan
-	Data      bool `flag:"exclude-data" env:"SFDB_BACKUP_EXCLUDE_DATA" default:"false"`      // Apakah exclude data (hanya struktur)
+	Databases  []string `flag:"exclude-db" env:"SFDB_BACKUP_EXCLUDE_DATABASES" default:""`          // Daftar database yang dikecualikan, dipisah koma
+	Tables     []string `flag:"exclude-table" env:"SFDB_BACKUP_EXCLUDE_TABLES" default:""`          // Tabel yang dikecualikan (format db.table, mendukung glob)
+	DataTables []string `flag:"exclude-table-data" env:"SFDB_BACKUP_EXCLUDE_TABLE_DATA" default:""` // Tabel yang hanya di-backup strukturnya (format db.table, mendukung glob)
+	SystemsDB  bool     `flag:"exclude-system" env:"SFDB_BACKUP_EXCLUDE_SYSTEMS" default:"false"`   // Apakah sistem dikecualikan
+	Users      bool     `flag:"exclude-user" env:"SFDB_BACKUP_EXCLUDE_USERS" default:"false"`       // Apakah user dikecualikan
+	Data       bool     `flag:"exclude-data" env:"SFDB_BACKUP_EXCLUDE_DATA" default:"false"`        // Apakah exclude data (hanya struktur)
 }
 
 // BackupInfo - Struct untuk menyimpan informasi hasil backup
@@ -105,4 +152,70 @@ type FilterInfo struct {
 type BackupSummaryFlags struct {
 	BackupID string `flag:"backup-id" env:"SFDB_BACKUP_SUMMARY_ID" default:""`       // ID backup untuk ditampilkan
 	Latest   bool   `flag:"latest" env:"SFDB_BACKUP_SUMMARY_LATEST" default:"false"` // Tampilkan summary terbaru
+
+	// Pencarian di katalog backup (tabel backup_runs pada target database)
+	Catalog  bool   `flag:"catalog" env:"SFDB_BACKUP_SUMMARY_CATALOG" default:"false"` // Query katalog, bukan file summary lokal
+	Host     string `flag:"host" env:"SFDB_BACKUP_SUMMARY_HOST" default:""`            // Filter host server sumber
+	Database string `flag:"database" env:"SFDB_BACKUP_SUMMARY_DATABASE" default:""`    // Filter backup yang memuat database ini
+	Status   string `flag:"status" env:"SFDB_BACKUP_SUMMARY_STATUS" default:""`        // Filter status: success, partial, failed
+	From     string `flag:"from" env:"SFDB_BACKUP_SUMMARY_FROM" default:""`            // Mulai tanggal (YYYY-MM-DD atau "YYYY-MM-DD HH:MM")
+	To       string `flag:"to" env:"SFDB_BACKUP_SUMMARY_TO" default:""`                // Sampai tanggal (inklusif untuk format YYYY-MM-DD)
+	Limit    int    `flag:"limit" env:"SFDB_BACKUP_SUMMARY_LIMIT" default:"50"`        // Jumlah baris maksimal (0 = tanpa batas)
+}
+
+// BackupRestoreFlags - Struct untuk menyimpan flags pada perintah backup restore
+type BackupRestoreFlags struct {
+	File          string   `flag:"file" env:"SFDB_RESTORE_FILE" default:""`             // Path file backup yang akan di-restore
+	BackupID      string   `flag:"backup-id" env:"SFDB_RESTORE_BACKUP_ID" default:""`   // ID backup dari summary JSON
+	Databases     []string `flag:"db" env:"SFDB_RESTORE_DB" default:""`                 // Batasi restore ke database tertentu (hanya untuk --backup-id)
+	EncryptionKey string   `flag:"encrypt-key" env:"SFDB_ENCRYPTION_KEY" default:""`    // Kunci untuk mendekripsi file backup
+	Force         bool     `flag:"force" env:"SFDB_RESTORE_FORCE" default:"false"`      // Lewati konfirmasi sebelum restore
+	Users         bool     `flag:"users" env:"SFDB_RESTORE_USERS" default:"false"`      // Restore juga akun user dan grant dari file users backup set (hanya untuk --backup-id)
+	Until         string   `flag:"until" env:"SFDB_RESTORE_UNTIL" default:""`           // Point-in-time recovery sampai waktu ini (format: 2006-01-02 15:04:05)
+	UntilGTID     string   `flag:"until-gtid" env:"SFDB_RESTORE_UNTIL_GTID" default:""` // Point-in-time recovery sampai posisi GTID ini
+	DBConfig      DBConfigInfo
+}
+
+// BackupRetryFlags - Struct untuk menyimpan flags pada perintah backup retry
+type BackupRetryFlags struct {
+	BackupID      string `flag:"backup-id" env:"SFDB_RETRY_BACKUP_ID" default:""`  // ID backup yang database gagalnya akan di-backup ulang
+	EncryptionKey string `flag:"encrypt-key" env:"SFDB_ENCRYPTION_KEY" default:""` // Kunci enkripsi (harus sama dengan backup asli)
+	DBConfig      DBConfigInfo
+	Retry         RetryOptions
+	Concurrency   ConcurrencyOptions
+	Lock          LockOptions
+}
+
+// BackupPhysicalFlags - Struct untuk menyimpan flags pada perintah backup physical (mariabackup)
+type BackupPhysicalFlags struct {
+	OutputDirectory string `flag:"output" env:"SFDB_BACKUP_OUTPUT_DIR" default:"./backups"`
+	DBConfig        DBConfigInfo
+	Encryption      EncryptionOptions
+	Compression     CompressionOptions
+	Verification    VerificationOptions
+	Cleanup         CleanupOptions
+	Parallel        int    `flag:"parallel" env:"SFDB_BACKUP_PARALLEL" default:"0"`          // Jumlah thread copy mariabackup (0 = default mariabackup)
+	MaxBandwidth    string `flag:"max-bandwidth" env:"SFDB_BACKUP_MAX_BANDWIDTH" default:""` // Batas kecepatan tulis file backup per detik, contoh: 50MB (kosong = tanpa batas)
+	Physical        PhysicalOptions
+	Lock            LockOptions
+}
+
+// PhysicalOptions - Opsi backup fisik: prepare dan incremental berbasis LSN
+type PhysicalOptions struct {
+	Prepare         bool   `flag:"prepare" env:"SFDB_PHYSICAL_PREPARE" default:"false"`              // Ekstrak stream dan jalankan mariabackup --prepare setelah backup
+	PrepareDir      string `flag:"prepare-dir" env:"SFDB_PHYSICAL_PREPARE_DIR" default:""`           // Direktori hasil prepare; untuk incremental berisi backup full yang sudah di-prepare
+	IncrementalFrom string `flag:"incremental-from" env:"SFDB_PHYSICAL_INCREMENTAL_FROM" default:""` // Backup ID fisik sebelumnya sebagai basis incremental (LSN diambil dari summary)
+	IncrementalLSN  string `flag:"incremental-lsn" env:"SFDB_PHYSICAL_INCREMENTAL_LSN" default:""`   // LSN basis incremental eksplisit (alternatif --incremental-from)
+}
+
+// BackupNotifyFlags - Struct untuk menyimpan flags pada perintah backup notify
+type BackupNotifyFlags struct {
+	BackupID string `flag:"backup-id" env:"SFDB_NOTIFY_BACKUP_ID" default:""` // ID backup yang summary-nya dikirim sebagai notifikasi
+	Force    bool   `flag:"force" env:"SFDB_NOTIFY_FORCE" default:"false"`    // Kirim ke semua target aktif tanpa memeriksa aturan 'on'
+}
+
+// BackupVerifyFlags - Struct untuk menyimpan flags pada perintah backup verify
+type BackupVerifyFlags struct {
+	Manifest string `flag:"manifest" env:"SFDB_VERIFY_MANIFEST" default:""`   // Path file MANIFEST yang akan diverifikasi
+	BackupID string `flag:"backup-id" env:"SFDB_VERIFY_BACKUP_ID" default:""` // ID backup (MANIFEST dicari di direktori summary)
 }
@@ -0,0 +1,19 @@
+// File : internal/structs/structs_binlog.go
+// Deskripsi : Struct untuk menyimpan flags pada perintah binlog
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package structs
+
+// BinlogArchiveFlags - Struct untuk menyimpan flags pada perintah binlog archive
+type BinlogArchiveFlags struct {
+	DBConfig        DBConfigInfo
+	Encryption      EncryptionOptions
+	Compression     CompressionOptions
+	OutputDirectory string `flag:"output" env:"SFDB_BINLOG_OUTPUT_DIR" default:""`    // Base direktori backup; arsip ditulis ke <output>/binlogs/<host>_<port>/
+	BinlogDir       string `flag:"binlog-dir" env:"SFDB_BINLOG_DIR" default:""`       // Direktori binlog lokal; jika file tidak ada, binlog dibaca via mysqlbinlog remote
+	Watch           bool   `flag:"watch" env:"SFDB_BINLOG_WATCH" default:"false"`     // Jalankan terus-menerus dan arsipkan binlog baru secara periodik
+	Interval        int    `flag:"interval" env:"SFDB_BINLOG_INTERVAL" default:"300"` // Interval pemeriksaan binlog baru dalam detik (mode --watch)
+	Source          string `flag:"source" env:"SFDB_BINLOG_SOURCE" default:"auto"`    // Sumber binlog: auto, local, atau remote
+}
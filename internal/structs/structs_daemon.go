@@ -0,0 +1,12 @@
+// File : internal/structs/structs_daemon.go
+// Deskripsi : Struktur flags untuk perintah daemon
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package structs
+
+// DaemonFlags - Struct untuk menyimpan flags pada perintah daemon
+type DaemonFlags struct {
+	List bool `flag:"list" env:"SFDB_DAEMON_LIST" default:"false"` // Tampilkan daftar job dan jadwal berikutnya lalu keluar
+}
@@ -2,7 +2,7 @@
 // Deskripsi : Struktur data untuk database scan
 // Author : Hadiyatna Muflihun
 // Tanggal : 15 Oktober 2025
-// Last Modified : 15 Oktober 2025
+// Last Modified : 16 Oktober 2026
 
 package structs
 
@@ -45,6 +45,12 @@ type ScanOptions struct {
 	SaveToDB       bool
 	Background     bool // Jalankan scanning di background
 
+	// Jumlah worker pengumpulan detail database (0 = jumlah CPU)
+	Parallel int
+
+	// Perilaku saat scan lain sedang berjalan (lock <log dir>/dbscan/dbscan.lock)
+	Lock LockOptions
+
 	// Internal use only
 	Mode string // "all" atau "database" atau "single" atau "rescan"
 }
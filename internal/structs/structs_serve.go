@@ -0,0 +1,13 @@
+// File : internal/structs/structs_serve.go
+// Deskripsi : Struktur flags untuk perintah serve
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package structs
+
+// ServeFlags - Struct untuk menyimpan flags pada perintah serve
+type ServeFlags struct {
+	Metrics bool   `flag:"metrics" env:"SFDB_SERVE_METRICS" default:"false"` // Aktifkan endpoint HTTP metrik Prometheus
+	Listen  string `flag:"listen" env:"SFDB_SERVE_LISTEN" default:""`        // Alamat listen (kosong = metrics.listen pada config)
+}
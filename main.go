@@ -2,10 +2,10 @@ package main
 
 import (
 	"fmt"
-	"os"
 	"sfDBTools/cmd"
 	config "sfDBTools/internal/appconfig"
 	"sfDBTools/pkg/database"
+	"sfDBTools/pkg/exitcode"
 	"sfDBTools/pkg/globals"
 
 	applog "sfDBTools/internal/applog"
@@ -20,8 +20,9 @@ func main() {
 	var err error
 	cfg, err = config.LoadConfigFromEnv()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "FATAL: Gagal memuat konfigurasi: %v\n", err)
-		os.Exit(1)
+		// Diselesaikan lewat cmd agar exit code dan hasil --result-json tetap konsisten
+		cmd.ExecuteStartupError(exitcode.AsConfigError(fmt.Errorf("gagal memuat konfigurasi: %w", err)))
+		return
 	}
 
 	// 2. Inisialisasi Logger Kustom
@@ -0,0 +1,76 @@
+// File : pkg/cmdresult/cmdresult.go
+// Deskripsi : Hasil eksekusi perintah dalam format JSON (--result-json) untuk orkestrasi dan otomasi
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+package cmdresult
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Result adalah hasil terstruktur satu eksekusi perintah
+type Result struct {
+	Command         string                 `json:"command"`           // Path perintah, misalnya "backup all-databases"
+	Status          string                 `json:"status"`            // success, partial, failed, config_error, cancelled, input_required, lock_held
+	ExitCode        int                    `json:"exit_code"`         // Exit code proses
+	Error           string                 `json:"error,omitempty"`   // Pesan error jika perintah gagal
+	StartedAt       time.Time              `json:"started_at"`        // Waktu perintah dimulai
+	FinishedAt      time.Time              `json:"finished_at"`       // Waktu perintah selesai
+	DurationSeconds float64                `json:"duration_seconds"`  // Durasi eksekusi
+	PID             int                    `json:"pid"`               // PID proses
+	Details         map[string]interface{} `json:"details,omitempty"` // Detail khusus perintah (summary backup, hasil scan, dll)
+}
+
+var (
+	mu      sync.Mutex
+	details = make(map[string]interface{})
+)
+
+// SetDetail menambahkan detail khusus perintah ke hasil JSON
+func SetDetail(key string, value interface{}) {
+	mu.Lock()
+	defer mu.Unlock()
+	details[key] = value
+}
+
+// Details mengembalikan salinan detail yang sudah dicatat
+func Details() map[string]interface{} {
+	mu.Lock()
+	defer mu.Unlock()
+	if len(details) == 0 {
+		return nil
+	}
+	out := make(map[string]interface{}, len(details))
+	for k, v := range details {
+		out[k] = v
+	}
+	return out
+}
+
+// Write menulis hasil ke path secara atomik (file sementara lalu rename)
+func Write(path string, result Result) error {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("gagal encode hasil perintah: %w", err)
+	}
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("gagal membuat direktori hasil perintah: %w", err)
+		}
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("gagal menulis hasil perintah: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("gagal menulis hasil perintah: %w", err)
+	}
+	return nil
+}
@@ -7,7 +7,10 @@ import (
 	"io"
 	"strings"
 
+	"github.com/dsnet/compress/bzip2"
 	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+	"github.com/ulikunitz/xz"
 )
 
 // NewDecompressingReader returns a reader that decompresses data from r using the specified compression type.
@@ -22,7 +25,17 @@ func NewDecompressingReader(r io.Reader, ctype CompressionType) (io.ReadCloser,
 		if err != nil {
 			return nil, err
 		}
-		return io.NopCloser(zr), nil
+		return zr.IOReadCloser(), nil
+	case CompressionLz4:
+		return io.NopCloser(lz4.NewReader(r)), nil
+	case CompressionXz:
+		xr, err := xz.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(xr), nil
+	case CompressionBzip2:
+		return bzip2.NewReader(r, nil)
 	case CompressionNone:
 		return io.NopCloser(r), nil
 	default:
@@ -41,6 +54,12 @@ func DetectCompressionTypeFromFile(path string) CompressionType {
 		return CompressionZstd
 	case strings.HasSuffix(name, ".zlib"):
 		return CompressionZlib
+	case strings.HasSuffix(name, ".lz4"):
+		return CompressionLz4
+	case strings.HasSuffix(name, ".xz"):
+		return CompressionXz
+	case strings.HasSuffix(name, ".bz2"):
+		return CompressionBzip2
 	default:
 		return CompressionNone
 	}
@@ -29,6 +29,12 @@ func NewCompressingWriter(baseWriter io.Writer, config CompressionConfig) (*Comp
 		compressor, err = createZlibWriter(baseWriter, config.Level)
 	case CompressionZstd:
 		compressor, err = createZstdWriter(baseWriter, config.Level)
+	case CompressionLz4:
+		compressor, err = createLz4Writer(baseWriter, config.Level)
+	case CompressionXz:
+		compressor, err = createXzWriter(baseWriter, config.Level)
+	case CompressionBzip2:
+		compressor, err = createBzip2Writer(baseWriter, config.Level)
 	default:
 		return nil, fmt.Errorf("unsupported compression type: %s", config.Type)
 	}
@@ -69,6 +75,12 @@ func GetFileExtension(compressionType CompressionType) string {
 		return ".zlib"
 	case CompressionZstd:
 		return ".zst"
+	case CompressionLz4:
+		return ".lz4"
+	case CompressionXz:
+		return ".xz"
+	case CompressionBzip2:
+		return ".bz2"
 	default:
 		return ""
 	}
@@ -118,5 +130,8 @@ func GetCompressionInfo() map[CompressionType]string {
 		CompressionPgzip: "Parallel gzip compression (faster for large files)",
 		CompressionZlib:  "Zlib compression (good compression ratio)",
 		CompressionZstd:  "Zstandard compression (fast and good ratio)",
+		CompressionLz4:   "LZ4 compression (fastest, lower ratio)",
+		CompressionXz:    "XZ/LZMA2 compression (best ratio, slowest)",
+		CompressionBzip2: "Bzip2 compression (good ratio, slow)",
 	}
 }
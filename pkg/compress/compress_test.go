@@ -0,0 +1,150 @@
+package compress
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+	"testing"
+)
+
+var allTypes = []CompressionType{
+	CompressionNone,
+	CompressionGzip,
+	CompressionPgzip,
+	CompressionZlib,
+	CompressionZstd,
+	CompressionLz4,
+	CompressionXz,
+	CompressionBzip2,
+}
+
+var allLevels = []CompressionLevel{LevelBestSpeed, LevelFast, LevelDefault, LevelBetter, LevelBest}
+
+// testPayload mixes repetitive SQL text with random bytes so both the match finder
+// and the literal path of every compressor are exercised.
+func testPayload() []byte {
+	var buf bytes.Buffer
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; buf.Len() < 256*1024; i++ {
+		fmt.Fprintf(&buf, "INSERT INTO `orders` VALUES (%d,'customer-%d','2026-10-16 01:00:00');\n", i, i%97)
+		if i%50 == 0 {
+			noise := make([]byte, 512)
+			rng.Read(noise)
+			buf.Write(noise)
+		}
+	}
+	return buf.Bytes()
+}
+
+func TestCompressRoundTrip(t *testing.T) {
+	payload := testPayload()
+
+	for _, ctype := range allTypes {
+		for _, level := range allLevels {
+			t.Run(string(ctype)+"/"+string(level), func(t *testing.T) {
+				var compressed bytes.Buffer
+				cw, err := NewCompressingWriter(&compressed, CompressionConfig{Type: ctype, Level: level})
+				if err != nil {
+					t.Fatalf("NewCompressingWriter: %v", err)
+				}
+				if _, err := cw.Write(payload); err != nil {
+					t.Fatalf("Write: %v", err)
+				}
+				if err := cw.Close(); err != nil {
+					t.Fatalf("Close: %v", err)
+				}
+				if ctype != CompressionNone && compressed.Len() >= len(payload) {
+					t.Errorf("compressed size %d is not smaller than input %d", compressed.Len(), len(payload))
+				}
+
+				dr, err := NewDecompressingReader(bytes.NewReader(compressed.Bytes()), ctype)
+				if err != nil {
+					t.Fatalf("NewDecompressingReader: %v", err)
+				}
+				defer dr.Close()
+
+				got, err := io.ReadAll(dr)
+				if err != nil {
+					t.Fatalf("ReadAll: %v", err)
+				}
+				if !bytes.Equal(got, payload) {
+					t.Errorf("round-trip mismatch: got %d bytes, want %d", len(got), len(payload))
+				}
+			})
+		}
+	}
+}
+
+func TestCompressRoundTripEmpty(t *testing.T) {
+	for _, ctype := range allTypes {
+		t.Run(string(ctype), func(t *testing.T) {
+			var compressed bytes.Buffer
+			cw, err := NewCompressingWriter(&compressed, CompressionConfig{Type: ctype, Level: LevelDefault})
+			if err != nil {
+				t.Fatalf("NewCompressingWriter: %v", err)
+			}
+			if err := cw.Close(); err != nil {
+				t.Fatalf("Close: %v", err)
+			}
+
+			dr, err := NewDecompressingReader(bytes.NewReader(compressed.Bytes()), ctype)
+			if err != nil {
+				t.Fatalf("NewDecompressingReader: %v", err)
+			}
+			defer dr.Close()
+			got, err := io.ReadAll(dr)
+			if err != nil {
+				t.Fatalf("ReadAll: %v", err)
+			}
+			if len(got) != 0 {
+				t.Errorf("got %d bytes, want 0", len(got))
+			}
+		})
+	}
+}
+
+func TestUnsupportedCompressionType(t *testing.T) {
+	if _, err := NewCompressingWriter(io.Discard, CompressionConfig{Type: "rar", Level: LevelDefault}); err == nil {
+		t.Error("NewCompressingWriter accepted unknown type")
+	}
+	if _, err := NewDecompressingReader(bytes.NewReader(nil), "rar"); err == nil {
+		t.Error("NewDecompressingReader accepted unknown type")
+	}
+}
+
+func TestFileExtensionDetection(t *testing.T) {
+	tests := []struct {
+		ctype    CompressionType
+		ext      string
+		detected CompressionType
+	}{
+		{CompressionNone, "", CompressionNone},
+		{CompressionGzip, ".gz", CompressionGzip},
+		{CompressionPgzip, ".gz", CompressionGzip},
+		{CompressionZlib, ".zlib", CompressionZlib},
+		{CompressionZstd, ".zst", CompressionZstd},
+		{CompressionLz4, ".lz4", CompressionLz4},
+		{CompressionXz, ".xz", CompressionXz},
+		{CompressionBzip2, ".bz2", CompressionBzip2},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.ctype), func(t *testing.T) {
+			ext := GetFileExtension(tt.ctype)
+			if ext != tt.ext {
+				t.Errorf("GetFileExtension(%s) = %q, want %q", tt.ctype, ext, tt.ext)
+			}
+
+			for _, name := range []string{
+				"/backup/sales_20261016.sql" + ext,
+				"/backup/sales_20261016.sql" + ext + ".enc",
+				"/backup/SALES_20261016.SQL" + ext,
+			} {
+				if got := DetectCompressionTypeFromFile(name); got != tt.detected {
+					t.Errorf("DetectCompressionTypeFromFile(%q) = %s, want %s", name, got, tt.detected)
+				}
+			}
+		})
+	}
+}
@@ -9,10 +9,11 @@ import (
 func ValidateCompressionType(compressionType string) (CompressionType, error) {
 	ct := CompressionType(strings.ToLower(compressionType))
 	switch ct {
-	case CompressionNone, CompressionGzip, CompressionPgzip, CompressionZlib, CompressionZstd:
+	case CompressionNone, CompressionGzip, CompressionPgzip, CompressionZlib, CompressionZstd,
+		CompressionLz4, CompressionXz, CompressionBzip2:
 		return ct, nil
 	default:
-		return CompressionNone, fmt.Errorf("unsupported compression type: %s. Supported types: none, gzip, pgzip, zlib, zstd", compressionType)
+		return CompressionNone, fmt.Errorf("unsupported compression type: %s. Supported types: none, gzip, pgzip, zlib, zstd, lz4, xz, bzip2", compressionType)
 	}
 }
 
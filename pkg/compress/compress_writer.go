@@ -5,8 +5,11 @@ import (
 	"compress/zlib"
 	"io"
 
+	"github.com/dsnet/compress/bzip2"
 	"github.com/klauspost/compress/zstd"
 	"github.com/klauspost/pgzip"
+	"github.com/pierrec/lz4/v4"
+	"github.com/ulikunitz/xz"
 )
 
 // createGzipWriter creates a gzip writer with specified level
@@ -92,3 +95,71 @@ func createZstdWriter(w io.Writer, level CompressionLevel) (*zstd.Encoder, error
 
 	return zstd.NewWriter(w, zstd.WithEncoderLevel(zstdLevel))
 }
+
+// createLz4Writer creates an lz4 frame writer with specified level
+func createLz4Writer(w io.Writer, level CompressionLevel) (*lz4.Writer, error) {
+	var lz4Level lz4.CompressionLevel
+	switch level {
+	case LevelBestSpeed:
+		lz4Level = lz4.Fast
+	case LevelFast:
+		lz4Level = lz4.Fast
+	case LevelDefault:
+		lz4Level = lz4.Level3
+	case LevelBetter:
+		lz4Level = lz4.Level6
+	case LevelBest:
+		lz4Level = lz4.Level9
+	default:
+		lz4Level = lz4.Fast
+	}
+
+	zw := lz4.NewWriter(w)
+	if err := zw.Apply(lz4.CompressionLevelOption(lz4Level)); err != nil {
+		return nil, err
+	}
+	return zw, nil
+}
+
+// createXzWriter creates an xz writer; xz has no numeric levels, so the level
+// is mapped to the LZMA2 dictionary size (larger dictionary = better ratio, more memory)
+func createXzWriter(w io.Writer, level CompressionLevel) (*xz.Writer, error) {
+	var dictCap int
+	switch level {
+	case LevelBestSpeed:
+		dictCap = 1 << 20 // 1 MiB
+	case LevelFast:
+		dictCap = 2 << 20 // 2 MiB
+	case LevelDefault:
+		dictCap = 8 << 20 // 8 MiB
+	case LevelBetter:
+		dictCap = 16 << 20 // 16 MiB
+	case LevelBest:
+		dictCap = 64 << 20 // 64 MiB
+	default:
+		dictCap = 8 << 20
+	}
+
+	return xz.WriterConfig{DictCap: dictCap}.NewWriter(w)
+}
+
+// createBzip2Writer creates a bzip2 writer with specified level
+func createBzip2Writer(w io.Writer, level CompressionLevel) (*bzip2.Writer, error) {
+	var bzip2Level int
+	switch level {
+	case LevelBestSpeed:
+		bzip2Level = bzip2.BestSpeed
+	case LevelFast:
+		bzip2Level = 3
+	case LevelDefault:
+		bzip2Level = bzip2.DefaultCompression
+	case LevelBetter:
+		bzip2Level = 8
+	case LevelBest:
+		bzip2Level = bzip2.BestCompression
+	default:
+		bzip2Level = bzip2.DefaultCompression
+	}
+
+	return bzip2.NewWriter(w, &bzip2.WriterConfig{Level: bzip2Level})
+}
@@ -0,0 +1,192 @@
+// File : pkg/cron/cron.go
+// Deskripsi : Parser ekspresi cron 5 field (menit jam tanggal bulan hari) dan penghitung waktu eksekusi berikutnya
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule adalah ekspresi cron yang sudah diurai; setiap field disimpan sebagai bitset nilai yang cocok
+type Schedule struct {
+	Expr   string
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+	// Jika tanggal dan hari sama-sama dibatasi, cron mencocokkan salah satunya (OR)
+	domAny bool
+	dowAny bool
+}
+
+// aliases memetakan singkatan jadwal; bentuk tanpa '@' diterima agar cocok dengan retention.cleanup_schedule
+var aliases = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+	"yearly":    "0 0 1 1 *",
+	"monthly":   "0 0 1 * *",
+	"weekly":    "0 0 * * 0",
+	"daily":     "0 0 * * *",
+	"hourly":    "0 * * * *",
+}
+
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var dowNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// Parse mengurai ekspresi cron standar 5 field, misalnya "30 1 * * *" atau "0 */6 * * mon-fri",
+// serta alias @hourly, @daily, @weekly, @monthly, dan @yearly.
+func Parse(expr string) (*Schedule, error) {
+	normalized := strings.ToLower(strings.TrimSpace(expr))
+	if alias, ok := aliases[normalized]; ok {
+		normalized = alias
+	}
+	fields := strings.Fields(normalized)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("ekspresi cron '%s' harus terdiri dari 5 field (menit jam tanggal bulan hari)", expr)
+	}
+
+	s := &Schedule{Expr: expr}
+	var err error
+	if s.minute, err = parseField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("field menit pada '%s': %w", expr, err)
+	}
+	if s.hour, err = parseField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("field jam pada '%s': %w", expr, err)
+	}
+	if s.dom, err = parseField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("field tanggal pada '%s': %w", expr, err)
+	}
+	if s.month, err = parseField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("field bulan pada '%s': %w", expr, err)
+	}
+	// Hari 7 diterima sebagai Minggu
+	if s.dow, err = parseField(fields[4], 0, 7, dowNames); err != nil {
+		return nil, fmt.Errorf("field hari pada '%s': %w", expr, err)
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+	s.domAny = fields[2] == "*" || fields[2] == "?"
+	s.dowAny = fields[4] == "*" || fields[4] == "?"
+	return s, nil
+}
+
+// parseField mengurai satu field cron (daftar dipisah koma berisi *, n, a-b, dengan langkah /n opsional)
+func parseField(field string, min, max int, names map[string]int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("langkah tidak valid: %s", part)
+			}
+			rangePart, step = part[:i], n
+		}
+
+		var lo, hi int
+		switch {
+		case rangePart == "*" || rangePart == "?":
+			lo, hi = min, max
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if lo, err = parseValue(bounds[0], names); err != nil {
+				return 0, err
+			}
+			if hi, err = parseValue(bounds[1], names); err != nil {
+				return 0, err
+			}
+		default:
+			var err error
+			if lo, err = parseValue(rangePart, names); err != nil {
+				return 0, err
+			}
+			hi = lo
+			// "5/15" berarti mulai 5 dengan langkah 15 sampai nilai maksimum
+			if step > 1 {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("nilai %s di luar rentang %d-%d", rangePart, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func parseValue(value string, names map[string]int) (int, error) {
+	if n, ok := names[value]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("nilai tidak valid: %s", value)
+	}
+	return n, nil
+}
+
+// Next mengembalikan waktu eksekusi pertama setelah t (presisi menit, pada zona waktu t).
+// Mengembalikan waktu nol jika tidak ada waktu yang cocok dalam 5 tahun (misalnya 30 Februari).
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dowMatch
+	case s.dowAny:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
@@ -0,0 +1,127 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func mustTime(t *testing.T, value string) time.Time {
+	t.Helper()
+	ts, err := time.Parse("2006-01-02 15:04", value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ts
+}
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		from string
+		want []string // Eksekusi berurutan setelah from; kosong berarti waktu nol
+	}{
+		{"setiap 15 menit", "*/15 * * * *", "2026-10-16 10:07",
+			[]string{"2026-10-16 10:15", "2026-10-16 10:30", "2026-10-16 10:45", "2026-10-16 11:00"}},
+		{"mulai 5 langkah 15", "5/15 * * * *", "2026-10-16 10:07",
+			[]string{"2026-10-16 10:20", "2026-10-16 10:35", "2026-10-16 10:50", "2026-10-16 11:05"}},
+		{"rentang dengan langkah", "0 1-10/3 * * *", "2026-10-16 00:00",
+			[]string{"2026-10-16 01:00", "2026-10-16 04:00", "2026-10-16 07:00", "2026-10-16 10:00", "2026-10-17 01:00"}},
+		{"daftar nilai", "0,30 6,18 * * *", "2026-10-16 06:00",
+			[]string{"2026-10-16 06:30", "2026-10-16 18:00", "2026-10-16 18:30", "2026-10-17 06:00"}},
+		{"waktu persis tidak dihitung", "30 1 * * *", "2026-10-16 01:30",
+			[]string{"2026-10-17 01:30"}},
+		{"setiap menit", "* * * * *", "2026-10-16 10:07",
+			[]string{"2026-10-16 10:08", "2026-10-16 10:09"}},
+		{"nama bulan", "0 0 1 jan,jul *", "2026-10-16 00:00",
+			[]string{"2027-01-01 00:00", "2027-07-01 00:00"}},
+		{"nama hari rentang", "0 9 * * mon-fri", "2026-10-16 10:00", // Jumat
+			[]string{"2026-10-19 09:00", "2026-10-20 09:00"}},
+		{"hari 7 adalah Minggu", "0 0 * * 7", "2026-10-16 00:00",
+			[]string{"2026-10-18 00:00", "2026-10-25 00:00"}},
+		{"hari 0 adalah Minggu", "0 0 * * sun", "2026-10-16 00:00",
+			[]string{"2026-10-18 00:00", "2026-10-25 00:00"}},
+		{"tanggal atau hari jika keduanya dibatasi", "0 0 13 * fri", "2026-10-01 00:00",
+			[]string{"2026-10-02 00:00", "2026-10-09 00:00", "2026-10-13 00:00", "2026-10-16 00:00"}},
+		{"hanya tanggal dibatasi", "0 0 13 * *", "2026-10-01 00:00",
+			[]string{"2026-10-13 00:00", "2026-11-13 00:00"}},
+		{"pergantian bulan", "0 0 31 * *", "2026-10-31 00:00",
+			[]string{"2026-12-31 00:00", "2027-01-31 00:00", "2027-03-31 00:00"}},
+		{"pergantian tahun", "59 23 31 12 *", "2026-12-31 23:59",
+			[]string{"2027-12-31 23:59"}},
+		{"tanggal kabisat", "0 0 29 2 *", "2026-10-16 00:00",
+			[]string{"2028-02-29 00:00", "2032-02-29 00:00"}},
+		{"tanggal tidak pernah ada", "0 0 30 2 *", "2026-10-16 00:00", nil},
+		{"alias daily untuk cleanup_schedule", "daily", "2026-10-16 10:07",
+			[]string{"2026-10-17 00:00", "2026-10-18 00:00"}},
+		{"alias @weekly", "@weekly", "2026-10-16 10:07",
+			[]string{"2026-10-18 00:00"}},
+		{"alias monthly tanpa @", " Monthly ", "2026-10-16 10:07",
+			[]string{"2026-11-01 00:00"}},
+		{"alias @hourly", "@hourly", "2026-10-16 23:30",
+			[]string{"2026-10-17 00:00"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.expr, err)
+			}
+
+			next := mustTime(t, tt.from)
+			if len(tt.want) == 0 {
+				if got := schedule.Next(next); !got.IsZero() {
+					t.Errorf("Next = %s, ingin waktu nol", got.Format(time.RFC3339))
+				}
+				return
+			}
+			for _, want := range tt.want {
+				next = schedule.Next(next)
+				if got := next.Format("2006-01-02 15:04"); got != want {
+					t.Fatalf("Next = %s, ingin %s", got, want)
+				}
+			}
+		})
+	}
+}
+
+// TestNextKeepsLocation memastikan detik diabaikan dan hasil tetap pada zona waktu input
+func TestNextKeepsLocation(t *testing.T) {
+	loc := time.FixedZone("WIB", 7*60*60)
+	schedule, err := Parse("30 1 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := schedule.Next(time.Date(2026, 10, 16, 2, 0, 45, 123, loc))
+	if want := time.Date(2026, 10, 17, 1, 30, 0, 0, loc); !got.Equal(want) || got.Location() != loc {
+		t.Errorf("Next = %s, ingin %s", got, want)
+	}
+}
+
+func TestParseRejectsInvalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",        // 4 field
+		"0 0 * * * 2026", // 6 field
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * 32 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"*/-5 * * * *",
+		"*/x * * * *",
+		"a * * * *",
+		"* * * foo *",
+		"* * * * funday",
+		"1-x * * * *",
+		"@reboot",
+	} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) berhasil, ingin error", expr)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+// File: pkg/database/database_catalog.go
+// Deskripsi: Menyimpan dan mencari riwayat backup pada tabel katalog backup_runs dan backup_files
+// Author: Hadiyatna Muflihun
+// Tanggal: 16 Oktober 2026
+// Last Modified: 16 Oktober 2026
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CatalogRun adalah satu baris tabel backup_runs
+type CatalogRun struct {
+	ID                  int64
+	BackupID            string
+	ServerHost          string
+	ServerPort          int
+	AgentHost           string // Hostname mesin yang menjalankan sfdbtools
+	BackupMode          string
+	Status              string
+	StartTime           time.Time
+	EndTime             time.Time
+	DurationSeconds     float64
+	TotalDatabases      int
+	SuccessfulDatabases int
+	WarningDatabases    int
+	FailedDatabases     int
+	TotalFiles          int
+	TotalSizeBytes      int64
+	OutputDirectory     string
+	ManifestFile        string
+	GTIDPosition        string
+	Errors              string
+}
+
+// CatalogFile adalah satu baris tabel backup_files (satu database dalam satu backup)
+type CatalogFile struct {
+	DatabaseName      string
+	Status            string // success, success_with_warnings, atau failed
+	FilePath          string
+	FileSizeBytes     int64
+	OriginalSizeBytes int64
+	CompressionRatio  float64
+	Duration          string
+	Attempts          int
+	SHA256            string
+	GTIDPosition      string
+	Warnings          string
+	Error             string
+}
+
+// CatalogFilter adalah kriteria pencarian backup_runs; field kosong berarti tidak difilter
+type CatalogFilter struct {
+	Host     string
+	Database string
+	Status   string
+	From     time.Time
+	To       time.Time
+	Limit    int
+}
+
+// SaveBackupRun menyimpan satu backup beserta file-filenya ke katalog.
+// Backup yang sudah ada (host, port, backup_id sama) diperbarui dan daftar filenya diganti,
+// sehingga summary yang berubah karena 'backup retry' tetap tercermin di katalog.
+func (c *Client) SaveBackupRun(ctx context.Context, run CatalogRun, files []CatalogFile) (int64, error) {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("gagal memulai transaksi katalog: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO backup_runs (
+			backup_id, server_host, server_port, agent_host, backup_mode, status,
+			start_time, end_time, duration_seconds, total_databases, successful_databases,
+			warning_databases, failed_databases, total_files, total_size_bytes,
+			output_directory, manifest_file, gtid_position, errors
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			id = LAST_INSERT_ID(id),
+			agent_host = VALUES(agent_host),
+			backup_mode = VALUES(backup_mode),
+			status = VALUES(status),
+			start_time = VALUES(start_time),
+			end_time = VALUES(end_time),
+			duration_seconds = VALUES(duration_seconds),
+			total_databases = VALUES(total_databases),
+			successful_databases = VALUES(successful_databases),
+			warning_databases = VALUES(warning_databases),
+			failed_databases = VALUES(failed_databases),
+			total_files = VALUES(total_files),
+			total_size_bytes = VALUES(total_size_bytes),
+			output_directory = VALUES(output_directory),
+			manifest_file = VALUES(manifest_file),
+			gtid_position = VALUES(gtid_position),
+			errors = VALUES(errors)`
+
+	res, err := tx.ExecContext(ctx, query,
+		run.BackupID, run.ServerHost, run.ServerPort, run.AgentHost, run.BackupMode, run.Status,
+		run.StartTime, nullTime(run.EndTime), run.DurationSeconds, run.TotalDatabases, run.SuccessfulDatabases,
+		run.WarningDatabases, run.FailedDatabases, run.TotalFiles, run.TotalSizeBytes,
+		run.OutputDirectory, run.ManifestFile, nullString(run.GTIDPosition), nullString(run.Errors),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("gagal menyimpan backup_runs: %w", err)
+	}
+	runID, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("gagal membaca id backup_runs: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM backup_files WHERE run_id = ?", runID); err != nil {
+		return 0, fmt.Errorf("gagal menghapus backup_files lama: %w", err)
+	}
+
+	fileQuery := `
+		INSERT INTO backup_files (
+			run_id, database_name, status, file_path, file_size_bytes, original_size_bytes,
+			compression_ratio, duration, attempts, sha256, gtid_position, warnings, error
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	for _, f := range files {
+		_, err := tx.ExecContext(ctx, fileQuery,
+			runID, f.DatabaseName, f.Status, f.FilePath, f.FileSizeBytes, f.OriginalSizeBytes,
+			f.CompressionRatio, f.Duration, f.Attempts, f.SHA256, nullString(f.GTIDPosition), nullString(f.Warnings), nullString(f.Error),
+		)
+		if err != nil {
+			return 0, fmt.Errorf("gagal menyimpan backup_files untuk database '%s': %w", f.DatabaseName, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("gagal commit transaksi katalog: %w", err)
+	}
+	return runID, nil
+}
+
+// QueryBackupRuns mencari backup_runs sesuai filter, diurutkan dari yang terbaru
+func (c *Client) QueryBackupRuns(ctx context.Context, filter CatalogFilter) ([]CatalogRun, error) {
+	var where []string
+	var args []any
+	if filter.Host != "" {
+		where = append(where, "r.server_host = ?")
+		args = append(args, filter.Host)
+	}
+	if filter.Status != "" {
+		where = append(where, "r.status = ?")
+		args = append(args, filter.Status)
+	}
+	if filter.Database != "" {
+		where = append(where, "EXISTS (SELECT 1 FROM backup_files f WHERE f.run_id = r.id AND f.database_name = ?)")
+		args = append(args, filter.Database)
+	}
+	if !filter.From.IsZero() {
+		where = append(where, "r.start_time >= ?")
+		args = append(args, filter.From)
+	}
+	if !filter.To.IsZero() {
+		where = append(where, "r.start_time < ?")
+		args = append(args, filter.To)
+	}
+
+	query := `
+		SELECT
+			r.id, r.backup_id, r.server_host, r.server_port, r.agent_host, r.backup_mode, r.status,
+			r.start_time, r.end_time, r.duration_seconds, r.total_databases, r.successful_databases,
+			r.warning_databases, r.failed_databases, r.total_files, r.total_size_bytes,
+			r.output_directory, r.manifest_file, r.gtid_position, r.errors
+		FROM backup_runs r`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY r.start_time DESC, r.id DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("gagal query backup_runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []CatalogRun
+	for rows.Next() {
+		var run CatalogRun
+		var endTime sql.NullTime
+		var gtid, errs sql.NullString
+		if err := rows.Scan(
+			&run.ID, &run.BackupID, &run.ServerHost, &run.ServerPort, &run.AgentHost, &run.BackupMode, &run.Status,
+			&run.StartTime, &endTime, &run.DurationSeconds, &run.TotalDatabases, &run.SuccessfulDatabases,
+			&run.WarningDatabases, &run.FailedDatabases, &run.TotalFiles, &run.TotalSizeBytes,
+			&run.OutputDirectory, &run.ManifestFile, &gtid, &errs,
+		); err != nil {
+			return nil, fmt.Errorf("gagal membaca baris backup_runs: %w", err)
+		}
+		run.EndTime = endTime.Time
+		run.GTIDPosition = gtid.String
+		run.Errors = errs.String
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("gagal iterasi backup_runs: %w", err)
+	}
+	return runs, nil
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
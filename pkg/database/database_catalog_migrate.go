@@ -0,0 +1,140 @@
+// File: pkg/database/database_catalog_migrate.go
+// Deskripsi: Migrasi skema katalog backup (backup_runs, backup_files) pada target database
+// Author: Hadiyatna Muflihun
+// Tanggal: 16 Oktober 2026
+// Last Modified: 16 Oktober 2026
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+const (
+	// catalogMigrationTable mencatat versi skema katalog yang sudah diterapkan
+	catalogMigrationTable = "sfdbtools_schema_migrations"
+	// catalogMigrationLock adalah nama GET_LOCK agar dua proses tidak menjalankan migrasi bersamaan
+	catalogMigrationLock = "sfdbtools_catalog_migration"
+	// catalogMigrationLockTimeout dalam detik
+	catalogMigrationLockTimeout = 30
+)
+
+// catalogMigration adalah satu langkah perubahan skema katalog.
+// Migrasi hanya boleh ditambahkan di akhir daftar; versi yang sudah dirilis tidak boleh diubah.
+type catalogMigration struct {
+	version     int
+	description string
+	statements  []string
+}
+
+var catalogMigrations = []catalogMigration{
+	{
+		version:     1,
+		description: "buat tabel backup_runs dan backup_files",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS backup_runs (
+				id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+				backup_id VARCHAR(128) NOT NULL,
+				server_host VARCHAR(255) NOT NULL,
+				server_port INT NOT NULL DEFAULT 0,
+				agent_host VARCHAR(255) NOT NULL DEFAULT '',
+				backup_mode VARCHAR(32) NOT NULL,
+				status VARCHAR(32) NOT NULL,
+				start_time DATETIME NOT NULL,
+				end_time DATETIME NULL,
+				duration_seconds DOUBLE NOT NULL DEFAULT 0,
+				total_databases INT NOT NULL DEFAULT 0,
+				successful_databases INT NOT NULL DEFAULT 0,
+				warning_databases INT NOT NULL DEFAULT 0,
+				failed_databases INT NOT NULL DEFAULT 0,
+				total_files INT NOT NULL DEFAULT 0,
+				total_size_bytes BIGINT NOT NULL DEFAULT 0,
+				output_directory VARCHAR(1024) NOT NULL DEFAULT '',
+				manifest_file VARCHAR(1024) NOT NULL DEFAULT '',
+				gtid_position TEXT NULL,
+				errors TEXT NULL,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+				PRIMARY KEY (id),
+				UNIQUE KEY uk_backup_runs_run (server_host, server_port, backup_id),
+				KEY idx_backup_runs_start (start_time),
+				KEY idx_backup_runs_status (status)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4`,
+			`CREATE TABLE IF NOT EXISTS backup_files (
+				id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+				run_id BIGINT UNSIGNED NOT NULL,
+				database_name VARCHAR(255) NOT NULL,
+				status VARCHAR(32) NOT NULL,
+				file_path VARCHAR(1024) NOT NULL DEFAULT '',
+				file_size_bytes BIGINT NOT NULL DEFAULT 0,
+				original_size_bytes BIGINT NOT NULL DEFAULT 0,
+				compression_ratio DOUBLE NOT NULL DEFAULT 0,
+				duration VARCHAR(32) NOT NULL DEFAULT '',
+				attempts INT NOT NULL DEFAULT 0,
+				sha256 CHAR(64) NOT NULL DEFAULT '',
+				gtid_position TEXT NULL,
+				warnings TEXT NULL,
+				error TEXT NULL,
+				PRIMARY KEY (id),
+				KEY idx_backup_files_run (run_id),
+				KEY idx_backup_files_database (database_name),
+				CONSTRAINT fk_backup_files_run FOREIGN KEY (run_id) REFERENCES backup_runs (id) ON DELETE CASCADE
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4`,
+		},
+	},
+}
+
+// MigrateCatalog membuat atau memperbarui skema katalog backup ke versi terbaru.
+// Aman dipanggil berulang kali dan dari beberapa host sekaligus.
+func (c *Client) MigrateCatalog(ctx context.Context) error {
+	// GET_LOCK terikat ke sesi, jadi semua perintah harus memakai koneksi yang sama
+	conn, err := c.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("gagal mengambil koneksi untuk migrasi katalog: %w", err)
+	}
+	defer conn.Close()
+
+	var locked sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", catalogMigrationLock, catalogMigrationLockTimeout).Scan(&locked); err != nil {
+		return fmt.Errorf("gagal mengambil lock migrasi katalog: %w", err)
+	}
+	if !locked.Valid || locked.Int64 != 1 {
+		return fmt.Errorf("lock migrasi katalog sedang dipegang proses lain")
+	}
+	defer conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", catalogMigrationLock)
+
+	createTable := `CREATE TABLE IF NOT EXISTS ` + catalogMigrationTable + ` (
+		version INT NOT NULL,
+		description VARCHAR(255) NOT NULL,
+		applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (version)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4`
+	if _, err := conn.ExecContext(ctx, createTable); err != nil {
+		return fmt.Errorf("gagal membuat tabel %s: %w", catalogMigrationTable, err)
+	}
+
+	var current int
+	if err := conn.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM "+catalogMigrationTable).Scan(&current); err != nil {
+		return fmt.Errorf("gagal membaca versi skema katalog: %w", err)
+	}
+
+	for _, m := range catalogMigrations {
+		if m.version <= current {
+			continue
+		}
+		// DDL MySQL/MariaDB melakukan implicit commit, sehingga setiap statement ditulis idempoten
+		// (IF NOT EXISTS) agar migrasi yang terputus bisa diulang dengan aman.
+		for _, stmt := range m.statements {
+			if _, err := conn.ExecContext(ctx, stmt); err != nil {
+				return fmt.Errorf("gagal menerapkan migrasi katalog versi %d (%s): %w", m.version, m.description, err)
+			}
+		}
+		if _, err := conn.ExecContext(ctx, "INSERT INTO "+catalogMigrationTable+" (version, description) VALUES (?, ?)", m.version, m.description); err != nil {
+			return fmt.Errorf("gagal mencatat migrasi katalog versi %d: %w", m.version, err)
+		}
+		c.log.Infof("Migrasi katalog backup versi %d diterapkan: %s", m.version, m.description)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// File : pkg/database/database_client_args.go
+// Deskripsi : Helper argumen koneksi untuk client command line MariaDB/MySQL
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"sfDBTools/internal/structs"
+	"strings"
+)
+
+// BuildClientArgs membangun argumen koneksi untuk client MariaDB/MySQL
+// (mysqldump, mysql, mysqlbinlog, mariabackup) dari informasi koneksi yang diberikan.
+// Password sengaja tidak disertakan karena argv terlihat oleh semua user lewat 'ps';
+// gunakan ClientCommand agar password dikirim lewat --defaults-extra-file.
+func BuildClientArgs(dbConn structs.ServerDBConnection) []string {
+	var args []string
+
+	// Host
+	if dbConn.Host != "" {
+		args = append(args, "--host="+dbConn.Host)
+	}
+
+	// Port
+	if dbConn.Port != 0 {
+		args = append(args, fmt.Sprintf("--port=%d", dbConn.Port))
+	}
+
+	// User
+	if dbConn.User != "" {
+		args = append(args, "--user="+dbConn.User)
+	}
+
+	return args
+}
+
+// ClientCommand membuat exec.Cmd untuk client MariaDB/MySQL dengan password yang ditulis ke
+// file opsi sementara (permission 0600) dan diberikan lewat --defaults-extra-file.
+// cleanup menghapus file tersebut dan wajib dipanggil setelah proses selesai.
+func ClientCommand(ctx context.Context, dbConn structs.ServerDBConnection, name string, args ...string) (cmd *exec.Cmd, cleanup func(), err error) {
+	if err := CheckArgsForPassword(args); err != nil {
+		return nil, nil, err
+	}
+
+	cleanup = func() {}
+	if dbConn.Password != "" {
+		path, err := writeClientOptionFile(dbConn.Password)
+		if err != nil {
+			return nil, nil, err
+		}
+		cleanup = func() { os.Remove(path) }
+		// --defaults-extra-file hanya dikenali jika menjadi argumen pertama
+		args = append([]string{"--defaults-extra-file=" + path}, args...)
+	}
+	return exec.CommandContext(ctx, name, args...), cleanup, nil
+}
+
+// CheckArgsForPassword menolak argumen yang membawa password (--password=..., -p...),
+// misalnya dari backup.mysqldump_args, agar kredensial tidak pernah muncul di argv.
+func CheckArgsForPassword(args []string) error {
+	for _, arg := range args {
+		if arg == "--password" || strings.HasPrefix(arg, "--password=") ||
+			(strings.HasPrefix(arg, "-p") && !strings.HasPrefix(arg, "--")) {
+			// Nilai argumen tidak disertakan pada pesan agar password tidak bocor ke log
+			return fmt.Errorf("argumen client tidak boleh memuat password (--password/-p); kredensial dikirim lewat --defaults-extra-file")
+		}
+	}
+	return nil
+}
+
+// writeClientOptionFile menulis file opsi [client] berisi password ke direktori sementara sistem
+func writeClientOptionFile(password string) (string, error) {
+	// os.CreateTemp membuat file dengan permission 0600
+	file, err := os.CreateTemp("", "sfdbtools-client-*.cnf")
+	if err != nil {
+		return "", fmt.Errorf("gagal membuat file kredensial sementara: %w", err)
+	}
+	path := file.Name()
+	content := "[client]\npassword=" + quoteOptionValue(password) + "\n"
+	if _, err := file.WriteString(content); err != nil {
+		file.Close()
+		os.Remove(path)
+		return "", fmt.Errorf("gagal menulis file kredensial sementara: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(path)
+		return "", fmt.Errorf("gagal menutup file kredensial sementara: %w", err)
+	}
+	return path, nil
+}
+
+// quoteOptionValue mengutip nilai file opsi MariaDB/MySQL agar karakter seperti #, spasi, dan kutip tetap utuh
+func quoteOptionValue(value string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	return `"` + r.Replace(value) + `"`
+}
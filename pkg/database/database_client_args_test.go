@@ -0,0 +1,107 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"sfDBTools/internal/structs"
+)
+
+const testPassword = `s3cr#t "pa ss"\x`
+
+func testConnection() structs.ServerDBConnection {
+	return structs.ServerDBConnection{Host: "db01.example.com", Port: 3306, User: "backup", Password: testPassword}
+}
+
+// TestClientCommandKeepsPasswordOutOfArgv memastikan password tidak pernah muncul di argv
+// client MariaDB/MySQL dan hanya dikirim lewat file opsi 0600 yang dihapus oleh cleanup.
+func TestClientCommandKeepsPasswordOutOfArgv(t *testing.T) {
+	conn := testConnection()
+	connArgs := BuildClientArgs(conn)
+	commands := map[string][]string{
+		"mysqldump":   append(append([]string{}, connArgs...), "--single-transaction", "--routines", "sales"),
+		"mysql":       append(append([]string{}, connArgs...), "--batch", "--execute=SELECT 1"),
+		"mariabackup": append(append([]string{}, connArgs...), "--backup", "--stream=xbstream", "--target-dir=/tmp"),
+		"mysqlbinlog": append(append([]string{}, connArgs...), "--read-from-remote-server", "--raw", "mysql-bin.000001"),
+	}
+
+	for name, args := range commands {
+		cmd, cleanup, err := ClientCommand(context.Background(), conn, name, args...)
+		if err != nil {
+			t.Fatalf("%s: ClientCommand: %v", name, err)
+		}
+
+		for _, arg := range cmd.Args {
+			if strings.Contains(arg, testPassword) || strings.Contains(arg, "s3cr#t") {
+				t.Errorf("%s: argv memuat password: %q", name, arg)
+			}
+		}
+		for _, env := range cmd.Env {
+			if strings.Contains(env, "s3cr#t") {
+				t.Errorf("%s: environment memuat password: %q", name, env)
+			}
+		}
+
+		// --defaults-extra-file harus menjadi argumen pertama setelah nama program
+		if len(cmd.Args) < 2 || !strings.HasPrefix(cmd.Args[1], "--defaults-extra-file=") {
+			t.Fatalf("%s: argumen pertama bukan --defaults-extra-file: %v", name, cmd.Args)
+		}
+		path := strings.TrimPrefix(cmd.Args[1], "--defaults-extra-file=")
+
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("%s: file opsi tidak ada: %v", name, err)
+		}
+		if perm := info.Mode().Perm(); perm != 0600 {
+			t.Errorf("%s: permission file opsi = %o, ingin 600", name, perm)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "[client]\npassword=" + `"s3cr#t \"pa ss\"\\x"` + "\n"; string(content) != want {
+			t.Errorf("%s: isi file opsi = %q, ingin %q", name, content, want)
+		}
+
+		cleanup()
+		if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("%s: file opsi masih ada setelah cleanup (stat error: %v)", name, err)
+		}
+	}
+}
+
+func TestClientCommandWithoutPassword(t *testing.T) {
+	conn := testConnection()
+	conn.Password = ""
+
+	cmd, cleanup, err := ClientCommand(context.Background(), conn, "mysql", BuildClientArgs(conn)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+	for _, arg := range cmd.Args {
+		if strings.HasPrefix(arg, "--defaults-extra-file=") {
+			t.Errorf("file opsi dibuat tanpa password: %v", cmd.Args)
+		}
+	}
+}
+
+func TestClientCommandRejectsPasswordArgs(t *testing.T) {
+	for _, arg := range []string{"--password=" + testPassword, "--password", "-p" + testPassword} {
+		cmd, _, err := ClientCommand(context.Background(), testConnection(), "mysqldump", "--single-transaction", arg)
+		if err == nil {
+			t.Errorf("argumen %q diterima: %v", arg, cmd.Args)
+			continue
+		}
+		if strings.Contains(err.Error(), "s3cr#t") {
+			t.Errorf("pesan error memuat password: %v", err)
+		}
+	}
+
+	if err := CheckArgsForPassword([]string{"--port=3306", "--protocol=tcp", "--skip-lock-tables"}); err != nil {
+		t.Errorf("argumen tanpa password ditolak: %v", err)
+	}
+}
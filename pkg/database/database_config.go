@@ -22,7 +22,8 @@ type Config struct {
 	AllowNativePasswords bool
 	ParseTime            bool
 	Loc                  *time.Location
-	Database             string // Optional, bisa kosong
+	Database             string            // Optional, bisa kosong
+	Params               map[string]string // Optional, variabel sesi tambahan (misal time_zone)
 }
 
 // DSN menghasilkan string Data Source Name (DSN) dari konfigurasi.
@@ -42,6 +43,7 @@ func (c *Config) DSN() string {
 		AllowNativePasswords: c.AllowNativePasswords,
 		ParseTime:            c.ParseTime,
 		Loc:                  loc,
+		Params:               c.Params,
 	}
 	return cfg.FormatDSN()
 }
@@ -135,9 +137,12 @@ func (c *Client) GetVersion(ctx context.Context) (string, error) {
 }
 
 func (c *Client) GetTargetDBConfig() structs.ServerDBConnection {
-	// Jika konfigurasi target DB sudah ada di ScanOptions, gunakan itu.
-	// Jika tidak, fallback ke environment variables.
+	return TargetDBConfig()
+}
 
+// TargetDBConfig mengembalikan koneksi target database (database_details dan katalog backup)
+// dari environment variables SFDB_DB_*.
+func TargetDBConfig() structs.ServerDBConnection {
 	return structs.ServerDBConnection{
 		Host:     GetEnvOrDefault("SFDB_DB_HOST", "localhost"),
 		Port:     GetEnvOrDefaultInt("SFDB_DB_PORT", 3306),
@@ -148,7 +153,12 @@ func (c *Client) GetTargetDBConfig() structs.ServerDBConnection {
 }
 
 func (c *Client) ConnectToTargetDB(ctx context.Context) (*Client, error) {
-	targetConn := c.GetTargetDBConfig()
+	return ConnectTargetDB(ctx)
+}
+
+// ConnectTargetDB membuka koneksi ke target database tanpa memerlukan koneksi ke server sumber
+func ConnectTargetDB(ctx context.Context) (*Client, error) {
+	targetConn := TargetDBConfig()
 
 	client, err := InitializeDatabase(targetConn)
 	if err != nil {
@@ -156,7 +166,7 @@ func (c *Client) ConnectToTargetDB(ctx context.Context) (*Client, error) {
 	}
 
 	// Verify koneksi dengan ping
-	if err := c.Ping(ctx); err != nil {
+	if err := client.Ping(ctx); err != nil {
 		client.Close()
 		return nil, fmt.Errorf("gagal verifikasi koneksi: %w", err)
 	}
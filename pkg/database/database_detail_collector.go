@@ -37,8 +37,9 @@ type DatabaseDetailJob struct {
 	DatabaseName string
 }
 
-// CollectDatabaseDetails mengumpulkan detail informasi untuk semua database secara concurrent
-func (c *Client) CollectDatabaseDetails(ctx context.Context, dbNames []string, logger applog.Logger) map[string]DatabaseDetailInfo {
+// CollectDatabaseDetails mengumpulkan detail informasi untuk semua database secara concurrent.
+// maxWorkers membatasi jumlah worker; 0 atau negatif berarti sejumlah CPU.
+func (c *Client) CollectDatabaseDetails(ctx context.Context, dbNames []string, logger applog.Logger, maxWorkers int) map[string]DatabaseDetailInfo {
 	const jobTimeout = 300 * time.Second // Increase overall timeout
 
 	// If there are no databases, return early.
@@ -47,8 +48,10 @@ func (c *Client) CollectDatabaseDetails(ctx context.Context, dbNames []string, l
 		return map[string]DatabaseDetailInfo{}
 	}
 
-	// Determine number of workers dynamically from available CPUs.
-	maxWorkers := runtime.NumCPU()
+	// Determine number of workers dynamically from available CPUs unless capped by caller.
+	if maxWorkers <= 0 {
+		maxWorkers = runtime.NumCPU()
+	}
 	if maxWorkers < 1 {
 		maxWorkers = 1
 	}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"sfDBTools/internal/structs"
 	"sfDBTools/pkg/ui"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -93,3 +94,67 @@ func (s *Client) GetGTID(ctx context.Context) (bool, string, error) {
 
 	return true, currentPos.String, nil
 }
+
+// IsBinlogEnabled mengembalikan true jika binary logging aktif pada server (@@GLOBAL.log_bin)
+func (s *Client) IsBinlogEnabled(ctx context.Context) (bool, error) {
+	var logBin sql.NullString
+	if err := s.DB().QueryRowContext(ctx, "SELECT @@GLOBAL.log_bin").Scan(&logBin); err != nil {
+		return false, fmt.Errorf("gagal membaca log_bin: %w", err)
+	}
+	value := strings.ToUpper(strings.TrimSpace(logBin.String))
+	return value == "1" || value == "ON", nil
+}
+
+// GetThreadsRunning mengembalikan nilai status global Threads_running sebagai indikator beban server
+func (s *Client) GetThreadsRunning(ctx context.Context) (int, error) {
+	var name string
+	var value int
+	if err := s.DB().QueryRowContext(ctx, "SHOW GLOBAL STATUS LIKE 'Threads_running'").Scan(&name, &value); err != nil {
+		return 0, fmt.Errorf("gagal membaca Threads_running: %w", err)
+	}
+	return value, nil
+}
+
+// GetReplicationLag mengembalikan Seconds_Behind_Master dari SHOW SLAVE STATUS.
+// isReplica bernilai false jika server bukan replika; lag -1 berarti replikasi tidak berjalan (NULL).
+func (s *Client) GetReplicationLag(ctx context.Context) (lag int64, isReplica bool, err error) {
+	rows, err := s.DB().QueryContext(ctx, "SHOW SLAVE STATUS")
+	if err != nil {
+		return 0, false, fmt.Errorf("gagal membaca status replikasi: %w", err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, false, fmt.Errorf("gagal membaca kolom status replikasi: %w", err)
+	}
+	lagIndex := -1
+	for i, col := range columns {
+		if col == "Seconds_Behind_Master" {
+			lagIndex = i
+		}
+	}
+
+	// Multi-source replication dapat mengembalikan beberapa baris; gunakan lag terbesar
+	values := make([]sql.RawBytes, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return 0, false, fmt.Errorf("gagal membaca status replikasi: %w", err)
+		}
+		isReplica = true
+		if lagIndex < 0 || values[lagIndex] == nil {
+			if lag == 0 {
+				lag = -1
+			}
+			continue
+		}
+		if v, err := strconv.ParseInt(string(values[lagIndex]), 10, 64); err == nil && v > lag {
+			lag = v
+		}
+	}
+	return lag, isReplica, rows.Err()
+}
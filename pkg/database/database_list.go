@@ -30,3 +30,29 @@ func (s *Client) GetDatabaseList(ctx context.Context, client *Client) ([]string,
 
 	return databases, nil
 }
+
+// GetTableList mendapatkan daftar tabel dan view dalam sebuah database dari information_schema.
+func (s *Client) GetTableList(ctx context.Context, dbName string) ([]string, error) {
+	var tables []string
+
+	rows, err := s.db.QueryContext(ctx, "SELECT TABLE_NAME FROM information_schema.TABLES WHERE TABLE_SCHEMA = ? ORDER BY TABLE_NAME", dbName)
+	if err != nil {
+		return nil, errors.New("gagal mendapatkan daftar tabel: " + err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tableName string
+		if err := rows.Scan(&tableName); err != nil {
+			return nil, errors.New("gagal membaca nama tabel: " + err.Error())
+		}
+
+		tables = append(tables, tableName)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("terjadi kesalahan saat membaca daftar tabel: " + err.Error())
+	}
+
+	return tables, nil
+}
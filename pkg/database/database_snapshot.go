@@ -0,0 +1,330 @@
+// File : pkg/database/database_snapshot.go
+// Deskripsi : Koneksi consistent snapshot dan query metadata untuk dumper logis native
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sfDBTools/internal/structs"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TableInfo adalah satu tabel atau view pada information_schema.TABLES
+type TableInfo struct {
+	Name string
+	Type string // BASE TABLE, VIEW, SEQUENCE, SYSTEM VERSIONED
+}
+
+// ColumnInfo adalah satu kolom tabel atau view pada information_schema.COLUMNS
+type ColumnInfo struct {
+	Name      string
+	DataType  string // DATA_TYPE, misal int, varchar, blob
+	Generated bool   // Kolom VIRTUAL/STORED GENERATED tidak boleh diisi saat INSERT
+}
+
+// TriggerInfo adalah satu trigger beserta tabelnya
+type TriggerInfo struct {
+	Name  string
+	Table string
+}
+
+// RoutineInfo adalah satu stored procedure atau function
+type RoutineInfo struct {
+	Name string
+	Type string // PROCEDURE atau FUNCTION
+}
+
+// BinlogCoordinates adalah posisi binlog dan GTID yang konsisten dengan snapshot
+type BinlogCoordinates struct {
+	File     string
+	Position int64
+	GTID     string
+}
+
+// Snapshot adalah satu koneksi khusus yang memegang transaksi consistent snapshot.
+// Semua query dump harus melalui Snapshot yang sama agar data yang dibaca konsisten.
+type Snapshot struct {
+	conn *sql.Conn
+}
+
+// NewDumpClient membuka client khusus dump logis. Nilai kolom dibaca apa adanya (tanpa parseTime)
+// agar tanggal nol dan presisi pecahan detik tidak berubah, dan zona waktu sesi diset UTC
+// sehingga nilai TIMESTAMP tidak bergantung pada zona waktu server.
+func NewDumpClient(creds structs.ServerDBConnection) (*Client, error) {
+	cfg := Config{
+		Host:                 creds.Host,
+		Port:                 creds.Port,
+		User:                 creds.User,
+		Password:             creds.Password,
+		AllowNativePasswords: true,
+		ParseTime:            false,
+		Loc:                  time.UTC,
+		Params:               map[string]string{"time_zone": "'+00:00'"},
+	}
+
+	client, err := NewClient(context.Background(), cfg, 5*time.Second, 2, 1, 0)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuka koneksi dump: %w", err)
+	}
+	return client, nil
+}
+
+// BeginSnapshot mengambil satu koneksi dari pool lalu memulai transaksi REPEATABLE READ
+// WITH CONSISTENT SNAPSHOT. Jika withReadLock true, FLUSH TABLES WITH READ LOCK dipegang
+// sampai Unlock dipanggil agar koordinat binlog dapat dibaca konsisten dengan snapshot.
+func (c *Client) BeginSnapshot(ctx context.Context, withReadLock bool) (*Snapshot, error) {
+	conn, err := c.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil koneksi dump: %w", err)
+	}
+
+	statements := []string{"SET SESSION TRANSACTION ISOLATION LEVEL REPEATABLE READ"}
+	if withReadLock {
+		statements = append(statements, "FLUSH TABLES WITH READ LOCK")
+	}
+	statements = append(statements, "START TRANSACTION /*!40100 WITH CONSISTENT SNAPSHOT */")
+
+	for _, stmt := range statements {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			if withReadLock {
+				conn.ExecContext(ctx, "UNLOCK TABLES")
+			}
+			conn.Close()
+			return nil, fmt.Errorf("gagal menjalankan %q: %w", stmt, err)
+		}
+	}
+	return &Snapshot{conn: conn}, nil
+}
+
+// Unlock melepas FLUSH TABLES WITH READ LOCK; snapshot tetap berlaku
+func (sn *Snapshot) Unlock(ctx context.Context) error {
+	if _, err := sn.conn.ExecContext(ctx, "UNLOCK TABLES"); err != nil {
+		return fmt.Errorf("gagal melepas read lock: %w", err)
+	}
+	return nil
+}
+
+// Close mengakhiri transaksi snapshot dan mengembalikan koneksi
+func (sn *Snapshot) Close() error {
+	sn.conn.ExecContext(context.Background(), "ROLLBACK")
+	return sn.conn.Close()
+}
+
+// MasterCoordinates membaca posisi binlog server ini (SHOW MASTER STATUS) dan gtid_binlog_pos
+func (sn *Snapshot) MasterCoordinates(ctx context.Context) (BinlogCoordinates, error) {
+	var coords BinlogCoordinates
+	status, found, err := sn.queryRowMap(ctx, "SHOW MASTER STATUS")
+	if err != nil {
+		return coords, fmt.Errorf("gagal membaca status master: %w", err)
+	}
+	if !found {
+		return coords, fmt.Errorf("binary log tidak aktif pada server")
+	}
+	coords.File = status["File"]
+	coords.Position, _ = strconv.ParseInt(status["Position"], 10, 64)
+	coords.GTID = sn.gtidVariable(ctx, "gtid_binlog_pos")
+	return coords, nil
+}
+
+// SlaveCoordinates membaca posisi master yang sudah dieksekusi replika (SHOW SLAVE STATUS) dan gtid_slave_pos.
+// Dipanggil selama read lock dipegang sehingga SQL thread replika tidak dapat commit dan posisinya stabil.
+func (sn *Snapshot) SlaveCoordinates(ctx context.Context) (BinlogCoordinates, error) {
+	var coords BinlogCoordinates
+	status, found, err := sn.queryRowMap(ctx, "SHOW SLAVE STATUS")
+	if err != nil {
+		return coords, fmt.Errorf("gagal membaca status replikasi: %w", err)
+	}
+	if !found {
+		return coords, fmt.Errorf("server bukan replika")
+	}
+	coords.File = status["Relay_Master_Log_File"]
+	coords.Position, _ = strconv.ParseInt(status["Exec_Master_Log_Pos"], 10, 64)
+	coords.GTID = sn.gtidVariable(ctx, "gtid_slave_pos")
+	return coords, nil
+}
+
+// gtidVariable membaca variabel GTID MariaDB, dengan fallback ke gtid_executed (MySQL)
+func (sn *Snapshot) gtidVariable(ctx context.Context, mariadbVariable string) string {
+	var value sql.NullString
+	if err := sn.conn.QueryRowContext(ctx, "SELECT @@GLOBAL."+mariadbVariable).Scan(&value); err == nil {
+		return value.String
+	}
+	if err := sn.conn.QueryRowContext(ctx, "SELECT @@GLOBAL.gtid_executed").Scan(&value); err == nil {
+		return strings.ReplaceAll(value.String, "\n", "")
+	}
+	return ""
+}
+
+// ShowCreateDatabase mengembalikan statement CREATE DATABASE
+func (sn *Snapshot) ShowCreateDatabase(ctx context.Context, dbName string) (string, error) {
+	row, err := sn.showCreate(ctx, "SHOW CREATE DATABASE "+QuoteIdentifier(dbName))
+	if err != nil {
+		return "", err
+	}
+	return row["Create Database"], nil
+}
+
+// ShowCreate menjalankan SHOW CREATE untuk TABLE, VIEW, TRIGGER, PROCEDURE, atau FUNCTION
+// dan mengembalikan seluruh kolom hasilnya (misal "Create Table", "sql_mode", "SQL Original Statement").
+func (sn *Snapshot) ShowCreate(ctx context.Context, objectType, dbName, name string) (map[string]string, error) {
+	return sn.showCreate(ctx, fmt.Sprintf("SHOW CREATE %s %s.%s", objectType, QuoteIdentifier(dbName), QuoteIdentifier(name)))
+}
+
+func (sn *Snapshot) showCreate(ctx context.Context, query string) (map[string]string, error) {
+	row, found, err := sn.queryRowMap(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("gagal menjalankan %s: %w", query, err)
+	}
+	if !found {
+		return nil, fmt.Errorf("%s tidak mengembalikan hasil", query)
+	}
+	return row, nil
+}
+
+// ListTables mengembalikan semua tabel dan view pada database, urut berdasarkan nama
+func (sn *Snapshot) ListTables(ctx context.Context, dbName string) ([]TableInfo, error) {
+	rows, err := sn.conn.QueryContext(ctx, "SELECT TABLE_NAME, TABLE_TYPE FROM information_schema.TABLES WHERE TABLE_SCHEMA = ? ORDER BY TABLE_NAME", dbName)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mendapatkan daftar tabel %s: %w", dbName, err)
+	}
+	defer rows.Close()
+
+	var tables []TableInfo
+	for rows.Next() {
+		var t TableInfo
+		if err := rows.Scan(&t.Name, &t.Type); err != nil {
+			return nil, fmt.Errorf("gagal membaca daftar tabel %s: %w", dbName, err)
+		}
+		tables = append(tables, t)
+	}
+	return tables, rows.Err()
+}
+
+// ListColumns mengembalikan kolom tabel atau view sesuai urutan definisi, termasuk kolom invisible
+func (sn *Snapshot) ListColumns(ctx context.Context, dbName, table string) ([]ColumnInfo, error) {
+	rows, err := sn.conn.QueryContext(ctx, "SELECT COLUMN_NAME, DATA_TYPE, EXTRA FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? ORDER BY ORDINAL_POSITION", dbName, table)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mendapatkan kolom %s.%s: %w", dbName, table, err)
+	}
+	defer rows.Close()
+
+	var columns []ColumnInfo
+	for rows.Next() {
+		var col ColumnInfo
+		var extra string
+		if err := rows.Scan(&col.Name, &col.DataType, &extra); err != nil {
+			return nil, fmt.Errorf("gagal membaca kolom %s.%s: %w", dbName, table, err)
+		}
+		col.DataType = strings.ToLower(col.DataType)
+		col.Generated = strings.Contains(strings.ToUpper(extra), "GENERATED")
+		columns = append(columns, col)
+	}
+	return columns, rows.Err()
+}
+
+// ListTriggers mengembalikan trigger pada database, urut per tabel sesuai urutan eksekusi
+func (sn *Snapshot) ListTriggers(ctx context.Context, dbName string) ([]TriggerInfo, error) {
+	rows, err := sn.conn.QueryContext(ctx, "SELECT TRIGGER_NAME, EVENT_OBJECT_TABLE FROM information_schema.TRIGGERS WHERE TRIGGER_SCHEMA = ? ORDER BY EVENT_OBJECT_TABLE, ACTION_ORDER", dbName)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mendapatkan daftar trigger %s: %w", dbName, err)
+	}
+	defer rows.Close()
+
+	var triggers []TriggerInfo
+	for rows.Next() {
+		var t TriggerInfo
+		if err := rows.Scan(&t.Name, &t.Table); err != nil {
+			return nil, fmt.Errorf("gagal membaca daftar trigger %s: %w", dbName, err)
+		}
+		triggers = append(triggers, t)
+	}
+	return triggers, rows.Err()
+}
+
+// ListRoutines mengembalikan stored procedure dan function pada database
+func (sn *Snapshot) ListRoutines(ctx context.Context, dbName string) ([]RoutineInfo, error) {
+	rows, err := sn.conn.QueryContext(ctx, "SELECT ROUTINE_NAME, ROUTINE_TYPE FROM information_schema.ROUTINES WHERE ROUTINE_SCHEMA = ? ORDER BY ROUTINE_TYPE, ROUTINE_NAME", dbName)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mendapatkan daftar routine %s: %w", dbName, err)
+	}
+	defer rows.Close()
+
+	var routines []RoutineInfo
+	for rows.Next() {
+		var r RoutineInfo
+		if err := rows.Scan(&r.Name, &r.Type); err != nil {
+			return nil, fmt.Errorf("gagal membaca daftar routine %s: %w", dbName, err)
+		}
+		routines = append(routines, r)
+	}
+	return routines, rows.Err()
+}
+
+// QueryTableRows membaca kolom yang diminta dari seluruh baris tabel secara streaming.
+// Query tanpa parameter memakai text protocol sehingga setiap nilai dapat di-scan sebagai sql.RawBytes.
+func (sn *Snapshot) QueryTableRows(ctx context.Context, dbName, table string, columns []string) (*sql.Rows, error) {
+	quoted := make([]string, len(columns))
+	for i, col := range columns {
+		quoted[i] = QuoteIdentifier(col)
+	}
+	query := fmt.Sprintf("SELECT /*!40001 SQL_NO_CACHE */ %s FROM %s.%s", strings.Join(quoted, ", "), QuoteIdentifier(dbName), QuoteIdentifier(table))
+	rows, err := sn.conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca data %s.%s: %w", dbName, table, err)
+	}
+	return rows, nil
+}
+
+// SequenceNextValue membaca next_not_cached_value dari SEQUENCE MariaDB
+func (sn *Snapshot) SequenceNextValue(ctx context.Context, dbName, sequence string) (string, error) {
+	var value string
+	query := fmt.Sprintf("SELECT next_not_cached_value FROM %s.%s", QuoteIdentifier(dbName), QuoteIdentifier(sequence))
+	if err := sn.conn.QueryRowContext(ctx, query).Scan(&value); err != nil {
+		return "", fmt.Errorf("gagal membaca nilai sequence %s.%s: %w", dbName, sequence, err)
+	}
+	return value, nil
+}
+
+// queryRowMap menjalankan query dan mengembalikan baris pertama sebagai map nama kolom -> nilai
+func (sn *Snapshot) queryRowMap(ctx context.Context, query string) (map[string]string, bool, error) {
+	rows, err := sn.conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, false, err
+	}
+	if !rows.Next() {
+		return nil, false, rows.Err()
+	}
+
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return nil, false, err
+	}
+
+	result := make(map[string]string, len(columns))
+	for i, col := range columns {
+		result[col] = values[i].String
+	}
+	return result, true, nil
+}
+
+// QuoteIdentifier membungkus nama database/tabel/kolom dengan backtick
+func QuoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
@@ -4,14 +4,16 @@ package dbconfig
 // Deskripsi: Fungsi umum untuk memuat dan menampilkan konfigurasi database
 // Author: Hadiyatna Muflihun
 // Tanggal: 16 Oktober 2025
-// Last Modified: 16 Oktober 2025
+// Last Modified: 16 Oktober 2026
 
 import (
 	"fmt"
 	"sfDBTools/internal/structs"
 	"sfDBTools/pkg/common"
 	"sfDBTools/pkg/encrypt"
+	"sfDBTools/pkg/exitcode"
 	"sfDBTools/pkg/globals"
+	"sfDBTools/pkg/input"
 	"sfDBTools/pkg/ui"
 )
 
@@ -30,6 +32,12 @@ func CheckAndSelectConfigFile(configInfo *structs.DBConfigInfo, encryptionKey st
 	ui.PrintSubHeader("Memeriksa File Konfigurasi")
 
 	if configInfo.FilePath == "" {
+		if input.IsNonInteractive() {
+			return &input.NonInteractiveError{
+				Prompt: "Pilih file konfigurasi database",
+				Hint:   "gunakan --config <file> atau set SFDB_CONFIG_FILE",
+			}
+		}
 		// Jalankan mode interaktif jika tidak ada file yang ditentukan
 		ui.PrintWarning("Tidak ada file konfigurasi yang ditentukan. Menjalankan mode interaktif...")
 
@@ -40,13 +48,13 @@ func CheckAndSelectConfigFile(configInfo *structs.DBConfigInfo, encryptionKey st
 		selectedConfig, err := encrypt.SelectExistingDBConfig(promptMessage)
 		if err != nil {
 			logger.Warn("Proses pemilihan file konfigurasi gagal: " + err.Error())
-			return err
+			return configError(err)
 		}
 		*configInfo = selectedConfig
 	} else {
 		// Muat konfigurasi dari file yang sudah ditentukan
 		if err := LoadAndApplyConfigFromFile(configInfo, encryptionKey); err != nil {
-			return err
+			return configError(err)
 		}
 	}
 
@@ -54,6 +62,15 @@ func CheckAndSelectConfigFile(configInfo *structs.DBConfigInfo, encryptionKey st
 	return nil
 }
 
+// configError menandai kegagalan memuat konfigurasi sebagai kesalahan konfigurasi (exit code 3).
+// Pembatalan oleh user dikembalikan apa adanya karena pemanggil membandingkannya langsung.
+func configError(err error) error {
+	if err == common.ErrUserCancelled {
+		return err
+	}
+	return exitcode.AsConfigError(err)
+}
+
 // LoadAndApplyConfigFromFile memuat dan menerapkan konfigurasi dari file yang ditentukan.
 // Fungsi ini adalah helper internal untuk memusatkan logika pemuatan, parsing, dan penerapan konfigurasi.
 //
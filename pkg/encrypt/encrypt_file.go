@@ -2,7 +2,7 @@
 // Deskripsi : Fungsi utilitas untuk enkripsi dan dekripsi file backup
 // Author : Hadiyatna Muflihun
 // Tanggal : 2024-10-14
-// Last Modified : 2024-10-14
+// Last Modified : 16 Oktober 2026
 package encrypt
 
 import (
@@ -25,41 +25,64 @@ func EncryptFile(inputPath, outputPath string, passphrase []byte) error {
 	if err != nil {
 		return fmt.Errorf("gagal membuat file output: %w", err)
 	}
-	defer outputFile.Close()
+	// Output parsial dihapus jika enkripsi gagal agar tidak tertinggal file yang tidak dapat didekripsi
+	fail := func(err error) error {
+		outputFile.Close()
+		os.Remove(outputPath)
+		return err
+	}
 
-	// Buat encrypting writer
-	encryptingWriter, err := NewEncryptingWriter(outputFile, passphrase)
+	// Buat encrypting writer; file output dibungkus agar hanya ditutup di sini setelah chunk final ditulis
+	encryptingWriter, err := NewEncryptingWriter(struct{ io.Writer }{outputFile}, passphrase)
 	if err != nil {
-		return fmt.Errorf("gagal membuat encrypting writer: %w", err)
+		return fail(fmt.Errorf("gagal membuat encrypting writer: %w", err))
 	}
-	defer encryptingWriter.Close()
 
 	// Copy file dengan enkripsi
-	_, err = io.Copy(encryptingWriter, inputFile)
-	if err != nil {
-		return fmt.Errorf("gagal mengenkripsi file: %w", err)
+	if _, err := io.Copy(encryptingWriter, inputFile); err != nil {
+		return fail(fmt.Errorf("gagal mengenkripsi file: %w", err))
+	}
+
+	// Close menulis chunk final yang terautentikasi; tanpa chunk ini file tidak dapat didekripsi
+	if err := encryptingWriter.Close(); err != nil {
+		return fail(fmt.Errorf("gagal menyelesaikan enkripsi file: %w", err))
+	}
+	if err := outputFile.Close(); err != nil {
+		os.Remove(outputPath)
+		return fmt.Errorf("gagal menulis file terenkripsi: %w", err)
 	}
 
 	return nil
 }
 
-// DecryptFile mendekripsi file menggunakan passphrase
+// DecryptFile mendekripsi file menggunakan passphrase secara streaming
 func DecryptFile(inputPath, outputPath string, passphrase []byte) error {
-	// Baca file terenkripsi
-	encryptedData, err := os.ReadFile(inputPath)
+	// Buka file terenkripsi
+	inputFile, err := os.Open(inputPath)
 	if err != nil {
 		return fmt.Errorf("gagal membaca file terenkripsi: %w", err)
 	}
+	defer inputFile.Close()
 
-	// Dekripsi data
-	plaintext, err := DecryptAES(encryptedData, passphrase)
+	decryptingReader, err := NewDecryptingReader(inputFile, passphrase)
 	if err != nil {
 		return fmt.Errorf("gagal mendekripsi file: %w", err)
 	}
 
-	// Tulis hasil dekripsi
-	err = os.WriteFile(outputPath, plaintext, 0644)
+	// Buat file output
+	outputFile, err := os.Create(outputPath)
 	if err != nil {
+		return fmt.Errorf("gagal membuat file output: %w", err)
+	}
+
+	// Copy hasil dekripsi; hapus output parsial jika autentikasi chunk gagal
+	if _, err := io.Copy(outputFile, decryptingReader); err != nil {
+		outputFile.Close()
+		os.Remove(outputPath)
+		return fmt.Errorf("gagal mendekripsi file: %w", err)
+	}
+
+	if err := outputFile.Close(); err != nil {
 		return fmt.Errorf("gagal menulis file hasil dekripsi: %w", err)
 	}
 
@@ -67,6 +90,7 @@ func DecryptFile(inputPath, outputPath string, passphrase []byte) error {
 }
 
 // IsEncryptedFile memeriksa apakah file adalah file terenkripsi berdasarkan header
+// (format stream "SFDBENC" maupun format lama "Salted__")
 func IsEncryptedFile(filePath string) (bool, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
@@ -74,23 +98,24 @@ func IsEncryptedFile(filePath string) (bool, error) {
 	}
 	defer file.Close()
 
-	// Baca 8 byte pertama untuk cek header "Salted__"
-	header := make([]byte, 8)
-	n, err := file.Read(header)
-	if err != nil && err != io.EOF {
+	// Baca 8 byte pertama untuk cek header
+	header := make([]byte, EncryptedHeaderSize)
+	n, err := io.ReadFull(file, header)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
 		return false, fmt.Errorf("gagal membaca header file: %w", err)
 	}
 
-	if n < 8 {
-		return false, nil // File terlalu kecil untuk menjadi file terenkripsi
-	}
+	return IsEncryptedHeader(header[:n]), nil
+}
 
-	opensslHeader := []byte("Salted__")
-	for i := 0; i < 8; i++ {
-		if header[i] != opensslHeader[i] {
-			return false, nil
-		}
-	}
+// EncryptedHeaderSize adalah jumlah byte awal yang cukup untuk mengenali file terenkripsi
+const EncryptedHeaderSize = len(legacyHeader)
 
-	return true, nil
+// IsEncryptedHeader memeriksa apakah byte awal sebuah stream merupakan header file terenkripsi.
+// Dipakai untuk stream yang tidak dapat dibuka ulang berdasarkan path, misalnya object di remote storage.
+func IsEncryptedHeader(header []byte) bool {
+	if len(header) >= len(streamMagic) && string(header[:len(streamMagic)]) == streamMagic {
+		return true
+	}
+	return len(header) >= len(legacyHeader) && string(header[:len(legacyHeader)]) == legacyHeader
 }
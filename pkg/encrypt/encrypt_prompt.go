@@ -2,7 +2,7 @@
 // Deskripsi : Fungsi utilitas untuk prompt input user pada modul enkripsi
 // Author : Hadiyatna Muflihun
 // Tanggal : 2024-10-03
-// Last Modified : 2024-10-03
+// Last Modified : 16 Oktober 2026
 
 package encrypt
 
@@ -25,6 +25,11 @@ func EncryptionPrompt(promptMessage string) (string, string, error) {
 	// Jika tidak, minta user memasukkan password
 	if password := os.Getenv(common.SFDB_ENCRYPTION_KEY); password != "" {
 		return password, "env", nil
+	} else if input.IsNonInteractive() {
+		return "", "", &input.NonInteractiveError{
+			Prompt: "Encryption Password",
+			Hint:   "set environment variable SFDB_ENCRYPTION_KEY atau gunakan flag --encryption-key/--encrypt-key",
+		}
 	} else {
 		ui.PrintSubHeader("Authentication Required")
 		ui.PrintWarning("Environment variable SFDB_ENCRYPTION_KEY tidak ditemukan atau kosong. Silakan atur SFDB_ENCRYPTION_KEY atau ketik password.")
@@ -0,0 +1,161 @@
+// File : pkg/encrypt/encrypt_reader.go
+// Deskripsi : Reader untuk dekripsi streaming file yang dienkripsi oleh EncryptingWriter
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+package encrypt
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// legacyHeader adalah header format lama (satu blok GCM untuk seluruh data)
+const legacyHeader = "Salted__"
+
+// DecryptingReader adalah reader yang mendekripsi data secara streaming per chunk.
+// File format lama ("Salted__") tetap didukung, namun harus dibaca utuh ke memori
+// karena tag autentikasinya baru tersedia di akhir data.
+type DecryptingReader struct {
+	reader      *bufio.Reader
+	legacy      io.Reader
+	gcm         cipher.AEAD
+	header      []byte
+	noncePrefix []byte
+	counter     uint32
+	sealed      []byte
+	plaintext   []byte
+	pending     []byte
+	done        bool
+}
+
+// NewDecryptingReader membuat reader yang mengembalikan plaintext dari data terenkripsi di r.
+// Format data dideteksi otomatis dari header (stream v1 atau format lama).
+func NewDecryptingReader(r io.Reader, passphrase []byte) (*DecryptingReader, error) {
+	br := bufio.NewReaderSize(r, streamChunkSize)
+
+	magic, err := br.Peek(len(legacyHeader))
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca header enkripsi: %w", err)
+	}
+
+	if string(magic) == legacyHeader {
+		return newLegacyDecryptingReader(br, passphrase)
+	}
+	if string(magic[:len(streamMagic)]) != streamMagic {
+		return nil, fmt.Errorf("format enkripsi tidak dikenali")
+	}
+
+	header := make([]byte, streamHeaderSize)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, fmt.Errorf("header enkripsi tidak lengkap: %w", err)
+	}
+
+	offset := len(streamMagic)
+	if version := header[offset]; version != streamVersion1 {
+		return nil, fmt.Errorf("versi format enkripsi tidak didukung: %d", version)
+	}
+	offset++
+
+	chunkSize := int(binary.BigEndian.Uint32(header[offset : offset+4]))
+	if chunkSize <= 0 || chunkSize > streamMaxChunkSize {
+		return nil, fmt.Errorf("ukuran chunk enkripsi tidak valid: %d", chunkSize)
+	}
+	offset += 4
+
+	salt := header[offset : offset+streamSaltSize]
+	offset += streamSaltSize
+	noncePrefix := header[offset : offset+streamNoncePrefixSize]
+
+	gcm, err := newStreamGCM(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DecryptingReader{
+		reader:      br,
+		gcm:         gcm,
+		header:      header,
+		noncePrefix: noncePrefix,
+		sealed:      make([]byte, chunkSize+gcm.Overhead()),
+		plaintext:   make([]byte, 0, chunkSize),
+	}, nil
+}
+
+// newLegacyDecryptingReader mendekripsi file format lama secara utuh di memori
+func newLegacyDecryptingReader(r io.Reader, passphrase []byte) (*DecryptingReader, error) {
+	encryptedData, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca data terenkripsi: %w", err)
+	}
+
+	plaintext, err := DecryptAES(encryptedData, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mendekripsi data: %w", err)
+	}
+
+	return &DecryptingReader{legacy: bytes.NewReader(plaintext)}, nil
+}
+
+// Read mengembalikan plaintext; setiap chunk diverifikasi sebelum datanya dikeluarkan
+func (dr *DecryptingReader) Read(p []byte) (int, error) {
+	if dr.legacy != nil {
+		return dr.legacy.Read(p)
+	}
+
+	for len(dr.pending) == 0 {
+		if dr.done {
+			return 0, io.EOF
+		}
+		if err := dr.openChunk(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, dr.pending)
+	dr.pending = dr.pending[n:]
+	return n, nil
+}
+
+// openChunk membaca dan mendekripsi chunk berikutnya
+func (dr *DecryptingReader) openChunk() error {
+	n, err := io.ReadFull(dr.reader, dr.sealed)
+
+	var final bool
+	switch {
+	case err == nil:
+		// Chunk penuh: final jika tidak ada data lagi setelahnya
+		if _, peekErr := dr.reader.Peek(1); peekErr != nil {
+			if !errors.Is(peekErr, io.EOF) {
+				return fmt.Errorf("gagal membaca data terenkripsi: %w", peekErr)
+			}
+			final = true
+		}
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		final = true
+	case errors.Is(err, io.EOF):
+		return fmt.Errorf("data terenkripsi terpotong: chunk final tidak ditemukan")
+	default:
+		return fmt.Errorf("gagal membaca data terenkripsi: %w", err)
+	}
+
+	if n < dr.gcm.Overhead() {
+		return fmt.Errorf("data terenkripsi terpotong pada chunk %d", dr.counter)
+	}
+
+	nonce := buildChunkNonce(dr.noncePrefix, dr.counter, final)
+	plaintext, err := dr.gcm.Open(dr.plaintext[:0], nonce, dr.sealed[:n], dr.header)
+	if err != nil {
+		return fmt.Errorf("gagal mendekripsi chunk %d (kunci salah, data rusak, atau terpotong): %w", dr.counter, err)
+	}
+
+	dr.pending = plaintext
+	dr.counter++
+	dr.done = final
+	return nil
+}
@@ -0,0 +1,239 @@
+package encrypt
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var testPassphrase = []byte("rahasia-backup")
+
+// sealedChunkSize adalah ukuran satu chunk penuh di dalam stream (ciphertext + tag GCM)
+const sealedChunkSize = streamChunkSize + 16
+
+func randomBytes(t *testing.T, n int) []byte {
+	t.Helper()
+	data := make([]byte, n)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func encryptStream(t *testing.T, plaintext, passphrase []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	ew, err := NewEncryptingWriter(&buf, passphrase)
+	if err != nil {
+		t.Fatalf("NewEncryptingWriter: %v", err)
+	}
+	if _, err := ew.Write(plaintext); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := ew.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func decryptStream(data, passphrase []byte) ([]byte, error) {
+	dr, err := NewDecryptingReader(bytes.NewReader(data), passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(dr)
+}
+
+func TestStreamRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		size   int
+		chunks int // Jumlah chunk yang diharapkan di dalam stream
+	}{
+		{"kosong", 0, 1},
+		{"satu byte", 1, 1},
+		{"tepat satu chunk", streamChunkSize, 1},
+		{"satu chunk plus satu", streamChunkSize + 1, 2},
+		{"tiga chunk minus satu", 3*streamChunkSize - 1, 3},
+		{"tepat tiga chunk", 3 * streamChunkSize, 3},
+		{"tiga chunk plus satu", 3*streamChunkSize + 1, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plaintext := randomBytes(t, tt.size)
+			encrypted := encryptStream(t, plaintext, testPassphrase)
+
+			if want := streamHeaderSize + tt.size + tt.chunks*16; len(encrypted) != want {
+				t.Errorf("ukuran stream = %d, ingin %d", len(encrypted), want)
+			}
+
+			decrypted, err := decryptStream(encrypted, testPassphrase)
+			if err != nil {
+				t.Fatalf("dekripsi: %v", err)
+			}
+			if !bytes.Equal(decrypted, plaintext) {
+				t.Errorf("plaintext tidak sama setelah round-trip (%d byte, ingin %d)", len(decrypted), len(plaintext))
+			}
+		})
+	}
+}
+
+func TestStreamRoundTripSmallWrites(t *testing.T) {
+	plaintext := randomBytes(t, 2*streamChunkSize+123)
+
+	var buf bytes.Buffer
+	ew, err := NewEncryptingWriter(&buf, testPassphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for rest := plaintext; len(rest) > 0; {
+		n := min(len(rest), 1000)
+		if _, err := ew.Write(rest[:n]); err != nil {
+			t.Fatal(err)
+		}
+		rest = rest[n:]
+	}
+	if err := ew.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	decrypted, err := decryptStream(buf.Bytes(), testPassphrase)
+	if err != nil {
+		t.Fatalf("dekripsi: %v", err)
+	}
+	if !bytes.Equal(decrypted, plaintext) {
+		t.Error("plaintext tidak sama setelah round-trip dengan write kecil")
+	}
+}
+
+func TestStreamRejectsTamperedData(t *testing.T) {
+	plaintext := randomBytes(t, 3*streamChunkSize)
+	encrypted := encryptStream(t, plaintext, testPassphrase)
+
+	tests := []struct {
+		name   string
+		mutate func([]byte) []byte
+	}{
+		{"terpotong di batas chunk", func(b []byte) []byte {
+			return b[:streamHeaderSize+2*sealedChunkSize]
+		}},
+		{"terpotong setelah header", func(b []byte) []byte {
+			return b[:streamHeaderSize]
+		}},
+		{"terpotong di tengah chunk", func(b []byte) []byte {
+			return b[:streamHeaderSize+sealedChunkSize+100]
+		}},
+		{"byte ciphertext dibalik", func(b []byte) []byte {
+			b[streamHeaderSize+sealedChunkSize+10] ^= 0x01
+			return b
+		}},
+		{"tag chunk final dibalik", func(b []byte) []byte {
+			b[len(b)-1] ^= 0x80
+			return b
+		}},
+		{"nonce prefix di header diubah", func(b []byte) []byte {
+			b[streamHeaderSize-1] ^= 0x01
+			return b
+		}},
+		{"salt di header diubah", func(b []byte) []byte {
+			b[len(streamMagic)+5] ^= 0x01
+			return b
+		}},
+		{"ukuran chunk di header diubah", func(b []byte) []byte {
+			b[len(streamMagic)+3] ^= 0x01
+			return b
+		}},
+		{"versi tidak dikenal", func(b []byte) []byte {
+			b[len(streamMagic)] = 2
+			return b
+		}},
+		{"magic rusak", func(b []byte) []byte {
+			b[0] = 'X'
+			return b
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.mutate(bytes.Clone(encrypted))
+			decrypted, err := decryptStream(data, testPassphrase)
+			if err == nil {
+				t.Fatalf("dekripsi berhasil (%d byte), ingin error", len(decrypted))
+			}
+		})
+	}
+}
+
+func TestStreamWrongPassphrase(t *testing.T) {
+	encrypted := encryptStream(t, []byte("isi backup"), testPassphrase)
+	if _, err := decryptStream(encrypted, []byte("passphrase-salah")); err == nil {
+		t.Fatal("dekripsi dengan passphrase salah berhasil, ingin error")
+	}
+}
+
+func TestDecryptingReaderLegacyFormat(t *testing.T) {
+	plaintext := []byte("CREATE TABLE t (id INT);\nINSERT INTO t VALUES (1);\n")
+	legacy, err := EncryptAES(plaintext, testPassphrase)
+	if err != nil {
+		t.Fatalf("EncryptAES: %v", err)
+	}
+	if !bytes.HasPrefix(legacy, []byte(legacyHeader)) {
+		t.Fatalf("EncryptAES tidak menghasilkan header %q", legacyHeader)
+	}
+
+	decrypted, err := decryptStream(legacy, testPassphrase)
+	if err != nil {
+		t.Fatalf("dekripsi format lama: %v", err)
+	}
+	if !bytes.Equal(decrypted, plaintext) {
+		t.Errorf("plaintext format lama = %q, ingin %q", decrypted, plaintext)
+	}
+
+	if _, err := decryptStream(legacy, []byte("passphrase-salah")); err == nil {
+		t.Error("dekripsi format lama dengan passphrase salah berhasil, ingin error")
+	}
+}
+
+func TestEncryptFileRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	plainPath := filepath.Join(dir, "dump.sql")
+	encPath := filepath.Join(dir, "dump.sql.enc")
+	outPath := filepath.Join(dir, "dump.restored.sql")
+
+	plaintext := randomBytes(t, 2*streamChunkSize+7)
+	if err := os.WriteFile(plainPath, plaintext, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := EncryptFile(plainPath, encPath, testPassphrase); err != nil {
+		t.Fatalf("EncryptFile: %v", err)
+	}
+	if err := DecryptFile(encPath, outPath, testPassphrase); err != nil {
+		t.Fatalf("DecryptFile: %v", err)
+	}
+
+	restored, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(restored, plaintext) {
+		t.Error("isi file tidak sama setelah EncryptFile/DecryptFile")
+	}
+}
+
+func TestEncryptFileRemovesOutputOnFailure(t *testing.T) {
+	dir := t.TempDir()
+	encPath := filepath.Join(dir, "dump.sql.enc")
+
+	// Membaca direktori sebagai input gagal di io.Copy setelah header sudah ditulis
+	if err := EncryptFile(dir, encPath, testPassphrase); err == nil {
+		t.Fatal("EncryptFile dengan input direktori berhasil, ingin error")
+	}
+	if _, err := os.Stat(encPath); !os.IsNotExist(err) {
+		t.Errorf("file output parsial masih ada (stat error: %v)", err)
+	}
+}
@@ -1,111 +1,115 @@
 // File : pkg/encrypt/encrypt_writer.go
-// Deskripsi : Writer untuk enkripsi streaming AES
+// Deskripsi : Writer untuk enkripsi streaming AES-GCM dengan format chunk (constant memory)
 // Author : Hadiyatna Muflihun
 // Tanggal : 2024-10-14
-// Last Modified : 2024-10-14
+// Last Modified : 16 Oktober 2026
 package encrypt
 
 import (
-	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
-	"crypto/sha256"
+	"encoding/binary"
 	"fmt"
 	"io"
+)
 
-	"golang.org/x/crypto/pbkdf2"
+// Format stream v1:
+//
+//	header  : magic "SFDBENC" (7) | versi (1) | ukuran chunk uint32 BE (4) | salt (16) | nonce prefix (7)
+//	chunk-N : AES-256-GCM(plaintext <= ukuran chunk) + tag 16 byte
+//
+// Nonce setiap chunk = nonce prefix (7) | counter uint32 BE (4) | flag final (1).
+// Header dipakai sebagai additional data sehingga parameter stream ikut terautentikasi.
+// Flag final pada nonce membuat pemotongan stream tepat di batas chunk tetap terdeteksi.
+const (
+	streamMagic           = "SFDBENC"
+	streamVersion1        = byte(1)
+	streamChunkSize       = 64 * 1024
+	streamMaxChunkSize    = 16 * 1024 * 1024
+	streamSaltSize        = 16
+	streamNoncePrefixSize = 7
+	streamHeaderSize      = len(streamMagic) + 1 + 4 + streamSaltSize + streamNoncePrefixSize
 )
 
-// EncryptingWriter adalah writer yang mengenkripsi data secara streaming
+// EncryptingWriter adalah writer yang mengenkripsi data secara streaming per chunk.
+// Memori yang digunakan konstan (satu chunk), berapa pun ukuran data yang ditulis.
 type EncryptingWriter struct {
-	writer        io.Writer
-	gcm           cipher.AEAD
-	nonce         []byte
-	buffer        *bytes.Buffer
-	headerWritten bool
-	closed        bool
+	writer      io.Writer
+	gcm         cipher.AEAD
+	header      []byte
+	noncePrefix []byte
+	counter     uint32
+	chunkSize   int
+	buffer      []byte
+	sealed      []byte
+	closed      bool
 }
 
 // NewEncryptingWriter membuat writer baru untuk enkripsi streaming
 func NewEncryptingWriter(writer io.Writer, passphrase []byte) (*EncryptingWriter, error) {
-	// Generate salt acak
-	salt := make([]byte, saltSizeBytes)
+	// Generate salt dan nonce prefix acak
+	salt := make([]byte, streamSaltSize)
 	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
 		return nil, fmt.Errorf("gagal generate salt: %w", err)
 	}
-
-	// Derive key dari passphrase dan salt
-	key := pbkdf2.Key(passphrase, salt, pbkdf2Iterations, 32, sha256.New)
-
-	// Inisialisasi AES-GCM
-	block, err := aes.NewCipher(key)
-	if err != nil {
-		return nil, fmt.Errorf("gagal membuat cipher: %w", err)
+	noncePrefix := make([]byte, streamNoncePrefixSize)
+	if _, err := io.ReadFull(rand.Reader, noncePrefix); err != nil {
+		return nil, fmt.Errorf("gagal generate nonce: %w", err)
 	}
 
-	gcm, err := cipher.NewGCM(block)
+	gcm, err := newStreamGCM(passphrase, salt)
 	if err != nil {
-		return nil, fmt.Errorf("gagal membuat GCM: %w", err)
-	}
-
-	// Generate nonce acak
-	nonce := make([]byte, gcm.NonceSize())
-	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
-		return nil, fmt.Errorf("gagal generate nonce: %w", err)
+		return nil, err
 	}
 
-	// Tulis header OpenSSL dan salt
-	opensslHeader := []byte("Salted__")
-	if _, err := writer.Write(opensslHeader); err != nil {
+	header := buildStreamHeader(streamChunkSize, salt, noncePrefix)
+	if _, err := writer.Write(header); err != nil {
 		return nil, fmt.Errorf("gagal menulis header: %w", err)
 	}
-	if _, err := writer.Write(salt); err != nil {
-		return nil, fmt.Errorf("gagal menulis salt: %w", err)
-	}
-
-	// Tulis nonce sebagai bagian awal ciphertext
-	if _, err := writer.Write(nonce); err != nil {
-		return nil, fmt.Errorf("gagal menulis nonce: %w", err)
-	}
 
 	return &EncryptingWriter{
-		writer:        writer,
-		gcm:           gcm,
-		nonce:         nonce,
-		buffer:        bytes.NewBuffer(nil),
-		headerWritten: true,
+		writer:      writer,
+		gcm:         gcm,
+		header:      header,
+		noncePrefix: noncePrefix,
+		chunkSize:   streamChunkSize,
+		buffer:      make([]byte, 0, streamChunkSize),
+		sealed:      make([]byte, 0, streamChunkSize+gcm.Overhead()),
 	}, nil
 }
 
-// Write mengenkripsi data dan menulisnya ke underlying writer
+// Write menampung data ke buffer chunk dan mengenkripsi setiap chunk yang sudah penuh
 func (ew *EncryptingWriter) Write(p []byte) (n int, err error) {
 	if ew.closed {
 		return 0, fmt.Errorf("writer sudah ditutup")
 	}
 
-	// Tambahkan data ke buffer
-	ew.buffer.Write(p)
-	return len(p), nil
+	for len(p) > 0 {
+		// Chunk penuh hanya di-seal saat masih ada data berikutnya,
+		// sehingga chunk terakhir selalu di-seal oleh Close dengan flag final.
+		if len(ew.buffer) == ew.chunkSize {
+			if err := ew.sealChunk(false); err != nil {
+				return n, err
+			}
+		}
+		copied := copy(ew.buffer[len(ew.buffer):ew.chunkSize], p)
+		ew.buffer = ew.buffer[:len(ew.buffer)+copied]
+		p = p[copied:]
+		n += copied
+	}
+	return n, nil
 }
 
-// Close menutup writer dan menulis data terenkripsi terakhir
+// Close mengenkripsi chunk terakhir dengan flag final lalu menutup underlying writer
 func (ew *EncryptingWriter) Close() error {
 	if ew.closed {
 		return nil
 	}
 
-	// Enkripsi semua data yang ada di buffer
-	plaintext := ew.buffer.Bytes()
-
-	// Enkripsi data menggunakan GCM
-	ciphertext := ew.gcm.Seal(nil, ew.nonce, plaintext, nil)
-
-	// Tulis ciphertext ke underlying writer
-	if _, err := ew.writer.Write(ciphertext); err != nil {
-		return fmt.Errorf("gagal menulis ciphertext: %w", err)
+	if err := ew.sealChunk(true); err != nil {
+		return err
 	}
-
 	ew.closed = true
 
 	// Jika underlying writer memiliki Close method, panggil
@@ -115,3 +119,56 @@ func (ew *EncryptingWriter) Close() error {
 
 	return nil
 }
+
+// sealChunk mengenkripsi isi buffer sebagai satu chunk dan menulisnya ke underlying writer
+func (ew *EncryptingWriter) sealChunk(final bool) error {
+	if !final && ew.counter == ^uint32(0) {
+		return fmt.Errorf("jumlah chunk melebihi batas format stream")
+	}
+
+	nonce := buildChunkNonce(ew.noncePrefix, ew.counter, final)
+	ew.sealed = ew.gcm.Seal(ew.sealed[:0], nonce, ew.buffer, ew.header)
+	if _, err := ew.writer.Write(ew.sealed); err != nil {
+		return fmt.Errorf("gagal menulis ciphertext: %w", err)
+	}
+
+	ew.buffer = ew.buffer[:0]
+	ew.counter++
+	return nil
+}
+
+// newStreamGCM menurunkan kunci dari passphrase dan membuat AES-256-GCM
+func newStreamGCM(passphrase, salt []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(deriveKey(passphrase, salt))
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat GCM: %w", err)
+	}
+	return gcm, nil
+}
+
+// buildStreamHeader menyusun header format stream v1
+func buildStreamHeader(chunkSize int, salt, noncePrefix []byte) []byte {
+	header := make([]byte, 0, streamHeaderSize)
+	header = append(header, streamMagic...)
+	header = append(header, streamVersion1)
+	header = binary.BigEndian.AppendUint32(header, uint32(chunkSize))
+	header = append(header, salt...)
+	header = append(header, noncePrefix...)
+	return header
+}
+
+// buildChunkNonce menyusun nonce 12 byte untuk chunk ke-counter
+func buildChunkNonce(noncePrefix []byte, counter uint32, final bool) []byte {
+	nonce := make([]byte, 0, streamNoncePrefixSize+5)
+	nonce = append(nonce, noncePrefix...)
+	nonce = binary.BigEndian.AppendUint32(nonce, counter)
+	if final {
+		return append(nonce, 1)
+	}
+	return append(nonce, 0)
+}
@@ -0,0 +1,96 @@
+// File : pkg/exitcode/exitcode.go
+// Deskripsi : Skema exit code proses sfdbtools dan klasifikasi error menjadi exit code
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+package exitcode
+
+import (
+	"errors"
+	"sfDBTools/pkg/common"
+	"sfDBTools/pkg/fs"
+	"sfDBTools/pkg/input"
+)
+
+// Exit code yang dikembalikan proses sfdbtools
+const (
+	Success       = 0 // Perintah selesai tanpa error
+	Failure       = 1 // Perintah gagal
+	Partial       = 2 // Sebagian pekerjaan berhasil (misalnya beberapa database gagal di-backup)
+	ConfigError   = 3 // Konfigurasi, file konfigurasi database, atau flag tidak valid
+	Cancelled     = 4 // Dibatalkan oleh pengguna (common.ErrUserCancelled)
+	InputRequired = 5 // Prompt membutuhkan jawaban user pada mode non-interaktif
+	LockHeld      = 6 // Proses lain (backup, cleanup, scan, daemon) sedang memegang lock
+)
+
+// Error membawa exit code eksplisit untuk sebuah error
+type Error struct {
+	Code int
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// WithCode menandai err dengan exit code tertentu; err nil tetap nil
+func WithCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Code: code, Err: err}
+}
+
+// AsPartial menandai err sebagai keberhasilan sebagian
+func AsPartial(err error) error {
+	return WithCode(Partial, err)
+}
+
+// AsConfigError menandai err sebagai kesalahan konfigurasi
+func AsConfigError(err error) error {
+	return WithCode(ConfigError, err)
+}
+
+// Code mengembalikan exit code untuk err. Penyebab yang spesifik (pembatalan, mode non-interaktif,
+// lock) didahulukan daripada kode eksplisit yang dibungkus di lapisan atas.
+func Code(err error) int {
+	var coded *Error
+	switch {
+	case err == nil:
+		return Success
+	case errors.Is(err, common.ErrUserCancelled):
+		return Cancelled
+	case errors.Is(err, input.ErrNonInteractive):
+		return InputRequired
+	case fs.IsLockHeld(err):
+		return LockHeld
+	case errors.As(err, &coded):
+		return coded.Code
+	default:
+		return Failure
+	}
+}
+
+// Status mengembalikan nama status untuk exit code (dipakai pada hasil JSON)
+func Status(code int) string {
+	switch code {
+	case Success:
+		return "success"
+	case Partial:
+		return "partial"
+	case ConfigError:
+		return "config_error"
+	case Cancelled:
+		return "cancelled"
+	case InputRequired:
+		return "input_required"
+	case LockHeld:
+		return "lock_held"
+	default:
+		return "failed"
+	}
+}
@@ -50,3 +50,62 @@ func AddBackupDBFlags(cmd *cobra.Command) {
 		os.Exit(1)
 	}
 }
+
+// AddBackupRestoreFlags adds flags specific to the backup restore command
+func AddBackupRestoreFlags(cmd *cobra.Command) {
+	// Gunakan struct kosong sebagai default
+	flagStruct := &structs.BackupRestoreFlags{}
+
+	if err := DynamicAddFlags(cmd, flagStruct); err != nil {
+		fmt.Fprintf(os.Stderr, "Error registering Restore flags dynamically: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// AddBackupVerifyFlags adds flags specific to the backup verify command
+func AddBackupVerifyFlags(cmd *cobra.Command) {
+	flagStruct := &structs.BackupVerifyFlags{}
+
+	if err := DynamicAddFlags(cmd, flagStruct); err != nil {
+		fmt.Fprintf(os.Stderr, "Error registering Verify flags dynamically: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// AddBackupNotifyFlags adds flags specific to the backup notify command
+func AddBackupNotifyFlags(cmd *cobra.Command) {
+	flagStruct := &structs.BackupNotifyFlags{}
+
+	if err := DynamicAddFlags(cmd, flagStruct); err != nil {
+		fmt.Fprintf(os.Stderr, "Error registering Notify flags dynamically: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// AddBackupRetryFlags adds flags specific to the backup retry command
+func AddBackupRetryFlags(cmd *cobra.Command) {
+	flagStruct, err := defaultvalue.GetDefaultBackupRetryFlags()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load backup retry defaults: %v\n", err)
+		flagStruct = &structs.BackupRetryFlags{}
+	}
+
+	if err := DynamicAddFlags(cmd, flagStruct); err != nil {
+		fmt.Fprintf(os.Stderr, "Error registering Retry flags dynamically: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// AddBackupPhysicalFlags adds flags specific to the backup physical command
+func AddBackupPhysicalFlags(cmd *cobra.Command) {
+	flagStruct, err := defaultvalue.GetDefaultBackupPhysicalFlags()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load backup physical defaults: %v\n", err)
+		flagStruct = &structs.BackupPhysicalFlags{}
+	}
+
+	if err := DynamicAddFlags(cmd, flagStruct); err != nil {
+		fmt.Fprintf(os.Stderr, "Error registering Physical flags dynamically: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,30 @@
+// File : pkg/flag/binlog_flag.go
+// Deskripsi : Fungsi utilitas untuk mendaftarkan flags pada perintah binlog
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package flags
+
+import (
+	"fmt"
+	"os"
+	defaultvalue "sfDBTools/internal/default_value"
+	"sfDBTools/internal/structs"
+
+	"github.com/spf13/cobra"
+)
+
+// AddBinlogArchiveFlags adds flags specific to the binlog archive command
+func AddBinlogArchiveFlags(cmd *cobra.Command) {
+	flagStruct, err := defaultvalue.GetDefaultBinlogArchiveFlags()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load binlog defaults: %v\n", err)
+		flagStruct = &structs.BinlogArchiveFlags{}
+	}
+
+	if err := DynamicAddFlags(cmd, flagStruct); err != nil {
+		fmt.Fprintf(os.Stderr, "Error registering Binlog Archive flags dynamically: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -61,4 +61,14 @@ func AddDbScanFlags(cmd *cobra.Command, opts *structs.ScanOptions) {
 		"Simpan hasil scan ke database")
 	cmd.Flags().BoolVar(&opts.Background, "background", opts.Background,
 		"Jalankan scanning di background (async mode)")
+	cmd.Flags().IntVar(&opts.Parallel, "parallel", opts.Parallel,
+		"Jumlah worker pengumpulan detail database (0 = jumlah CPU)")
+
+	// Lock Flags
+	cmd.Flags().BoolVar(&opts.Lock.Wait, "wait", opts.Lock.Wait,
+		"Tunggu scan lain selesai alih-alih langsung gagal")
+	cmd.Flags().BoolVar(&opts.Lock.NoWait, "no-wait", opts.Lock.NoWait,
+		"Langsung gagal jika scan lain sedang berjalan (mengalahkan --wait)")
+	cmd.Flags().IntVar(&opts.Lock.WaitTimeout, "wait-timeout", opts.Lock.WaitTimeout,
+		"Batas waktu menunggu lock dalam detik (0 = tanpa batas)")
 }
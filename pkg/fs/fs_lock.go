@@ -0,0 +1,222 @@
+// File : pkg/fs/fs_lock.go
+// Deskripsi : Lock eksklusif antar proses (flock) dengan PID file agar backup, cleanup, dan dbscan tidak berjalan bersamaan
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package fs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// lockPollInterval adalah jeda antar percobaan mengambil lock saat menunggu
+const lockPollInterval = 2 * time.Second
+
+// ProcessLock adalah lock eksklusif antar proses berbasis flock dengan PID file pendamping.
+// Lock dilepas otomatis oleh kernel jika proses mati, sehingga PID file yang tertinggal hanya informasi.
+type ProcessLock struct {
+	Path     string // Path lock file (<name>.lock)
+	PIDPath  string // Path PID file (<name>.pid)
+	StalePID int    // PID dari PID file basi yang ditemukan saat lock diambil (0 jika tidak ada)
+	file     *os.File
+}
+
+// LockHeldError dikembalikan jika lock sedang dipegang proses lain
+type LockHeldError struct {
+	Path string
+	PID  int    // PID pemegang lock menurut PID file (0 jika tidak diketahui)
+	Host string // Host pemegang lock menurut PID file (kosong jika tidak diketahui)
+}
+
+func (e *LockHeldError) Error() string {
+	if e.PID > 0 && e.Host != "" && e.Host != localHostname() {
+		return fmt.Sprintf("proses lain (PID %d di host %s) sedang berjalan (lockfile=%s)", e.PID, e.Host, e.Path)
+	}
+	if e.PID > 0 {
+		return fmt.Sprintf("proses lain (PID %d) sedang berjalan (lockfile=%s)", e.PID, e.Path)
+	}
+	return fmt.Sprintf("proses lain sedang berjalan (lockfile=%s)", e.Path)
+}
+
+// IsLockHeld mengembalikan true jika err menandakan lock dipegang proses lain
+func IsLockHeld(err error) bool {
+	var held *LockHeldError
+	return errors.As(err, &held)
+}
+
+// AcquireLock mengambil lock eksklusif <dir>/<name>.lock dan menulis PID proses ke <dir>/<name>.pid.
+// Jika wait false, langsung mengembalikan *LockHeldError saat lock dipegang proses lain.
+// Jika wait true, menunggu sampai lock bebas, ctx dibatalkan, atau timeout habis (0 = tanpa batas).
+func AcquireLock(ctx context.Context, dir, name string, wait bool, timeout time.Duration) (*ProcessLock, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("gagal membuat direktori lock %s: %w", dir, err)
+	}
+
+	lock := &ProcessLock{
+		Path:    filepath.Join(dir, name+".lock"),
+		PIDPath: filepath.Join(dir, name+".pid"),
+	}
+	file, err := os.OpenFile(lock.Path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuka lock file %s: %w", lock.Path, err)
+	}
+
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	for {
+		err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, syscall.EWOULDBLOCK) {
+			file.Close()
+			return nil, fmt.Errorf("gagal mengambil lock %s: %w", lock.Path, err)
+		}
+
+		pid, host := readLockOwner(lock.PIDPath)
+		held := &LockHeldError{Path: lock.Path, PID: pid, Host: host}
+		if !wait {
+			file.Close()
+			return nil, held
+		}
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			file.Close()
+			return nil, fmt.Errorf("batas waktu menunggu lock habis: %w", held)
+		}
+		select {
+		case <-ctx.Done():
+			file.Close()
+			return nil, fmt.Errorf("menunggu lock dibatalkan: %w", ctx.Err())
+		case <-time.After(lockPollInterval):
+		}
+	}
+
+	// PID file yang masih ada saat lock berhasil diambil berasal dari proses yang berhenti tanpa cleanup
+	if pid, _ := readLockOwner(lock.PIDPath); pid > 0 && pid != os.Getpid() {
+		lock.StalePID = pid
+	}
+	lock.file = file
+
+	// Hostname ikut ditulis karena lock di direktori bersama (NFS) dapat dipegang proses di host lain
+	owner := strconv.Itoa(os.Getpid()) + "\n" + localHostname() + "\n"
+	if err := os.WriteFile(lock.PIDPath, []byte(owner), 0644); err != nil {
+		lock.Release()
+		return nil, fmt.Errorf("gagal menulis PID file %s: %w", lock.PIDPath, err)
+	}
+	return lock, nil
+}
+
+// LockPolicy menentukan perilaku saat lock dipegang proses lain (--wait/--no-wait/--wait-timeout)
+type LockPolicy struct {
+	Wait    bool          // Tunggu proses lain selesai alih-alih langsung gagal
+	NoWait  bool          // Langsung gagal meskipun Wait aktif
+	Timeout time.Duration // Batas waktu menunggu lock (0 = tanpa batas)
+}
+
+// LockLogger adalah logger minimal untuk AcquireLockWithPolicy (dipenuhi oleh applog.Logger)
+type LockLogger interface {
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+}
+
+// AcquireLockWithPolicy mengambil lock <dir>/<name>.lock sesuai policy. Jika lock dipegang proses lain
+// dan policy tidak menunggu, error menyebut holder (misalnya "backup atau cleanup") dan opsi --wait.
+// PID file basi dari proses yang berhenti tanpa melepas lock dicatat sebagai warning lalu lock diambil alih.
+func AcquireLockWithPolicy(ctx context.Context, dir, name, holder string, policy LockPolicy, logger LockLogger) (*ProcessLock, error) {
+	wait := policy.Wait && !policy.NoWait
+
+	lock, err := AcquireLock(ctx, dir, name, false, 0)
+	if IsLockHeld(err) && wait {
+		logger.Infof("%v, menunggu lock dilepas...", err)
+		lock, err = AcquireLock(ctx, dir, name, true, policy.Timeout)
+	}
+	if err != nil {
+		if IsLockHeld(err) && !wait {
+			return nil, fmt.Errorf("%s lain masih berjalan (gunakan --wait untuk menunggu): %w", holder, err)
+		}
+		return nil, err
+	}
+
+	if lock.StalePID > 0 {
+		logger.Warnf("PID file basi dari proses %d ditemukan (proses berhenti tanpa melepas lock), lock diambil alih", lock.StalePID)
+	}
+	return lock, nil
+}
+
+// Release menghapus PID file dan melepas lock
+func (l *ProcessLock) Release() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+	var firstErr error
+	if err := os.Remove(l.PIDPath); err != nil && !os.IsNotExist(err) {
+		firstErr = fmt.Errorf("gagal menghapus PID file %s: %w", l.PIDPath, err)
+	}
+	if err := syscall.Flock(int(l.file.Fd()), syscall.LOCK_UN); err != nil && firstErr == nil {
+		firstErr = fmt.Errorf("gagal melepaskan lock %s: %w", l.Path, err)
+	}
+	if err := l.file.Close(); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	l.file = nil
+	return firstErr
+}
+
+// ReadLockPID membaca PID dari PID file dan memeriksa apakah proses tersebut masih hidup.
+// Mengembalikan 0 jika PID file tidak ada atau tidak valid.
+// Proses di host lain tidak dapat diperiksa sehingga dianggap masih hidup.
+func ReadLockPID(pidPath string) (int, bool) {
+	pid, host := readLockOwner(pidPath)
+	if pid == 0 {
+		return 0, false
+	}
+	if host != "" && host != localHostname() {
+		return pid, true
+	}
+	return pid, processAlive(pid)
+}
+
+// readLockOwner membaca PID dan hostname dari PID file (format: "<pid>\n<hostname>\n").
+// PID file lama yang hanya berisi PID tetap didukung dengan hostname kosong.
+func readLockOwner(pidPath string) (int, string) {
+	data, err := os.ReadFile(pidPath)
+	if err != nil {
+		return 0, ""
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0, ""
+	}
+	pid, err := strconv.Atoi(fields[0])
+	if err != nil || pid <= 0 {
+		return 0, ""
+	}
+	host := ""
+	if len(fields) > 1 {
+		host = fields[1]
+	}
+	return pid, host
+}
+
+// localHostname mengembalikan hostname lokal (kosong jika tidak dapat dibaca)
+func localHostname() string {
+	host, _ := os.Hostname()
+	return host
+}
+
+// processAlive mengembalikan true jika proses dengan pid masih ada (EPERM: ada namun milik user lain)
+func processAlive(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
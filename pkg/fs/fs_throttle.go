@@ -0,0 +1,98 @@
+// File : pkg/fs/fs_throttle.go
+// Deskripsi : Pembatas kecepatan tulis (token bucket) yang dapat dibagi beberapa writer sekaligus
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package fs
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+)
+
+// RateLimiter membatasi jumlah byte per detik menggunakan token bucket.
+// Satu RateLimiter dapat dipakai bersama oleh banyak writer sehingga batasnya berlaku total.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // byte per detik
+	burst  float64 // kapasitas bucket, satu detik kuota
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter membuat RateLimiter dengan batas bytesPerSecond
+func NewRateLimiter(bytesPerSecond int64) *RateLimiter {
+	return &RateLimiter{
+		rate:   float64(bytesPerSecond),
+		burst:  float64(bytesPerSecond),
+		tokens: float64(bytesPerSecond),
+		last:   time.Now(),
+	}
+}
+
+// ParseBandwidth mem-parse batas bandwidth seperti "50MB", "200MiB", atau "1GB" menjadi byte per detik.
+// String kosong atau "0" berarti tanpa batas dan mengembalikan 0.
+func ParseBandwidth(value string) (int64, error) {
+	value = strings.TrimSuffix(strings.TrimSpace(value), "/s")
+	if value == "" || value == "0" {
+		return 0, nil
+	}
+	n, err := humanize.ParseBytes(value)
+	if err != nil {
+		return 0, fmt.Errorf("format bandwidth tidak valid '%s': %w", value, err)
+	}
+	return int64(n), nil
+}
+
+// Wait memblokir sampai n byte boleh ditulis
+func (l *RateLimiter) Wait(n int) {
+	remaining := float64(n)
+	for remaining > 0 {
+		chunk := remaining
+		if chunk > l.burst {
+			chunk = l.burst
+		}
+
+		l.mu.Lock()
+		now := time.Now()
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.last = now
+		// Token boleh negatif: penulis berikutnya ikut menunggu sampai kuota terbayar
+		l.tokens -= chunk
+		deficit := -l.tokens
+		l.mu.Unlock()
+
+		if deficit > 0 {
+			time.Sleep(time.Duration(deficit / l.rate * float64(time.Second)))
+		}
+		remaining -= chunk
+	}
+}
+
+// throttledWriter meneruskan tulisan ke writer asli setelah kuota RateLimiter tersedia
+type throttledWriter struct {
+	w       io.Writer
+	limiter *RateLimiter
+}
+
+// NewThrottledWriter membungkus w dengan limiter; jika limiter nil, w dikembalikan apa adanya
+func NewThrottledWriter(w io.Writer, limiter *RateLimiter) io.Writer {
+	if limiter == nil {
+		return w
+	}
+	return &throttledWriter{w: w, limiter: limiter}
+}
+
+func (t *throttledWriter) Write(p []byte) (int, error) {
+	t.limiter.Wait(len(p))
+	return t.w.Write(p)
+}
@@ -2,7 +2,7 @@
 // Deskripsi : Fungsi utilitas untuk input interaktif dari user
 // Author : Hadiyatna Muflihun
 // Tanggal : 2024-10-03
-// Last Modified : 2024-10-03
+// Last Modified : 16 Oktober 2026
 package input
 
 import (
@@ -31,6 +31,9 @@ func PrintError(message string) {
 // ShowMenuAndClear displays a menu, gets user choice, then clears screen
 // Menggantikan logika manual di terminal/helpers.go
 func ShowMenu(title string, options []string) (int, error) {
+	if err := RequireInteractive(title, ""); err != nil {
+		return 0, err
+	}
 	var selectedIndex int
 
 	prompt := &survey.Select{
@@ -60,6 +63,9 @@ func ShowMenu(title string, options []string) (int, error) {
 
 // ShowMultiSelect menampilkan menu multi-select dan mengembalikan indeks terpilih (1-based).
 func ShowMultiSelect(title string, options []string) ([]int, error) {
+	if err := RequireInteractive(title, ""); err != nil {
+		return nil, err
+	}
 	var selected []string
 
 	prompt := &survey.MultiSelect{
@@ -96,6 +102,9 @@ func ShowMultiSelect(title string, options []string) ([]int, error) {
 // Menggantikan logika manual golang.org/x/term di terminal/input.go
 // AskPassword menampilkan prompt untuk password.
 func AskPassword(message string, validator survey.Validator) (string, error) {
+	if err := RequireInteractive(message, ""); err != nil {
+		return "", err
+	}
 	answer := ""
 	prompt := &survey.Password{
 		Message: message,
@@ -112,8 +121,16 @@ func AskPassword(message string, validator survey.Validator) (string, error) {
 }
 
 // AskInt menampilkan prompt untuk input integer dengan validasi.
+// Pada mode non-interaktif nilai default dipakai jika lolos validasi.
 func AskInt(message string, defaultValue int, validator survey.Validator) (int, error) {
 	strDefault := fmt.Sprintf("%d", defaultValue)
+	if IsNonInteractive() {
+		if validator != nil && validator(strDefault) != nil {
+			return 0, &NonInteractiveError{Prompt: message}
+		}
+		noticeDefault(message, defaultValue)
+		return defaultValue, nil
+	}
 	answerStr := ""
 	prompt := &survey.Input{
 		Message: message,
@@ -133,7 +150,15 @@ func AskInt(message string, defaultValue int, validator survey.Validator) (int,
 }
 
 // AskString menampilkan prompt untuk input string dengan validasi.
+// Pada mode non-interaktif nilai default dipakai jika lolos validasi.
 func AskString(message, defaultValue string, validator survey.Validator) (string, error) {
+	if IsNonInteractive() {
+		if defaultValue == "" || (validator != nil && validator(defaultValue) != nil) {
+			return "", &NonInteractiveError{Prompt: message}
+		}
+		noticeDefault(message, defaultValue)
+		return defaultValue, nil
+	}
 	answer := ""
 	prompt := &survey.Input{
 		Message: message,
@@ -148,7 +173,13 @@ func AskString(message, defaultValue string, validator survey.Validator) (string
 }
 
 // AskYesNo prompts user for yes/no input with default value
+// Pada mode non-interaktif jawaban adalah ya jika --yes aktif, selain itu nilai default.
 func AskYesNo(question string, defaultValue bool) (bool, error) {
+	if IsNonInteractive() {
+		answer := defaultValue || AssumeYes()
+		noticeDefault(question, answer)
+		return answer, nil
+	}
 	var response bool
 
 	prompt := &survey.Confirm{
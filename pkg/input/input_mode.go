@@ -0,0 +1,76 @@
+// File : pkg/input/input_mode.go
+// Deskripsi : Mode non-interaktif untuk otomasi (cron, systemd, daemon) agar prompt tidak menggantung proses
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+package input
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// ErrNonInteractive menandakan sebuah prompt membutuhkan jawaban user saat mode non-interaktif aktif
+var ErrNonInteractive = errors.New("input interaktif tidak tersedia (mode non-interaktif)")
+
+// NonInteractiveError menjelaskan prompt yang tidak dapat dijawab dan cara menyediakan nilainya
+type NonInteractiveError struct {
+	Prompt string // Pertanyaan yang seharusnya ditampilkan
+	Hint   string // Flag atau env yang dapat dipakai sebagai pengganti prompt
+}
+
+func (e *NonInteractiveError) Error() string {
+	msg := fmt.Sprintf("mode non-interaktif: prompt '%s' membutuhkan jawaban user", e.Prompt)
+	if e.Hint != "" {
+		msg += "; " + e.Hint
+	}
+	return msg
+}
+
+// Is membuat errors.Is(err, ErrNonInteractive) bernilai true
+func (e *NonInteractiveError) Is(target error) bool {
+	return target == ErrNonInteractive
+}
+
+var (
+	nonInteractive bool
+	assumeYes      bool
+)
+
+// SetNonInteractive mengatur mode non-interaktif. Jika yes true, konfirmasi ya/tidak selalu dijawab ya.
+func SetNonInteractive(enabled, yes bool) {
+	nonInteractive = enabled || yes
+	assumeYes = yes
+}
+
+// IsNonInteractive mengembalikan true jika prompt tidak boleh ditampilkan
+func IsNonInteractive() bool {
+	return nonInteractive
+}
+
+// AssumeYes mengembalikan true jika konfirmasi dijawab ya secara otomatis (--yes)
+func AssumeYes() bool {
+	return assumeYes
+}
+
+// StdinIsTerminal mengembalikan true jika stdin terhubung ke terminal (bukan pipe, file, atau /dev/null)
+func StdinIsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// RequireInteractive mengembalikan *NonInteractiveError jika mode non-interaktif aktif.
+// Dipakai sebelum prompt yang tidak memiliki nilai default yang aman.
+func RequireInteractive(prompt, hint string) error {
+	if !nonInteractive {
+		return nil
+	}
+	return &NonInteractiveError{Prompt: prompt, Hint: hint}
+}
+
+// noticeDefault mencatat ke stderr bahwa prompt dijawab otomatis
+func noticeDefault(prompt string, answer interface{}) {
+	fmt.Fprintf(os.Stderr, "Mode non-interaktif: '%s' dijawab otomatis dengan '%v'\n", prompt, answer)
+}
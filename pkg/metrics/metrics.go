@@ -0,0 +1,149 @@
+// File : pkg/metrics/metrics.go
+// Deskripsi : Pengumpul metrik format teks Prometheus untuk textfile node_exporter dan endpoint HTTP /metrics
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Label adalah pasangan nama-nilai label pada satu sampel metrik
+type Label struct {
+	Name  string
+	Value string
+}
+
+// L adalah singkatan untuk membuat Label
+func L(name, value string) Label {
+	return Label{Name: name, Value: value}
+}
+
+type sample struct {
+	labels []Label
+	value  float64
+}
+
+type family struct {
+	name    string
+	help    string
+	kind    string
+	samples []sample
+}
+
+// Collector mengumpulkan gauge dan counter lalu menuliskannya dalam format eksposisi teks Prometheus.
+// Collector tidak aman dipakai bersamaan dari beberapa goroutine; buat satu Collector per scrape atau per file.
+type Collector struct {
+	families map[string]*family
+	order    []string
+}
+
+// NewCollector membuat Collector kosong
+func NewCollector() *Collector {
+	return &Collector{families: make(map[string]*family)}
+}
+
+// Gauge menambahkan sampel gauge
+func (c *Collector) Gauge(name, help string, value float64, labels ...Label) {
+	c.add("gauge", name, help, value, labels)
+}
+
+// Counter menambahkan sampel counter (nilai kumulatif)
+func (c *Collector) Counter(name, help string, value float64, labels ...Label) {
+	c.add("counter", name, help, value, labels)
+}
+
+func (c *Collector) add(kind, name, help string, value float64, labels []Label) {
+	f, ok := c.families[name]
+	if !ok {
+		f = &family{name: name, help: help, kind: kind}
+		c.families[name] = f
+		c.order = append(c.order, name)
+	}
+	f.samples = append(f.samples, sample{labels: labels, value: value})
+}
+
+// WriteTo menulis semua metrik dalam format teks Prometheus
+func (c *Collector) WriteTo(w io.Writer) (int64, error) {
+	var b bytes.Buffer
+	for _, name := range c.order {
+		f := c.families[name]
+		fmt.Fprintf(&b, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+		fmt.Fprintf(&b, "# TYPE %s %s\n", f.name, f.kind)
+		for _, s := range f.samples {
+			b.WriteString(f.name)
+			if len(s.labels) > 0 {
+				parts := make([]string, 0, len(s.labels))
+				for _, l := range s.labels {
+					parts = append(parts, l.Name+`="`+escapeLabel(l.Value)+`"`)
+				}
+				sort.Strings(parts)
+				b.WriteString("{" + strings.Join(parts, ",") + "}")
+			}
+			b.WriteString(" " + formatValue(s.value) + "\n")
+		}
+	}
+	n, err := w.Write(b.Bytes())
+	return int64(n), err
+}
+
+// WriteTextfile menulis metrik ke <dir>/<name> secara atomik (file sementara lalu rename),
+// sehingga textfile collector node_exporter tidak pernah membaca file yang setengah tertulis.
+func (c *Collector) WriteTextfile(dir, name string) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("gagal membuat direktori metrik %s: %w", dir, err)
+	}
+	path := filepath.Join(dir, name)
+	tmp, err := os.CreateTemp(dir, "."+name+".tmp*")
+	if err != nil {
+		return "", fmt.Errorf("gagal membuat file metrik sementara: %w", err)
+	}
+	if _, err := c.WriteTo(tmp); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("gagal menulis file metrik: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("gagal menutup file metrik: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("gagal mengatur permission file metrik: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("gagal memindahkan file metrik ke %s: %w", path, err)
+	}
+	return path, nil
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func escapeHelp(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
+
+func escapeLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`).Replace(s)
+}
@@ -0,0 +1,65 @@
+// File : pkg/metrics/metrics_server.go
+// Deskripsi : HTTP server endpoint /metrics yang mengumpulkan metrik setiap kali di-scrape
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// GatherFunc mengisi Collector dengan metrik terkini; dipanggil pada setiap scrape
+type GatherFunc func(c *Collector) error
+
+// Handler mengembalikan http.Handler yang menjalankan semua gather dan menulis hasilnya.
+// Gather yang gagal dilaporkan lewat metrik sfdbtools_metrics_gather_errors agar scrape tetap berhasil.
+func Handler(gatherers ...GatherFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := NewCollector()
+		failed := 0
+		for _, gather := range gatherers {
+			if err := gather(c); err != nil {
+				failed++
+			}
+		}
+		c.Gauge("sfdbtools_metrics_gather_errors", "Jumlah sumber metrik yang gagal dikumpulkan pada scrape ini", float64(failed))
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		c.WriteTo(w)
+	})
+}
+
+// Serve menjalankan HTTP server metrik pada addr sampai ctx dibatalkan
+func Serve(ctx context.Context, addr, path string, gatherers ...GatherFunc) error {
+	mux := http.NewServeMux()
+	mux.Handle(path, Handler(gatherers...))
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return fmt.Errorf("server metrik gagal: %w", err)
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	}
+}
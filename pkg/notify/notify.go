@@ -0,0 +1,101 @@
+// File : pkg/notify/notify.go
+// Deskripsi : Abstraksi notifikasi (email SMTP dan webhook HTTP) yang dikirim setelah proses selesai
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package notify
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sfDBTools/internal/appconfig"
+	"strings"
+)
+
+// Message adalah isi notifikasi yang sudah dirender oleh pemanggil.
+// Setiap target memilih representasi yang sesuai: email memakai Text/HTML, webhook memakai Fields dan Payload.
+type Message struct {
+	Subject string
+	Status  string  // Status proses (success, partial, failed, empty) yang dicocokkan dengan aturan 'on'
+	Text    string  // Isi plain text
+	HTML    string  // Isi HTML (opsional, fallback ke Text)
+	Fields  []Field // Ringkasan key-value untuk template Slack/Teams
+	Payload any     // Data lengkap yang di-embed pada template webhook json
+}
+
+// Field adalah satu baris ringkasan key-value pada notifikasi
+type Field struct {
+	Name  string
+	Value string
+}
+
+// Notifier adalah satu tujuan notifikasi
+type Notifier interface {
+	// Name mengembalikan nama target untuk log
+	Name() string
+	// Accepts mengembalikan true jika status memenuhi aturan 'on' target
+	Accepts(status string) bool
+	// Send mengirim notifikasi
+	Send(ctx context.Context, msg Message) error
+}
+
+// New membuat semua Notifier yang diaktifkan pada konfigurasi notifications
+func New(cfg appconfig.NotifyConfig) ([]Notifier, error) {
+	var notifiers []Notifier
+	if cfg.Email.Enabled {
+		email, err := NewEmail(cfg.Email)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, email)
+	}
+	for i, wh := range cfg.Webhooks {
+		if !wh.Enabled {
+			continue
+		}
+		webhook, err := NewWebhook(wh)
+		if err != nil {
+			return nil, fmt.Errorf("webhook #%d: %w", i+1, err)
+		}
+		notifiers = append(notifiers, webhook)
+	}
+	return notifiers, nil
+}
+
+// statusRule adalah aturan 'on' bersama: daftar status pemicu, kosong berarti semua status
+type statusRule []string
+
+func (r statusRule) Accepts(status string) bool {
+	if len(r) == 0 {
+		return true
+	}
+	for _, on := range r {
+		if strings.EqualFold(strings.TrimSpace(on), status) {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusColor mengembalikan warna hex (tanpa #) untuk status pada template berwarna
+func StatusColor(status string) string {
+	switch status {
+	case "success":
+		return "2EB67D"
+	case "partial":
+		return "ECB22E"
+	case "failed":
+		return "E01E5A"
+	default:
+		return "808080"
+	}
+}
+
+func envOrValue(value, key string) string {
+	if value != "" {
+		return value
+	}
+	return os.Getenv(key)
+}
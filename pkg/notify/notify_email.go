@@ -0,0 +1,173 @@
+// File : pkg/notify/notify_email.go
+// Deskripsi : Target notifikasi email melalui SMTP (none, STARTTLS, atau TLS langsung)
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package notify
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"sfDBTools/internal/appconfig"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Mode keamanan koneksi SMTP
+const (
+	SecurityNone     = "none"
+	SecurityStartTLS = "starttls"
+	SecurityTLS      = "tls"
+)
+
+// defaultSMTPTimeout dipakai jika notifications.email.timeout tidak diisi
+const defaultSMTPTimeout = 30 * time.Second
+
+// Email mengirim notifikasi ke satu atau lebih penerima melalui server SMTP
+type Email struct {
+	statusRule
+	cfg      appconfig.EmailNotifyConfig
+	password string
+	timeout  time.Duration
+}
+
+// NewEmail membuat target email dan memvalidasi konfigurasi minimum
+func NewEmail(cfg appconfig.EmailNotifyConfig) (*Email, error) {
+	if cfg.Host == "" || cfg.From == "" || len(cfg.To) == 0 {
+		return nil, fmt.Errorf("notifications.email.host, from, dan to wajib diisi")
+	}
+	if cfg.Port == 0 {
+		cfg.Port = 587
+	}
+	cfg.Security = strings.ToLower(strings.TrimSpace(cfg.Security))
+	switch cfg.Security {
+	case "":
+		cfg.Security = SecurityStartTLS
+	case SecurityNone, SecurityStartTLS, SecurityTLS:
+	default:
+		return nil, fmt.Errorf("notifications.email.security tidak dikenal: %s (gunakan none, starttls, atau tls)", cfg.Security)
+	}
+
+	timeout := defaultSMTPTimeout
+	if cfg.Timeout > 0 {
+		timeout = time.Duration(cfg.Timeout) * time.Second
+	}
+	return &Email{
+		statusRule: cfg.On,
+		cfg:        cfg,
+		password:   envOrValue(cfg.Password, "SFDB_SMTP_PASSWORD"),
+		timeout:    timeout,
+	}, nil
+}
+
+func (e *Email) Name() string { return "email" }
+
+// Send mengirim pesan dalam format html (multipart/alternative dengan bagian text) atau text
+func (e *Email) Send(ctx context.Context, msg Message) error {
+	addr := net.JoinHostPort(e.cfg.Host, strconv.Itoa(e.cfg.Port))
+	tlsConfig := &tls.Config{ServerName: e.cfg.Host, InsecureSkipVerify: e.cfg.InsecureSkipVerify}
+
+	dialer := &net.Dialer{Timeout: e.timeout}
+	var conn net.Conn
+	var err error
+	if e.cfg.Security == SecurityTLS {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("gagal koneksi ke SMTP %s: %w", addr, err)
+	}
+	conn.SetDeadline(time.Now().Add(e.timeout))
+
+	client, err := smtp.NewClient(conn, e.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("gagal memulai sesi SMTP: %w", err)
+	}
+	defer client.Close()
+
+	if e.cfg.Security == SecurityStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("server SMTP %s tidak mendukung STARTTLS (gunakan security: none untuk server tanpa TLS)", addr)
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("gagal STARTTLS: %w", err)
+		}
+	}
+	if e.cfg.Username != "" {
+		if ok, _ := client.Extension("AUTH"); ok {
+			if err := client.Auth(smtp.PlainAuth("", e.cfg.Username, e.password, e.cfg.Host)); err != nil {
+				return fmt.Errorf("autentikasi SMTP gagal: %w", err)
+			}
+		}
+	}
+
+	if err := client.Mail(e.cfg.From); err != nil {
+		return fmt.Errorf("SMTP MAIL FROM gagal: %w", err)
+	}
+	for _, to := range e.cfg.To {
+		if err := client.Rcpt(to); err != nil {
+			return fmt.Errorf("SMTP RCPT TO %s gagal: %w", to, err)
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("SMTP DATA gagal: %w", err)
+	}
+	if _, err := w.Write(e.buildMessage(msg)); err != nil {
+		w.Close()
+		return fmt.Errorf("gagal menulis isi email: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("server SMTP menolak email: %w", err)
+	}
+	return client.Quit()
+}
+
+// buildMessage menyusun header dan body MIME email
+func (e *Email) buildMessage(msg Message) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + e.cfg.From + "\r\n")
+	b.WriteString("To: " + strings.Join(e.cfg.To, ", ") + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+
+	if strings.EqualFold(e.cfg.Format, "text") || msg.HTML == "" {
+		b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+		b.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+		b.WriteString(crlf(msg.Text))
+		return []byte(b.String())
+	}
+
+	boundary := randomBoundary()
+	b.WriteString("Content-Type: multipart/alternative; boundary=\"" + boundary + "\"\r\n\r\n")
+	b.WriteString("--" + boundary + "\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\nContent-Transfer-Encoding: 8bit\r\n\r\n")
+	b.WriteString(crlf(msg.Text) + "\r\n")
+	b.WriteString("--" + boundary + "\r\n")
+	b.WriteString("Content-Type: text/html; charset=utf-8\r\nContent-Transfer-Encoding: 8bit\r\n\r\n")
+	b.WriteString(crlf(msg.HTML) + "\r\n")
+	b.WriteString("--" + boundary + "--\r\n")
+	return []byte(b.String())
+}
+
+// crlf menormalkan akhir baris ke CRLF sesuai format pesan SMTP
+func crlf(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "\r\n", "\n"), "\n", "\r\n")
+}
+
+func randomBoundary() string {
+	buf := make([]byte, 12)
+	rand.Read(buf)
+	return "sfdbtools-" + hex.EncodeToString(buf)
+}
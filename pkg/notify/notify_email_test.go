@@ -0,0 +1,238 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"sfDBTools/internal/appconfig"
+)
+
+// smtpSession adalah hasil satu sesi pada server SMTP uji
+type smtpSession struct {
+	Auth  string // Kredensial AUTH PLAIN yang sudah di-decode
+	From  string
+	Rcpts []string
+	Data  string
+}
+
+// smtpSink adalah server SMTP lokal minimal tanpa TLS yang mencatat email yang diterima
+type smtpSink struct {
+	listener   net.Listener
+	rejectRcpt string // Penerima yang ditolak dengan 550
+	sessions   chan smtpSession
+}
+
+func newSMTPSink(t *testing.T, rejectRcpt string) *smtpSink {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	sink := &smtpSink{listener: ln, rejectRcpt: rejectRcpt, sessions: make(chan smtpSession, 1)}
+	t.Cleanup(func() { ln.Close() })
+	go sink.serve()
+	return sink
+}
+
+func (s *smtpSink) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *smtpSink) serve() {
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	var session smtpSession
+	defer func() { s.sessions <- session }()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+	reply("220 localhost ESMTP sink")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		cmd := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250-localhost")
+			reply("250 AUTH PLAIN")
+		case strings.HasPrefix(cmd, "AUTH PLAIN"):
+			decoded, _ := base64.StdEncoding.DecodeString(strings.TrimSpace(line[len("AUTH PLAIN"):]))
+			session.Auth = string(decoded)
+			reply("235 2.7.0 Authentication successful")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			session.From = line[len("MAIL FROM:"):]
+			reply("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			rcpt := line[len("RCPT TO:"):]
+			if s.rejectRcpt != "" && strings.Contains(rcpt, s.rejectRcpt) {
+				reply("550 5.1.1 mailbox unavailable")
+				continue
+			}
+			session.Rcpts = append(session.Rcpts, rcpt)
+			reply("250 OK")
+		case cmd == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				dataLine, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			session.Data = data.String()
+			reply("250 OK queued")
+		case cmd == "RSET", cmd == "NOOP":
+			reply("250 OK")
+		case cmd == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func emailConfig(sink *smtpSink) appconfig.EmailNotifyConfig {
+	return appconfig.EmailNotifyConfig{
+		Host:     "127.0.0.1",
+		Port:     sink.port(),
+		Username: "notifier",
+		Password: "smtp-rahasia",
+		From:     "sfdbtools@example.com",
+		To:       []string{"dba@example.com", "ops@example.com"},
+		Security: SecurityNone,
+		Timeout:  5,
+	}
+}
+
+func sendEmail(t *testing.T, cfg appconfig.EmailNotifyConfig, msg Message) error {
+	t.Helper()
+	email, err := NewEmail(cfg)
+	if err != nil {
+		t.Fatalf("NewEmail: %v", err)
+	}
+	return email.Send(context.Background(), msg)
+}
+
+func TestEmailHTMLMessage(t *testing.T) {
+	sink := newSMTPSink(t, "")
+	msg := testMessage()
+	msg.Text = "baris satu\nbaris dua"
+	msg.HTML = "<p>2 dari 3 database berhasil</p>"
+
+	if err := sendEmail(t, emailConfig(sink), msg); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	session := <-sink.sessions
+	if session.Auth != "\x00notifier\x00smtp-rahasia" {
+		t.Errorf("AUTH PLAIN = %q", session.Auth)
+	}
+	if session.From != "<sfdbtools@example.com>" {
+		t.Errorf("MAIL FROM = %q", session.From)
+	}
+	if strings.Join(session.Rcpts, ",") != "<dba@example.com>,<ops@example.com>" {
+		t.Errorf("RCPT TO = %v", session.Rcpts)
+	}
+	for _, want := range []string{
+		"From: sfdbtools@example.com\r\n",
+		"To: dba@example.com, ops@example.com\r\n",
+		"Subject: Backup gagal sebagian: db01\r\n",
+		"MIME-Version: 1.0\r\n",
+		"Content-Type: multipart/alternative; boundary=\"sfdbtools-",
+		"Content-Type: text/plain; charset=utf-8\r\n",
+		"baris satu\r\nbaris dua\r\n",
+		"Content-Type: text/html; charset=utf-8\r\n",
+		"<p>2 dari 3 database berhasil</p>",
+	} {
+		if !strings.Contains(session.Data, want) {
+			t.Errorf("isi email tidak memuat %q:\n%s", want, session.Data)
+		}
+	}
+}
+
+func TestEmailTextFormat(t *testing.T) {
+	sink := newSMTPSink(t, "")
+	cfg := emailConfig(sink)
+	cfg.Format = "text"
+	msg := testMessage()
+	msg.HTML = "<p>diabaikan</p>"
+
+	if err := sendEmail(t, cfg, msg); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	session := <-sink.sessions
+	if !strings.Contains(session.Data, "Content-Type: text/plain; charset=utf-8\r\n") {
+		t.Errorf("email text tidak memakai text/plain:\n%s", session.Data)
+	}
+	if strings.Contains(session.Data, "multipart/alternative") || strings.Contains(session.Data, "diabaikan") {
+		t.Errorf("email text tidak boleh memuat bagian html:\n%s", session.Data)
+	}
+}
+
+func TestEmailRejectedRecipientIsError(t *testing.T) {
+	sink := newSMTPSink(t, "ops@example.com")
+	err := sendEmail(t, emailConfig(sink), testMessage())
+	<-sink.sessions
+	if err == nil || !strings.Contains(err.Error(), "SMTP RCPT TO ops@example.com gagal") {
+		t.Errorf("error = %v, ingin penolakan RCPT", err)
+	}
+}
+
+func TestEmailStartTLSUnsupportedIsError(t *testing.T) {
+	sink := newSMTPSink(t, "")
+	cfg := emailConfig(sink)
+	cfg.Security = SecurityStartTLS
+	err := sendEmail(t, cfg, testMessage())
+	if err == nil || !strings.Contains(err.Error(), "tidak mendukung STARTTLS") {
+		t.Errorf("error = %v, ingin STARTTLS tidak didukung", err)
+	}
+}
+
+func TestEmailUnreachableIsError(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+
+	cfg := appconfig.EmailNotifyConfig{Host: "127.0.0.1", Port: port, From: "a@example.com", To: []string{"b@example.com"}, Security: SecurityNone, Timeout: 2}
+	err = sendEmail(t, cfg, testMessage())
+	if err == nil || !strings.Contains(err.Error(), "gagal koneksi ke SMTP 127.0.0.1:"+strconv.Itoa(port)) {
+		t.Errorf("error = %v, ingin kegagalan koneksi", err)
+	}
+}
+
+func TestNewEmailValidation(t *testing.T) {
+	if _, err := NewEmail(appconfig.EmailNotifyConfig{Host: "smtp.example.com", From: "a@example.com"}); err == nil {
+		t.Error("NewEmail tanpa penerima berhasil, ingin error")
+	}
+	if _, err := NewEmail(appconfig.EmailNotifyConfig{Host: "smtp.example.com", From: "a@example.com", To: []string{"b@example.com"}, Security: "ssl"}); err == nil {
+		t.Error("NewEmail dengan security tidak dikenal berhasil, ingin error")
+	}
+	email, err := NewEmail(appconfig.EmailNotifyConfig{Host: "smtp.example.com", From: "a@example.com", To: []string{"b@example.com"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if email.cfg.Port != 587 || email.cfg.Security != SecurityStartTLS {
+		t.Errorf("default port/security = %d/%s, ingin 587/starttls", email.cfg.Port, email.cfg.Security)
+	}
+}
@@ -0,0 +1,136 @@
+// File : pkg/notify/notify_webhook.go
+// Deskripsi : Target notifikasi webhook HTTP POST dengan template json, Slack, dan Microsoft Teams
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sfDBTools/internal/appconfig"
+	"strings"
+	"time"
+)
+
+// Template body webhook
+const (
+	TemplateJSON  = "json"
+	TemplateSlack = "slack"
+	TemplateTeams = "teams"
+)
+
+// defaultWebhookTimeout dipakai jika timeout webhook tidak diisi
+const defaultWebhookTimeout = 15 * time.Second
+
+// Webhook mengirim notifikasi sebagai HTTP POST berisi JSON
+type Webhook struct {
+	statusRule
+	cfg    appconfig.WebhookNotifyConfig
+	client *http.Client
+}
+
+// NewWebhook membuat target webhook dan memvalidasi URL serta template
+func NewWebhook(cfg appconfig.WebhookNotifyConfig) (*Webhook, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("url webhook tidak valid: %q", cfg.URL)
+	}
+	cfg.Template = strings.ToLower(strings.TrimSpace(cfg.Template))
+	switch cfg.Template {
+	case "":
+		cfg.Template = TemplateJSON
+	case TemplateJSON, TemplateSlack, TemplateTeams:
+	default:
+		return nil, fmt.Errorf("template webhook tidak dikenal: %s (gunakan json, slack, atau teams)", cfg.Template)
+	}
+	if cfg.Name == "" {
+		cfg.Name = u.Host
+	}
+
+	timeout := defaultWebhookTimeout
+	if cfg.Timeout > 0 {
+		timeout = time.Duration(cfg.Timeout) * time.Second
+	}
+	return &Webhook{
+		statusRule: cfg.On,
+		cfg:        cfg,
+		client:     &http.Client{Timeout: timeout},
+	}, nil
+}
+
+func (w *Webhook) Name() string { return "webhook " + w.cfg.Name }
+
+// Send mem-POST body sesuai template; status HTTP selain 2xx dianggap gagal
+func (w *Webhook) Send(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(w.buildBody(msg))
+	if err != nil {
+		return fmt.Errorf("gagal menyusun body webhook: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("gagal membuat request webhook: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "sfDBTools")
+	for key, value := range w.cfg.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("gagal mengirim webhook %s: %w", w.cfg.Name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook %s membalas HTTP %d: %s", w.cfg.Name, resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
+
+// buildBody menyusun body JSON sesuai template
+func (w *Webhook) buildBody(msg Message) any {
+	switch w.cfg.Template {
+	case TemplateSlack:
+		fields := make([]map[string]any, 0, len(msg.Fields))
+		for _, f := range msg.Fields {
+			fields = append(fields, map[string]any{"title": f.Name, "value": f.Value, "short": len(f.Value) < 40})
+		}
+		return map[string]any{
+			"text": "*" + msg.Subject + "*",
+			"attachments": []map[string]any{{
+				"color":    "#" + StatusColor(msg.Status),
+				"fallback": msg.Subject,
+				"fields":   fields,
+			}},
+		}
+	case TemplateTeams:
+		facts := make([]map[string]string, 0, len(msg.Fields))
+		for _, f := range msg.Fields {
+			facts = append(facts, map[string]string{"name": f.Name, "value": f.Value})
+		}
+		return map[string]any{
+			"@type":      "MessageCard",
+			"@context":   "https://schema.org/extensions",
+			"summary":    msg.Subject,
+			"themeColor": StatusColor(msg.Status),
+			"title":      msg.Subject,
+			"sections":   []map[string]any{{"facts": facts}},
+		}
+	default:
+		return map[string]any{
+			"subject": msg.Subject,
+			"status":  msg.Status,
+			"text":    msg.Text,
+			"data":    msg.Payload,
+		}
+	}
+}
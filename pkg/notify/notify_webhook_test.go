@@ -0,0 +1,186 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"sfDBTools/internal/appconfig"
+)
+
+// capturedRequest adalah request yang diterima server webhook uji
+type capturedRequest struct {
+	Method string
+	Header http.Header
+	Body   map[string]any
+}
+
+// newWebhookServer menjalankan server HTTP lokal yang mencatat request dan membalas dengan status
+func newWebhookServer(t *testing.T, status int, reply string) (*httptest.Server, <-chan capturedRequest) {
+	t.Helper()
+	requests := make(chan capturedRequest, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		var body map[string]any
+		if err := json.Unmarshal(data, &body); err != nil {
+			t.Errorf("body webhook bukan JSON: %v (%s)", err, data)
+		}
+		requests <- capturedRequest{Method: r.Method, Header: r.Header.Clone(), Body: body}
+		w.WriteHeader(status)
+		io.WriteString(w, reply)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, requests
+}
+
+func testMessage() Message {
+	return Message{
+		Subject: "Backup gagal sebagian: db01",
+		Status:  "partial",
+		Text:    "2 dari 3 database berhasil",
+		Fields: []Field{
+			{Name: "Host", Value: "db01:3306"},
+			{Name: "Gagal", Value: "sales"},
+		},
+		Payload: map[string]any{"backup_id": "backup_20261016_010000"},
+	}
+}
+
+func sendWebhook(t *testing.T, cfg appconfig.WebhookNotifyConfig) error {
+	t.Helper()
+	wh, err := NewWebhook(cfg)
+	if err != nil {
+		t.Fatalf("NewWebhook: %v", err)
+	}
+	return wh.Send(context.Background(), testMessage())
+}
+
+func TestWebhookJSONPayloadAndHeaders(t *testing.T) {
+	srv, requests := newWebhookServer(t, http.StatusOK, "ok")
+	err := sendWebhook(t, appconfig.WebhookNotifyConfig{
+		URL:     srv.URL,
+		Headers: map[string]string{"Authorization": "Bearer rahasia"},
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	req := <-requests
+	if req.Method != http.MethodPost {
+		t.Errorf("method = %s, ingin POST", req.Method)
+	}
+	if got := req.Header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q", got)
+	}
+	if got := req.Header.Get("User-Agent"); got != "sfDBTools" {
+		t.Errorf("User-Agent = %q", got)
+	}
+	if got := req.Header.Get("Authorization"); got != "Bearer rahasia" {
+		t.Errorf("Authorization = %q", got)
+	}
+	if req.Body["subject"] != "Backup gagal sebagian: db01" || req.Body["status"] != "partial" || req.Body["text"] != "2 dari 3 database berhasil" {
+		t.Errorf("body json tidak sesuai: %v", req.Body)
+	}
+	data, _ := req.Body["data"].(map[string]any)
+	if data["backup_id"] != "backup_20261016_010000" {
+		t.Errorf("payload data tidak sesuai: %v", req.Body["data"])
+	}
+}
+
+func TestWebhookSlackTemplate(t *testing.T) {
+	srv, requests := newWebhookServer(t, http.StatusOK, "ok")
+	if err := sendWebhook(t, appconfig.WebhookNotifyConfig{URL: srv.URL, Template: "Slack"}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	req := <-requests
+	if req.Body["text"] != "*Backup gagal sebagian: db01*" {
+		t.Errorf("text = %v", req.Body["text"])
+	}
+	attachments, _ := req.Body["attachments"].([]any)
+	if len(attachments) != 1 {
+		t.Fatalf("attachments = %v", req.Body["attachments"])
+	}
+	attachment := attachments[0].(map[string]any)
+	if attachment["color"] != "#"+StatusColor("partial") {
+		t.Errorf("color = %v", attachment["color"])
+	}
+	fields, _ := attachment["fields"].([]any)
+	if len(fields) != 2 || fields[0].(map[string]any)["title"] != "Host" || fields[1].(map[string]any)["value"] != "sales" {
+		t.Errorf("fields = %v", attachment["fields"])
+	}
+}
+
+func TestWebhookTeamsTemplate(t *testing.T) {
+	srv, requests := newWebhookServer(t, http.StatusOK, "1")
+	if err := sendWebhook(t, appconfig.WebhookNotifyConfig{URL: srv.URL, Template: TemplateTeams}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	req := <-requests
+	if req.Body["@type"] != "MessageCard" || req.Body["title"] != "Backup gagal sebagian: db01" {
+		t.Errorf("body teams tidak sesuai: %v", req.Body)
+	}
+	if req.Body["themeColor"] != StatusColor("partial") {
+		t.Errorf("themeColor = %v", req.Body["themeColor"])
+	}
+	sections, _ := req.Body["sections"].([]any)
+	if len(sections) != 1 {
+		t.Fatalf("sections = %v", req.Body["sections"])
+	}
+	facts, _ := sections[0].(map[string]any)["facts"].([]any)
+	if len(facts) != 2 || facts[0].(map[string]any)["name"] != "Host" || facts[0].(map[string]any)["value"] != "db01:3306" {
+		t.Errorf("facts = %v", facts)
+	}
+}
+
+func TestWebhookNon2xxIsError(t *testing.T) {
+	srv, requests := newWebhookServer(t, http.StatusInternalServerError, "internal error\n")
+	err := sendWebhook(t, appconfig.WebhookNotifyConfig{Name: "ops", URL: srv.URL})
+	<-requests
+	if err == nil {
+		t.Fatal("Send berhasil, ingin error untuk HTTP 500")
+	}
+	if !strings.Contains(err.Error(), "webhook ops membalas HTTP 500: internal error") {
+		t.Errorf("error = %v", err)
+	}
+}
+
+func TestWebhookUnreachableIsError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	err := sendWebhook(t, appconfig.WebhookNotifyConfig{Name: "ops", URL: url, Timeout: 2})
+	if err == nil || !strings.Contains(err.Error(), "gagal mengirim webhook ops") {
+		t.Errorf("error = %v, ingin kegagalan koneksi", err)
+	}
+}
+
+func TestNewWebhookValidation(t *testing.T) {
+	for _, cfg := range []appconfig.WebhookNotifyConfig{
+		{URL: "ftp://example.com/hook"},
+		{URL: "http://"},
+		{URL: "https://example.com/hook", Template: "discord"},
+	} {
+		if _, err := NewWebhook(cfg); err == nil {
+			t.Errorf("NewWebhook(%+v) berhasil, ingin error", cfg)
+		}
+	}
+}
+
+func TestStatusRule(t *testing.T) {
+	rule := statusRule{"partial", " FAILED "}
+	for status, want := range map[string]bool{"partial": true, "failed": true, "success": false, "empty": false} {
+		if got := rule.Accepts(status); got != want {
+			t.Errorf("Accepts(%q) = %v, ingin %v", status, got, want)
+		}
+	}
+	if !statusRule(nil).Accepts("success") {
+		t.Error("aturan kosong harus menerima semua status")
+	}
+}
@@ -69,3 +69,69 @@ func ParseBackupSummaryFlags(cmd *cobra.Command) (*structs.BackupSummaryFlags, e
 
 	return summaryFlags, nil
 }
+
+// ParseBackupRestoreFlags mem-parse flags untuk perintah 'backup restore'
+func ParseBackupRestoreFlags(cmd *cobra.Command) (*structs.BackupRestoreFlags, error) {
+	restoreFlags := &structs.BackupRestoreFlags{}
+
+	// Parse flags dinamis ke dalam struct menggunakan refleksi
+	if err := DynamicParseFlags(cmd, restoreFlags); err != nil {
+		return nil, fmt.Errorf("failed to dynamically parse backup restore flags: %w", err)
+	}
+
+	return restoreFlags, nil
+}
+
+// ParseBackupVerifyFlags mem-parse flags untuk perintah 'backup verify'
+func ParseBackupVerifyFlags(cmd *cobra.Command) (*structs.BackupVerifyFlags, error) {
+	verifyFlags := &structs.BackupVerifyFlags{}
+
+	// Parse flags dinamis ke dalam struct menggunakan refleksi
+	if err := DynamicParseFlags(cmd, verifyFlags); err != nil {
+		return nil, fmt.Errorf("failed to dynamically parse backup verify flags: %w", err)
+	}
+
+	return verifyFlags, nil
+}
+
+// ParseBackupNotifyFlags mem-parse flags untuk perintah 'backup notify'
+func ParseBackupNotifyFlags(cmd *cobra.Command) (*structs.BackupNotifyFlags, error) {
+	notifyFlags := &structs.BackupNotifyFlags{}
+
+	// Parse flags dinamis ke dalam struct menggunakan refleksi
+	if err := DynamicParseFlags(cmd, notifyFlags); err != nil {
+		return nil, fmt.Errorf("failed to dynamically parse backup notify flags: %w", err)
+	}
+
+	return notifyFlags, nil
+}
+
+// ParseBackupRetryFlags mem-parse flags untuk perintah 'backup retry'
+func ParseBackupRetryFlags(cmd *cobra.Command) (*structs.BackupRetryFlags, error) {
+	retryFlags, err := defaultvalue.GetDefaultBackupRetryFlags()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load backup retry defaults from config: %w", err)
+	}
+
+	// Parse flags dinamis ke dalam struct menggunakan refleksi
+	if err := DynamicParseFlags(cmd, retryFlags); err != nil {
+		return nil, fmt.Errorf("failed to dynamically parse backup retry flags: %w", err)
+	}
+
+	return retryFlags, nil
+}
+
+// ParseBackupPhysicalFlags mem-parse flags untuk perintah 'backup physical'
+func ParseBackupPhysicalFlags(cmd *cobra.Command) (*structs.BackupPhysicalFlags, error) {
+	physicalFlags, err := defaultvalue.GetDefaultBackupPhysicalFlags()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load backup physical defaults from config: %w", err)
+	}
+
+	// Parse flags dinamis ke dalam struct menggunakan refleksi
+	if err := DynamicParseFlags(cmd, physicalFlags); err != nil {
+		return nil, fmt.Errorf("failed to dynamically parse backup physical flags: %w", err)
+	}
+
+	return physicalFlags, nil
+}
@@ -0,0 +1,29 @@
+// File : pkg/parsing/binlog_parsing.go
+// Deskripsi : Fungsi parsing flags untuk perintah binlog
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package parsing
+
+import (
+	"fmt"
+	defaultvalue "sfDBTools/internal/default_value"
+	"sfDBTools/internal/structs"
+
+	"github.com/spf13/cobra"
+)
+
+// ParseBinlogArchiveFlags mem-parse flags untuk perintah 'binlog archive'
+func ParseBinlogArchiveFlags(cmd *cobra.Command) (*structs.BinlogArchiveFlags, error) {
+	archiveFlags, err := defaultvalue.GetDefaultBinlogArchiveFlags()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load binlog defaults from config: %w", err)
+	}
+
+	if err := DynamicParseFlags(cmd, archiveFlags); err != nil {
+		return nil, fmt.Errorf("failed to dynamically parse binlog archive flags: %w", err)
+	}
+
+	return archiveFlags, nil
+}
@@ -0,0 +1,116 @@
+// File : pkg/storage/storage.go
+// Deskripsi : Abstraksi penyimpanan file backup (local, S3-compatible, SFTP)
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	iofs "io/fs"
+	"os"
+	"sfDBTools/internal/appconfig"
+	"strings"
+	"time"
+)
+
+// Jenis storage yang didukung
+const (
+	TypeLocal = "local"
+	TypeS3    = "s3"
+	TypeSFTP  = "sftp"
+)
+
+// Storage adalah tujuan penyimpanan file backup, summary, dan manifest.
+// Path selalu memakai pemisah "/" dan ditafsirkan di dalam namespace storage masing-masing.
+// Error "tidak ditemukan" dibungkus dengan io/fs.ErrNotExist sehingga dapat diperiksa dengan errors.Is.
+type Storage interface {
+	// Type mengembalikan jenis storage (local, s3, sftp)
+	Type() string
+	// Create membuat file baru untuk ditulis secara streaming; file lama dengan path yang sama ditimpa
+	Create(path string) (Writer, error)
+	// Open membuka file untuk dibaca secara streaming
+	Open(path string) (io.ReadCloser, error)
+	// Stat mengembalikan informasi file
+	Stat(path string) (FileInfo, error)
+	// List mengembalikan semua file (bukan direktori) di bawah dir secara rekursif
+	List(dir string) ([]FileInfo, error)
+	// Remove menghapus satu file
+	Remove(path string) error
+	// MkdirAll membuat direktori beserta parent-nya; no-op untuk object storage
+	MkdirAll(dir string) error
+	// Close menutup koneksi ke storage
+	Close() error
+}
+
+// Writer adalah file yang sedang ditulis. Close menyelesaikan penulisan (termasuk upload multipart),
+// sedangkan Abort membatalkan penulisan dan membuang data parsial.
+type Writer interface {
+	io.WriteCloser
+	Abort() error
+}
+
+// FileInfo adalah informasi file pada storage
+type FileInfo struct {
+	Path    string
+	Size    int64
+	ModTime time.Time
+}
+
+// Name mengembalikan nama file tanpa direktori
+func (f FileInfo) Name() string {
+	return f.Path[strings.LastIndex(f.Path, "/")+1:]
+}
+
+// New membuat Storage sesuai backup.storage.type; kosong berarti local
+func New(cfg appconfig.StorageConfig) (Storage, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Type)) {
+	case "", TypeLocal:
+		return NewLocal(), nil
+	case TypeS3:
+		return NewS3(cfg)
+	case TypeSFTP:
+		return NewSFTP(cfg)
+	default:
+		return nil, fmt.Errorf("jenis storage tidak dikenal: %s (gunakan local, s3, atau sftp)", cfg.Type)
+	}
+}
+
+// WriteFile menulis data ke path dalam satu kali tulis
+func WriteFile(st Storage, path string, data []byte) error {
+	w, err := st.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		w.Abort()
+		return fmt.Errorf("gagal menulis %s: %w", path, err)
+	}
+	return w.Close()
+}
+
+// ReadFile membaca seluruh isi file pada path
+func ReadFile(st Storage, path string) ([]byte, error) {
+	r, err := st.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// IsNotExist memeriksa apakah error menandakan file tidak ditemukan
+func IsNotExist(err error) bool {
+	return errors.Is(err, iofs.ErrNotExist)
+}
+
+// envOrValue mengembalikan value jika terisi, selain itu nilai environment variable key
+func envOrValue(value, key string) string {
+	if value != "" {
+		return value
+	}
+	return os.Getenv(key)
+}
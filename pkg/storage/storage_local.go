@@ -0,0 +1,98 @@
+// File : pkg/storage/storage_local.go
+// Deskripsi : Implementasi Storage untuk disk lokal (termasuk mount NFS)
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package storage
+
+import (
+	"io"
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Local menyimpan file langsung di filesystem lokal
+type Local struct{}
+
+// NewLocal membuat storage lokal
+func NewLocal() *Local {
+	return &Local{}
+}
+
+func (l *Local) Type() string { return TypeLocal }
+
+func (l *Local) Create(path string) (Writer, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &localWriter{File: file}, nil
+}
+
+func (l *Local) Open(path string) (io.ReadCloser, error) {
+	return os.Open(path)
+}
+
+func (l *Local) Stat(path string) (FileInfo, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return FileInfo{}, err
+	}
+	return FileInfo{Path: path, Size: info.Size(), ModTime: info.ModTime()}, nil
+}
+
+func (l *Local) List(dir string) ([]FileInfo, error) {
+	var files []FileInfo
+	err := filepath.WalkDir(dir, func(path string, d iofs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil // File terhapus saat walk
+		}
+		files = append(files, FileInfo{Path: filepath.ToSlash(path), Size: info.Size(), ModTime: info.ModTime()})
+		return nil
+	})
+	return files, err
+}
+
+func (l *Local) Remove(path string) error {
+	return os.Remove(path)
+}
+
+func (l *Local) MkdirAll(dir string) error {
+	return os.MkdirAll(dir, 0755)
+}
+
+func (l *Local) Close() error { return nil }
+
+// localWriter menghapus file parsial saat Abort
+type localWriter struct {
+	*os.File
+	closed bool
+}
+
+func (w *localWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	err := w.File.Close()
+	w.closed = err == nil
+	return err
+}
+
+// Abort setelah Close berhasil tidak berpengaruh
+func (w *localWriter) Abort() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	w.File.Close()
+	return os.Remove(w.File.Name())
+}
@@ -0,0 +1,212 @@
+// File : pkg/storage/storage_s3.go
+// Deskripsi : Implementasi Storage untuk object storage S3-compatible (AWS S3, MinIO, dll)
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	iofs "io/fs"
+	"path"
+	"sfDBTools/internal/appconfig"
+	"strings"
+	"sync"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// defaultS3PartSizeMB dipakai jika backup.storage.s3.part_size_mb tidak diisi.
+// Dengan batas 10.000 part per upload, 64MB cukup untuk object sampai ~640GB.
+const defaultS3PartSizeMB = 64
+
+// errUploadAborted menandai upload yang dibatalkan lewat Writer.Abort
+var errUploadAborted = errors.New("upload dibatalkan")
+
+// S3 menyimpan file sebagai object pada bucket S3-compatible.
+// Path "/a/b/c" dipetakan ke key "<prefix>/a/b/c"; direktori tidak memiliki wujud.
+type S3 struct {
+	client   *minio.Client
+	bucket   string
+	prefix   string
+	partSize uint64
+}
+
+// NewS3 membuat storage S3 dan memastikan bucket dapat diakses
+func NewS3(cfg appconfig.StorageConfig) (*S3, error) {
+	c := cfg.S3
+	if c.Endpoint == "" || c.Bucket == "" {
+		return nil, fmt.Errorf("backup.storage.s3.endpoint dan backup.storage.s3.bucket wajib diisi")
+	}
+
+	client, err := minio.New(c.Endpoint, &minio.Options{
+		Creds: credentials.NewStaticV4(
+			envOrValue(c.AccessKey, "SFDB_S3_ACCESS_KEY"),
+			envOrValue(c.SecretKey, "SFDB_S3_SECRET_KEY"),
+			"",
+		),
+		Secure: c.UseSSL,
+		Region: c.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat client S3: %w", err)
+	}
+
+	exists, err := client.BucketExists(context.Background(), c.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengakses bucket %s: %w", c.Bucket, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("bucket %s tidak ditemukan", c.Bucket)
+	}
+
+	partSizeMB := c.PartSizeMB
+	if partSizeMB <= 0 {
+		partSizeMB = defaultS3PartSizeMB
+	}
+
+	return &S3{
+		client:   client,
+		bucket:   c.Bucket,
+		prefix:   strings.Trim(c.Prefix, "/"),
+		partSize: uint64(partSizeMB) * 1024 * 1024,
+	}, nil
+}
+
+func (s *S3) Type() string { return TypeS3 }
+
+// key mengubah path storage menjadi object key
+func (s *S3) key(p string) string {
+	p = strings.TrimPrefix(path.Clean("/"+p), "/")
+	if s.prefix == "" {
+		return p
+	}
+	return s.prefix + "/" + p
+}
+
+// Create memulai upload streaming. Karena ukuran akhir tidak diketahui, minio-go memakai
+// multipart upload dengan ukuran part partSize; upload diselesaikan saat Close.
+func (s *S3) Create(p string) (Writer, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pr, pw := io.Pipe()
+	w := &s3Writer{pw: pw, cancel: cancel, done: make(chan error, 1)}
+
+	go func() {
+		_, err := s.client.PutObject(ctx, s.bucket, s.key(p), pr, -1, minio.PutObjectOptions{
+			PartSize:    s.partSize,
+			ContentType: "application/octet-stream",
+		})
+		// Hentikan penulis jika upload gagal di tengah jalan
+		pr.CloseWithError(err)
+		w.done <- err
+	}()
+
+	return w, nil
+}
+
+func (s *S3) Open(p string) (io.ReadCloser, error) {
+	obj, err := s.client.GetObject(context.Background(), s.bucket, s.key(p), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, s.wrapError(p, err)
+	}
+	// GetObject bersifat lazy; Stat memastikan object ada sebelum dibaca
+	if _, err := obj.Stat(); err != nil {
+		obj.Close()
+		return nil, s.wrapError(p, err)
+	}
+	return obj, nil
+}
+
+func (s *S3) Stat(p string) (FileInfo, error) {
+	info, err := s.client.StatObject(context.Background(), s.bucket, s.key(p), minio.StatObjectOptions{})
+	if err != nil {
+		return FileInfo{}, s.wrapError(p, err)
+	}
+	return FileInfo{Path: p, Size: info.Size, ModTime: info.LastModified}, nil
+}
+
+func (s *S3) List(dir string) ([]FileInfo, error) {
+	dirKey := s.key(dir)
+	prefix := dirKey + "/"
+	if dirKey == "" {
+		prefix = ""
+	}
+
+	var files []FileInfo
+	for obj := range s.client.ListObjects(context.Background(), s.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if obj.Err != nil {
+			return nil, fmt.Errorf("gagal membaca daftar object %s: %w", prefix, obj.Err)
+		}
+		if strings.HasSuffix(obj.Key, "/") {
+			continue // Placeholder direktori
+		}
+		files = append(files, FileInfo{
+			Path:    path.Join(dir, strings.TrimPrefix(obj.Key, prefix)),
+			Size:    obj.Size,
+			ModTime: obj.LastModified,
+		})
+	}
+	return files, nil
+}
+
+func (s *S3) Remove(p string) error {
+	if err := s.client.RemoveObject(context.Background(), s.bucket, s.key(p), minio.RemoveObjectOptions{}); err != nil {
+		return s.wrapError(p, err)
+	}
+	return nil
+}
+
+func (s *S3) MkdirAll(dir string) error { return nil }
+
+func (s *S3) Close() error { return nil }
+
+// wrapError memetakan NoSuchKey ke fs.ErrNotExist
+func (s *S3) wrapError(p string, err error) error {
+	switch minio.ToErrorResponse(err).Code {
+	case "NoSuchKey", "NoSuchObject", "NotFound":
+		return &iofs.PathError{Op: "s3", Path: p, Err: iofs.ErrNotExist}
+	}
+	return fmt.Errorf("s3 %s: %w", p, err)
+}
+
+// s3Writer meneruskan data ke PutObject yang berjalan di goroutine terpisah
+type s3Writer struct {
+	pw     *io.PipeWriter
+	cancel context.CancelFunc
+	done   chan error
+	once   sync.Once
+	err    error
+}
+
+func (w *s3Writer) Write(p []byte) (int, error) {
+	return w.pw.Write(p)
+}
+
+// Close menandai akhir data lalu menunggu upload selesai
+func (w *s3Writer) Close() error {
+	w.once.Do(func() {
+		w.pw.Close()
+		if err := <-w.done; err != nil {
+			w.err = fmt.Errorf("gagal mengunggah object: %w", err)
+		}
+		w.cancel()
+	})
+	return w.err
+}
+
+// Abort membatalkan upload; minio-go membatalkan multipart upload yang belum selesai.
+// Abort setelah Close tidak berpengaruh.
+func (w *s3Writer) Abort() error {
+	w.once.Do(func() {
+		w.pw.CloseWithError(errUploadAborted)
+		w.cancel()
+		<-w.done
+		w.err = errUploadAborted
+	})
+	return nil
+}
@@ -0,0 +1,185 @@
+// File : pkg/storage/storage_sftp.go
+// Deskripsi : Implementasi Storage untuk server SFTP
+// Author : Hadiyatna Muflihun
+// Tanggal : 16 Oktober 2026
+// Last Modified : 16 Oktober 2026
+
+package storage
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sfDBTools/internal/appconfig"
+	"strconv"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// sftpDialTimeout adalah batas waktu koneksi SSH
+const sftpDialTimeout = 30 * time.Second
+
+// SFTP menyimpan file pada server remote melalui SFTP; path adalah path di server remote
+type SFTP struct {
+	conn   *ssh.Client
+	client *sftp.Client
+}
+
+// NewSFTP membuka koneksi SSH lalu sesi SFTP.
+// Host key diverifikasi terhadap known_hosts kecuali insecure_ignore_host_key diaktifkan.
+func NewSFTP(cfg appconfig.StorageConfig) (*SFTP, error) {
+	c := cfg.SFTP
+	if c.Host == "" || c.User == "" {
+		return nil, fmt.Errorf("backup.storage.sftp.host dan backup.storage.sftp.user wajib diisi")
+	}
+	port := c.Port
+	if port == 0 {
+		port = 22
+	}
+
+	auth, err := sftpAuthMethods(c.PrivateKeyFile, envOrValue(c.Password, "SFDB_SFTP_PASSWORD"))
+	if err != nil {
+		return nil, err
+	}
+
+	hostKeyCallback := ssh.InsecureIgnoreHostKey()
+	if !c.InsecureIgnoreHostKey {
+		knownHostsFile := c.KnownHostsFile
+		if knownHostsFile == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, fmt.Errorf("gagal menentukan home directory untuk known_hosts: %w", err)
+			}
+			knownHostsFile = filepath.Join(home, ".ssh", "known_hosts")
+		}
+		hostKeyCallback, err = knownhosts.New(knownHostsFile)
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca known_hosts %s: %w", knownHostsFile, err)
+		}
+	}
+
+	addr := net.JoinHostPort(c.Host, strconv.Itoa(port))
+	conn, err := ssh.Dial("tcp", addr, &ssh.ClientConfig{
+		User:            c.User,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         sftpDialTimeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gagal terhubung ke SFTP %s: %w", addr, err)
+	}
+
+	client, err := sftp.NewClient(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("gagal membuka sesi SFTP %s: %w", addr, err)
+	}
+
+	return &SFTP{conn: conn, client: client}, nil
+}
+
+// sftpAuthMethods menyusun metode autentikasi dari private key dan/atau password
+func sftpAuthMethods(privateKeyFile, password string) ([]ssh.AuthMethod, error) {
+	var methods []ssh.AuthMethod
+	if privateKeyFile != "" {
+		key, err := os.ReadFile(privateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca private key %s: %w", privateKeyFile, err)
+		}
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("gagal mem-parse private key %s: %w", privateKeyFile, err)
+		}
+		methods = append(methods, ssh.PublicKeys(signer))
+	}
+	if password != "" {
+		methods = append(methods, ssh.Password(password))
+	}
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("backup.storage.sftp.private_key_file atau password (SFDB_SFTP_PASSWORD) wajib diisi")
+	}
+	return methods, nil
+}
+
+func (s *SFTP) Type() string { return TypeSFTP }
+
+func (s *SFTP) Create(path string) (Writer, error) {
+	file, err := s.client.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat file remote %s: %w", path, err)
+	}
+	return &sftpWriter{File: file, client: s.client, path: path}, nil
+}
+
+func (s *SFTP) Open(path string) (io.ReadCloser, error) {
+	return s.client.Open(path)
+}
+
+func (s *SFTP) Stat(path string) (FileInfo, error) {
+	info, err := s.client.Stat(path)
+	if err != nil {
+		return FileInfo{}, err
+	}
+	return FileInfo{Path: path, Size: info.Size(), ModTime: info.ModTime()}, nil
+}
+
+func (s *SFTP) List(dir string) ([]FileInfo, error) {
+	var files []FileInfo
+	walker := s.client.Walk(dir)
+	for walker.Step() {
+		if err := walker.Err(); err != nil {
+			return nil, err
+		}
+		info := walker.Stat()
+		if info.IsDir() {
+			continue
+		}
+		files = append(files, FileInfo{Path: walker.Path(), Size: info.Size(), ModTime: info.ModTime()})
+	}
+	return files, nil
+}
+
+func (s *SFTP) Remove(path string) error {
+	return s.client.Remove(path)
+}
+
+func (s *SFTP) MkdirAll(dir string) error {
+	return s.client.MkdirAll(dir)
+}
+
+func (s *SFTP) Close() error {
+	s.client.Close()
+	return s.conn.Close()
+}
+
+// sftpWriter menghapus file remote parsial saat Abort
+type sftpWriter struct {
+	*sftp.File
+	client *sftp.Client
+	path   string
+	closed bool
+}
+
+func (w *sftpWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	err := w.File.Close()
+	w.closed = err == nil
+	return err
+}
+
+// Abort setelah Close berhasil tidak berpengaruh
+func (w *sftpWriter) Abort() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	w.File.Close()
+	return w.client.Remove(w.path)
+}